@@ -0,0 +1,176 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import "github.com/ava-labs/hypersdk/chain"
+
+// Names used to refer to actions in the upgrade schedule.
+const (
+	TransferName            = "Transfer"
+	CreateAssetName         = "CreateAsset"
+	MintAssetName           = "MintAsset"
+	BurnAssetName           = "BurnAsset"
+	ModifyAssetName         = "ModifyAsset"
+	CreateOrderName         = "CreateOrder"
+	FillOrderName           = "FillOrder"
+	CloseOrderName          = "CloseOrder"
+	ImportAssetName         = "ImportAsset"
+	ExportAssetName         = "ExportAsset"
+	SendMessageName         = "SendMessage"
+	ReceiveMessageName      = "ReceiveMessage"
+	BatchExportAssetName    = "BatchExportAsset"
+	BatchImportAssetName    = "BatchImportAsset"
+	SetWarpDestinationsName = "SetWarpDestinations"
+	PauseAssetName          = "PauseAsset"
+	FreezeAccountName       = "FreezeAccount"
+	ClawbackAssetName       = "ClawbackAsset"
+	SetAllowlistModeName    = "SetAllowlistMode"
+	SetAllowlistName        = "SetAllowlist"
+	CreateVestingName       = "CreateVesting"
+	ClaimVestedName         = "ClaimVested"
+	ProposeName             = "Propose"
+	VoteName                = "Vote"
+	ExecuteProposalName     = "ExecuteProposal"
+	WithdrawVoteName        = "WithdrawVote"
+	SwapOfferName           = "SwapOffer"
+	SetSponsorRateName      = "SetSponsorRate"
+	RegisterSessionName     = "RegisterSession"
+	RevokeSessionName       = "RevokeSession"
+	SetGuardiansName        = "SetGuardians"
+	InitiateRecoveryName    = "InitiateRecovery"
+	CancelRecoveryName      = "CancelRecovery"
+	CompleteRecoveryName    = "CompleteRecovery"
+
+	RegisterNameName          = "RegisterName"
+	UpdateNameName            = "UpdateName"
+	TransferNameOwnershipName = "TransferNameOwnership"
+	RenewNameName             = "RenewName"
+
+	CreateCollectionName = "CreateCollection"
+	MintNFTName          = "MintNFT"
+	TransferNFTName      = "TransferNFT"
+	BurnNFTName          = "BurnNFT"
+
+	CreateStreamName   = "CreateStream"
+	WithdrawStreamName = "WithdrawStream"
+	CancelStreamName   = "CancelStream"
+
+	CreateAirdropName  = "CreateAirdrop"
+	ClaimAirdropName   = "ClaimAirdrop"
+	ReclaimAirdropName = "ReclaimAirdrop"
+
+	CreateEscrowName  = "CreateEscrow"
+	ResolveEscrowName = "ResolveEscrow"
+
+	CreateFeedName = "CreateFeed"
+	UpdateFeedName = "UpdateFeed"
+
+	CreateTriggerOrderName = "CreateTriggerOrder"
+	TriggerOrderName       = "TriggerOrder"
+	CancelTriggerOrderName = "CancelTriggerOrder"
+
+	SettleFeesName = "SettleFees"
+
+	ReclaimDepositName = "ReclaimDeposit"
+)
+
+// Names contains the name of every action that can be enabled or disabled by
+// the upgrade schedule.
+var Names = []string{
+	TransferName,
+	CreateAssetName,
+	MintAssetName,
+	BurnAssetName,
+	ModifyAssetName,
+	CreateOrderName,
+	FillOrderName,
+	CloseOrderName,
+	ImportAssetName,
+	ExportAssetName,
+	SendMessageName,
+	ReceiveMessageName,
+	BatchExportAssetName,
+	BatchImportAssetName,
+	SetWarpDestinationsName,
+	PauseAssetName,
+	FreezeAccountName,
+	ClawbackAssetName,
+	SetAllowlistModeName,
+	SetAllowlistName,
+	CreateVestingName,
+	ClaimVestedName,
+	ProposeName,
+	VoteName,
+	ExecuteProposalName,
+	WithdrawVoteName,
+	SwapOfferName,
+	SetSponsorRateName,
+	RegisterSessionName,
+	RevokeSessionName,
+	SetGuardiansName,
+	InitiateRecoveryName,
+	CancelRecoveryName,
+	CompleteRecoveryName,
+	RegisterNameName,
+	UpdateNameName,
+	TransferNameOwnershipName,
+	RenewNameName,
+	CreateCollectionName,
+	MintNFTName,
+	TransferNFTName,
+	BurnNFTName,
+	CreateStreamName,
+	WithdrawStreamName,
+	CancelStreamName,
+	CreateAirdropName,
+	ClaimAirdropName,
+	ReclaimAirdropName,
+	CreateEscrowName,
+	ResolveEscrowName,
+	CreateFeedName,
+	UpdateFeedName,
+	CreateTriggerOrderName,
+	TriggerOrderName,
+	CancelTriggerOrderName,
+	SettleFeesName,
+	ReclaimDepositName,
+}
+
+const activationPrefix = "activation/"
+
+// ActivationWindow is the range of timestamps during which an action can be
+// included in a block. A value of -1 means there is no start/end.
+type ActivationWindow struct {
+	Start int64
+	End   int64
+}
+
+// ActivationKey is the key used to look up the [ActivationWindow] of the
+// action named [name] with [chain.Rules.FetchCustom].
+func ActivationKey(name string) string {
+	return activationPrefix + name
+}
+
+// ActivationName returns the action name referenced by [key], if [key] was
+// created with [ActivationKey].
+func ActivationName(key string) (string, bool) {
+	if len(key) <= len(activationPrefix) || key[:len(activationPrefix)] != activationPrefix {
+		return "", false
+	}
+	return key[len(activationPrefix):], true
+}
+
+// validRange returns the activation window of the action named [name]. If
+// the rules do not specify one, the action is always valid.
+func validRange(r chain.Rules, name string) (int64, int64) {
+	v, ok := r.FetchCustom(ActivationKey(name))
+	if !ok {
+		return -1, -1
+	}
+	w, ok := v.(*ActivationWindow)
+	if !ok {
+		return -1, -1
+	}
+	return w.Start, w.End
+}
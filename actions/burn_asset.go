@@ -46,27 +46,30 @@ func (b *BurnAsset) Execute(
 	_ ids.ID,
 	_ bool,
 ) (*chain.Result, error) {
-	actor := auth.GetActor(rauth)
 	unitsUsed := b.MaxUnits(r) // max units == units
 	if b.Value == 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, b.Asset, b.Value); err != nil {
+	if err := spend(ctx, db, rauth, b.Asset, b.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	exists, metadata, supply, owner, warp, err := storage.GetAsset(ctx, db, b.Asset)
+	exists, metadata, supply, owner, warp, flags, err := storage.GetAsset(ctx, db, b.Asset)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if !exists {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
 	}
+	if flags&storage.AssetNonFungible != 0 {
+		// NFTs must be burned with [BurnNFT] so that their records are removed
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNonFungible}, nil
+	}
 	newSupply, err := smath.Sub(supply, b.Value)
 	if err != nil {
 		// This should never fail
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.SetAsset(ctx, db, b.Asset, metadata, newSupply, owner, warp); err != nil {
+	if err := storage.SetAsset(ctx, db, b.Asset, metadata, newSupply, owner, warp, flags); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	return &chain.Result{Success: true, Units: unitsUsed}, nil
@@ -90,7 +93,6 @@ func UnmarshalBurnAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error)
 	return &burn, p.Err()
 }
 
-func (*BurnAsset) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*BurnAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, BurnAssetName)
 }
@@ -0,0 +1,118 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*BurnNFT)(nil)
+
+// BurnNFT destroys token [ID] of [Collection], which must be owned by the
+// actor.
+type BurnNFT struct {
+	// Collection is the collection of the NFT.
+	Collection ids.ID `json:"collection"`
+
+	// ID is the ID of the NFT in [Collection].
+	ID uint64 `json:"id"`
+}
+
+func (b *BurnNFT) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	nft := NFTID(b.Collection, b.ID)
+	return [][]byte{
+		storage.PrefixCollectionKey(b.Collection),
+		storage.PrefixAssetKey(nft),
+		storage.PrefixNFTKey(nft),
+		storage.PrefixBalanceKey(actor, nft),
+	}
+}
+
+func (b *BurnNFT) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := b.MaxUnits(r) // max units == units
+	nft := NFTID(b.Collection, b.ID)
+	exists, _, _, _, err := storage.GetNFT(ctx, db, nft)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTMissing}, nil
+	}
+	if err := spend(ctx, db, rauth, nft, 1); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.DeleteNFT(ctx, db, nft); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	// We keep the asset (with no supply) so that [ID] can't be minted again
+	_, metadata, _, owner, warp, flags, err := storage.GetAsset(ctx, db, nft)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetAsset(ctx, db, nft, metadata, 0, owner, warp, flags); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	_, collectionOwner, supply, collectionMetadata, err := storage.GetCollection(ctx, db, b.Collection)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	newSupply, err := smath.Sub(supply, 1)
+	if err != nil {
+		// This should never fail
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetCollection(
+		ctx,
+		db,
+		b.Collection,
+		collectionOwner,
+		newSupply,
+		collectionMetadata,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*BurnNFT) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len
+}
+
+func (b *BurnNFT) Marshal(p *codec.Packer) {
+	p.PackID(b.Collection)
+	p.PackUint64(b.ID)
+}
+
+func UnmarshalBurnNFT(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var burn BurnNFT
+	p.UnpackID(true, &burn.Collection)
+	burn.ID = p.UnpackUint64(false) // 0 is a valid ID
+	return &burn, p.Err()
+}
+
+func (*BurnNFT) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, BurnNFTName)
+}
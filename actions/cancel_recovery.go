@@ -0,0 +1,71 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CancelRecovery)(nil)
+
+// CancelRecovery stops a recovery of the actor's account that was initiated
+// with [InitiateRecovery] but has not been completed.
+type CancelRecovery struct{}
+
+func (*CancelRecovery) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{storage.PrefixRecoveryKey(actor)}
+}
+
+func (c *CancelRecovery) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if _, ok := rauth.(*auth.Session); ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionNotAllowed}, nil
+	}
+	pending, _, _, err := storage.GetRecovery(ctx, db, actor)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !pending {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputRecoveryMissing}, nil
+	}
+	if err := storage.DeleteRecovery(ctx, db, actor); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CancelRecovery) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return 1
+}
+
+func (*CancelRecovery) Marshal(*codec.Packer) {}
+
+func UnmarshalCancelRecovery(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	return &CancelRecovery{}, p.Err()
+}
+
+func (*CancelRecovery) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CancelRecoveryName)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CancelStream)(nil)
+
+// CancelStream stops a stream created by the actor with [CreateStream] and
+// returns everything that has not yet been streamed to the actor.
+//
+// Whatever has already been streamed (but not withdrawn) stays in the stream
+// and can still be withdrawn by the recipient with [WithdrawStream].
+type CancelStream struct {
+	// Stream is the [TxID] that created the stream.
+	Stream ids.ID `json:"stream"`
+
+	// Asset is the asset locked in the stream. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (c *CancelStream) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		receiveKeys(c.Asset, actor),
+		storage.PrefixStreamKey(c.Stream),
+		storage.PrefixBalanceKey(actor, c.Asset),
+	)
+}
+
+func (c *CancelStream) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, asset, sender, recipient, amount, start, end, withdrawn, err := storage.GetStream(ctx, db, c.Stream)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputStreamMissing}, nil
+	}
+	if sender != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	streamed := StreamedAmount(amount, start, end, t)
+	if streamed == amount {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputStreamEnded}, nil
+	}
+	// End the stream now with only what has already been streamed. [t] must be
+	// after [start] because something has been streamed.
+	if output := claimStream(
+		ctx, db, c.Stream, asset, sender, recipient,
+		streamed, start, t, withdrawn, actor, amount-streamed,
+	); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CancelStream) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (c *CancelStream) Marshal(p *codec.Packer) {
+	p.PackID(c.Stream)
+	p.PackID(c.Asset)
+}
+
+func UnmarshalCancelStream(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var cancel CancelStream
+	p.UnpackID(true, &cancel.Stream)
+	p.UnpackID(false, &cancel.Asset) // empty ID is the native asset
+	return &cancel, p.Err()
+}
+
+func (*CancelStream) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CancelStreamName)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CancelTriggerOrder)(nil)
+
+// CancelTriggerOrder refunds everything locked in a trigger order created by
+// the actor with [CreateTriggerOrder] (including the reward).
+type CancelTriggerOrder struct {
+	// [Trigger] is the [TxID] that created the trigger order.
+	Trigger ids.ID `json:"trigger"`
+
+	// [In] is the asset locked in the trigger order. We need to provide this
+	// to populate [StateKeys].
+	In ids.ID `json:"in"`
+}
+
+func (c *CancelTriggerOrder) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		receiveKeys(c.In, actor),
+		storage.PrefixTriggerOrderKey(c.Trigger),
+		storage.PrefixBalanceKey(actor, c.In),
+	)
+}
+
+func (c *CancelTriggerOrder) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, in, _, owner, value, reward, _, _, _, _, _, err := storage.GetTriggerOrder(ctx, db, c.Trigger)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputTriggerOrderMissing}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if in != c.In {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongIn}, nil
+	}
+	if output := checkReceivable(ctx, db, c.In, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	locked, err := smath.Add64(value, reward)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.DeleteTriggerOrder(ctx, db, c.Trigger); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, c.In, locked); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CancelTriggerOrder) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (c *CancelTriggerOrder) Marshal(p *codec.Packer) {
+	p.PackID(c.Trigger)
+	p.PackID(c.In)
+}
+
+func UnmarshalCancelTriggerOrder(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var cancel CancelTriggerOrder
+	p.UnpackID(true, &cancel.Trigger)
+	p.UnpackID(false, &cancel.In) // empty ID is the native asset
+	return &cancel, p.Err()
+}
+
+func (*CancelTriggerOrder) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CancelTriggerOrderName)
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ClaimAirdrop)(nil)
+
+// ClaimAirdrop sends [Amount] from an airdrop created by [CreateAirdrop] to
+// the actor if [Proof] shows that the actor was allocated [Amount]. Each
+// account can only claim once from each airdrop.
+type ClaimAirdrop struct {
+	// Airdrop is the [TxID] that created the airdrop.
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Asset is the asset locked in the airdrop. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Amount is the amount allocated to the actor.
+	Amount uint64 `json:"amount"`
+
+	// Proof is the list of siblings from the leaf of the actor to the root of
+	// the airdrop.
+	Proof []ids.ID `json:"proof"`
+}
+
+func (c *ClaimAirdrop) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		receiveKeys(c.Asset, actor),
+		storage.PrefixAirdropKey(c.Airdrop),
+		storage.PrefixAirdropClaimKey(c.Airdrop, actor),
+		storage.PrefixBalanceKey(actor, c.Asset),
+	)
+}
+
+func (c *ClaimAirdrop) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, asset, creator, root, expiry, remaining, err := storage.GetAirdrop(ctx, db, c.Airdrop)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropMissing}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropExpired}, nil
+	}
+	claimed, err := storage.GetAirdropClaimed(ctx, db, c.Airdrop, actor)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if claimed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropClaimed}, nil
+	}
+	if !VerifyAirdropProof(root, AirdropLeaf(actor, c.Amount), c.Proof) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidProof}, nil
+	}
+	// This can only happen if the creator did not lock enough for every leaf
+	if c.Amount > remaining {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropDepleted}, nil
+	}
+	if output := checkReceivable(ctx, db, asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SetAirdropClaimed(ctx, db, c.Airdrop, actor); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetAirdrop(
+		ctx, db, c.Airdrop, asset, creator,
+		root, expiry, remaining-c.Amount,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, c.Amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (c *ClaimAirdrop) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + consts.Uint64Len + consts.IntLen + uint64(len(c.Proof))*consts.IDLen
+}
+
+func (c *ClaimAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Airdrop)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Amount)
+	p.PackInt(len(c.Proof))
+	for _, sibling := range c.Proof {
+		p.PackID(sibling)
+	}
+}
+
+func UnmarshalClaimAirdrop(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var claim ClaimAirdrop
+	p.UnpackID(true, &claim.Airdrop)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	claim.Amount = p.UnpackUint64(true)
+	count := p.UnpackInt(false) // a tree with 1 leaf has an empty proof
+	if count > MaxAirdropProofSize {
+		return nil, ErrProofTooLarge
+	}
+	claim.Proof = make([]ids.ID, count)
+	for i := range claim.Proof {
+		p.UnpackID(false, &claim.Proof[i])
+	}
+	return &claim, p.Err()
+}
+
+func (*ClaimAirdrop) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ClaimAirdropName)
+}
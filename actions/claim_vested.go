@@ -0,0 +1,142 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"math"
+	"math/bits"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ClaimVested)(nil)
+
+// ClaimVested sends everything that has vested (and has not yet been claimed)
+// from a schedule created by [CreateVesting] to its beneficiary.
+type ClaimVested struct {
+	// Vesting is the [TxID] that created the vesting schedule.
+	Vesting ids.ID `json:"vesting"`
+
+	// Asset is the asset locked in the schedule. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (c *ClaimVested) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		receiveKeys(c.Asset, actor),
+		storage.PrefixVestingKey(c.Vesting),
+		storage.PrefixBalanceKey(actor, c.Asset),
+	)
+}
+
+func (c *ClaimVested) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, asset, beneficiary, amount, start, cliff, duration, claimed, err := storage.GetVesting(ctx, db, c.Vesting)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputVestingMissing}, nil
+	}
+	if beneficiary != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	vested := VestedAmount(amount, start, cliff, duration, t)
+	if vested <= claimed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNothingVested}, nil
+	}
+	if output := checkReceivable(ctx, db, asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if vested == amount {
+		if err := storage.DeleteVesting(ctx, db, c.Vesting); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	} else {
+		if err := storage.SetVesting(
+			ctx, db, c.Vesting, asset, beneficiary,
+			amount, start, cliff, duration, vested,
+		); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, vested-claimed); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*ClaimVested) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (c *ClaimVested) Marshal(p *codec.Packer) {
+	p.PackID(c.Vesting)
+	p.PackID(c.Asset)
+}
+
+func UnmarshalClaimVested(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var claim ClaimVested
+	p.UnpackID(true, &claim.Vesting)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	return &claim, p.Err()
+}
+
+func (*ClaimVested) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ClaimVestedName)
+}
+
+// ValidVestingSchedule returns true if [cliff] and [duration] describe a
+// schedule that eventually releases everything it holds. The schedule must end
+// before the maximum timestamp so that [start] + [duration] cannot overflow.
+func ValidVestingSchedule(start int64, cliff int64, duration int64) bool {
+	return start >= 0 && duration > 0 && duration <= math.MaxInt64-start &&
+		cliff >= 0 && cliff <= duration
+}
+
+// VestedAmount returns how much of [amount] has vested at [t] (whether or not
+// it has been claimed). Nothing vests under an invalid schedule.
+func VestedAmount(amount uint64, start int64, cliff int64, duration int64, t int64) uint64 {
+	if !ValidVestingSchedule(start, cliff, duration) || t < start+cliff {
+		return 0
+	}
+	elapsed := t - start
+	if elapsed >= duration {
+		return amount
+	}
+	// [amount] * [elapsed] may overflow a uint64, so we perform the
+	// multiplication with 128 bits. [elapsed] is less than [duration], so
+	// [Div64] will not panic.
+	hi, lo := bits.Mul64(amount, uint64(elapsed))
+	vested, _ := bits.Div64(hi, lo, uint64(duration))
+	if vested > amount {
+		return amount
+	}
+	return vested
+}
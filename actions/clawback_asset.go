@@ -0,0 +1,106 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ClawbackAsset)(nil)
+
+// ClawbackAsset moves [Value] of [Asset] from [From] to the owner of [Asset].
+//
+// Clawbacks are allowed even if [Asset] is paused or [From] is frozen.
+type ClawbackAsset struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// From is the holder whose balance is reduced.
+	From crypto.PublicKey `json:"from"`
+
+	// Value is the amount of [Asset] returned to the owner.
+	Value uint64 `json:"value"`
+}
+
+func (c *ClawbackAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixBalanceKey(c.From, c.Asset),
+		storage.PrefixBalanceKey(auth.GetActor(rauth), c.Asset),
+	}
+}
+
+func (c *ClawbackAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	if c.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, _, _, owner, isWarp, _, err := storage.GetAsset(ctx, db, c.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if isWarp {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SubBalance(ctx, db, c.From, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*ClawbackAsset) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + consts.Uint64Len
+}
+
+func (c *ClawbackAsset) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackPublicKey(c.From)
+	p.PackUint64(c.Value)
+}
+
+func UnmarshalClawbackAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var clawback ClawbackAsset
+	p.UnpackID(true, &clawback.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(false, &clawback.From)
+	clawback.Value = p.UnpackUint64(true)
+	return &clawback, p.Err()
+}
+
+func (*ClawbackAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ClawbackAssetName)
+}
@@ -30,10 +30,11 @@ type CloseOrder struct {
 
 func (c *CloseOrder) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 	actor := auth.GetActor(rauth)
-	return [][]byte{
+	return append(
+		receiveKeys(c.Out, actor),
 		storage.PrefixOrderKey(c.Order),
 		storage.PrefixBalanceKey(actor, c.Out),
-	}
+	)
 }
 
 func (c *CloseOrder) Execute(
@@ -60,6 +61,9 @@ func (c *CloseOrder) Execute(
 	if out != c.Out {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOut}, nil
 	}
+	if output := checkReceivable(ctx, db, c.Out, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
 	if err := storage.DeleteOrder(ctx, db, c.Order); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -87,7 +91,6 @@ func UnmarshalCloseOrder(p *codec.Packer, _ *warp.Message) (chain.Action, error)
 	return &cl, p.Err()
 }
 
-func (*CloseOrder) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*CloseOrder) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CloseOrderName)
 }
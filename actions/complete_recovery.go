@@ -0,0 +1,85 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CompleteRecovery)(nil)
+
+// CompleteRecovery moves control of [Account] to the key approved with
+// [InitiateRecovery] once its delay has passed. After it completes, [Account]
+// can only be used with [auth.Recovered] transactions signed by the new key.
+//
+// Anyone can complete a recovery.
+type CompleteRecovery struct {
+	// Account is the account to recover.
+	Account crypto.PublicKey `json:"account"`
+}
+
+func (c *CompleteRecovery) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixRecoveryKey(c.Account),
+		storage.PrefixAccountKey(c.Account),
+	}
+}
+
+func (c *CompleteRecovery) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	_ chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := c.MaxUnits(r) // max units == units
+	pending, key, ready, err := storage.GetRecovery(ctx, db, c.Account)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !pending {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputRecoveryMissing}, nil
+	}
+	if t < ready {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputRecoveryNotReady}, nil
+	}
+	if err := storage.SetAccountKey(ctx, db, c.Account, key); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.DeleteRecovery(ctx, db, c.Account); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CompleteRecovery) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen
+}
+
+func (c *CompleteRecovery) Marshal(p *codec.Packer) {
+	p.PackPublicKey(c.Account)
+}
+
+func UnmarshalCompleteRecovery(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var complete CompleteRecovery
+	p.UnpackPublicKey(true, &complete.Account)
+	return &complete, p.Err()
+}
+
+func (*CompleteRecovery) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CompleteRecoveryName)
+}
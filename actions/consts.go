@@ -3,4 +3,48 @@
 
 package actions
 
-const MaxMetadataSize = 256
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+const (
+	MaxMetadataSize = 256
+	MaxMessageSize  = 1024
+	MaxBatchSize    = 64
+
+	MaxWarpDestinations = 16
+
+	MaxSessionActions = 32
+	MaxSessionLimits  = 16
+
+	MaxGuardians = 16
+
+	// MaxAirdropProofSize allows airdrops with up to 2^32 recipients.
+	MaxAirdropProofSize = 32
+
+	MaxFeedDecimals = 18
+)
+
+// Every warp payload emitted by the tokenvm is prefixed with its type so that
+// a payload created by one action can never be interpreted as another kind of
+// payload on import.
+const (
+	warpTransferType byte = iota
+	warpMessageType
+	warpBatchTransferType
+)
+
+// ChainIDKey is the key used to look up the ID of the chain with
+// [chain.Rules.FetchCustom]. Messages signed outside of a transaction include
+// it so that they can't be replayed on another chain.
+const ChainIDKey = "chainID"
+
+func getChainID(r chain.Rules) ids.ID {
+	v, ok := r.FetchCustom(ChainIDKey)
+	if !ok {
+		return ids.Empty
+	}
+	chainID, _ := v.(ids.ID)
+	return chainID
+}
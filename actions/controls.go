@@ -0,0 +1,128 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+)
+
+// spend subtracts [amount] of [asset] from the actor of [rauth]. If [rauth] is
+// an [auth.Session], [amount] is also charged to the allowance of the session
+// key.
+func spend(
+	ctx context.Context,
+	db chain.Database,
+	rauth chain.Auth,
+	asset ids.ID,
+	amount uint64,
+) error {
+	if err := auth.Spend(ctx, db, rauth, asset, amount); err != nil {
+		return err
+	}
+	return storage.SubBalance(ctx, db, auth.GetActor(rauth), asset, amount)
+}
+
+// controlKeys returns the state keys read by [checkTransferable].
+func controlKeys(asset ids.ID, owner crypto.PublicKey) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(asset),
+		storage.PrefixFrozenKey(asset, owner),
+	}
+}
+
+// checkTransferable returns a non-empty output if [owner] is not allowed to
+// move their balance of [asset] because the asset is paused or [owner] is
+// frozen by the asset owner.
+//
+// NFTs can't be moved by actions that use [checkTransferable] because they
+// would not update the owner record of the NFT (use [TransferNFT] instead).
+func checkTransferable(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) []byte {
+	return checkMovable(ctx, db, asset, owner, false)
+}
+
+// checkTradable is [checkTransferable] for the order book, which can also
+// move NFTs.
+func checkTradable(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) []byte {
+	return checkMovable(ctx, db, asset, owner, true)
+}
+
+func checkMovable(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+	allowNFT bool,
+) []byte {
+	_, _, _, _, _, flags, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if flags&storage.AssetPaused != 0 {
+		return OutputAssetPaused
+	}
+	if flags&storage.AssetNonFungible != 0 && !allowNFT {
+		return OutputNonFungible
+	}
+	frozen, err := storage.GetFrozen(ctx, db, asset, owner)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if frozen {
+		return OutputAccountFrozen
+	}
+	return nil
+}
+
+// receiveKeys returns the state keys read by [checkReceivable].
+func receiveKeys(asset ids.ID, recipient crypto.PublicKey) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(asset),
+		storage.PrefixAllowlistKey(asset, recipient),
+	}
+}
+
+// checkReceivable returns a non-empty output if [recipient] is not allowed to
+// be credited [asset] because the asset is in allowlist mode and [recipient]
+// was not approved by the asset owner. The asset owner is always allowed to
+// receive the asset.
+func checkReceivable(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	recipient crypto.PublicKey,
+) []byte {
+	_, _, _, owner, _, flags, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if flags&storage.AssetAllowlist == 0 || owner == recipient {
+		return nil
+	}
+	allowed, err := storage.GetAllowlisted(ctx, db, asset, recipient)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if !allowed {
+		return OutputNotAllowlisted
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateAirdrop)(nil)
+
+// CreateAirdrop locks [Amount] of [Asset] from the actor so that each
+// (recipient, amount) leaf of the Merkle tree with [Root] can be claimed
+// once with [ClaimAirdrop] before [Expiry]. After [Expiry], the actor can
+// take back whatever was not claimed with [ReclaimAirdrop].
+//
+// The airdrop is identified by the [TxID] that created it.
+type CreateAirdrop struct {
+	// Asset is the asset locked in the airdrop.
+	Asset ids.ID `json:"asset"`
+
+	// Amount is the total amount that can be claimed (usually the sum of all
+	// leaves).
+	Amount uint64 `json:"amount"`
+
+	// Root is the root of the Merkle tree built with [BuildAirdropTree].
+	Root ids.ID `json:"root"`
+
+	// Expiry is the unix timestamp (in seconds) after which nothing can be
+	// claimed.
+	Expiry int64 `json:"expiry"`
+}
+
+func (c *CreateAirdrop) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		controlKeys(c.Asset, actor),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixAirdropKey(txID),
+	)
+}
+
+func (c *CreateAirdrop) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Amount == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if c.Expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropExpired}, nil
+	}
+	if output := checkTransferable(ctx, db, c.Asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := spend(ctx, db, rauth, c.Asset, c.Amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetAirdrop(ctx, db, txID, c.Asset, actor, c.Root, c.Expiry, c.Amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateAirdrop) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + consts.Uint64Len*2
+}
+
+func (c *CreateAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackUint64(c.Amount)
+	p.PackID(c.Root)
+	p.PackInt64(c.Expiry)
+}
+
+func UnmarshalCreateAirdrop(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateAirdrop
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	create.Amount = p.UnpackUint64(true)
+	p.UnpackID(true, &create.Root)
+	create.Expiry = p.UnpackInt64(true)
+	return &create, p.Err()
+}
+
+func (*CreateAirdrop) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateAirdropName)
+}
+
+const (
+	airdropLeafType byte = iota
+	airdropNodeType
+)
+
+// AirdropLeaf returns the leaf that allows [recipient] to claim [amount].
+func AirdropLeaf(recipient crypto.PublicKey, amount uint64) ids.ID {
+	p := codec.NewWriter(1 + crypto.PublicKeyLen + consts.Uint64Len)
+	p.PackByte(airdropLeafType)
+	p.PackPublicKey(recipient)
+	p.PackUint64(amount)
+	return utils.ToID(p.Bytes())
+}
+
+// airdropNode returns the parent of [a] and [b]. The children are sorted
+// before hashing so that proofs don't need to specify the side of each
+// sibling.
+func airdropNode(a ids.ID, b ids.ID) ids.ID {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	p := codec.NewWriter(1 + consts.IDLen*2)
+	p.PackByte(airdropNodeType)
+	p.PackID(a)
+	p.PackID(b)
+	return utils.ToID(p.Bytes())
+}
+
+// VerifyAirdropProof returns true if [proof] shows that [leaf] is in the tree
+// with [root].
+func VerifyAirdropProof(root ids.ID, leaf ids.ID, proof []ids.ID) bool {
+	node := leaf
+	for _, sibling := range proof {
+		node = airdropNode(node, sibling)
+	}
+	return node == root
+}
+
+// BuildAirdropTree returns the root of the Merkle tree of [leaves] and the
+// proof of each leaf (in the same order as [leaves]). If a level has an odd
+// number of nodes, the last node is carried up to the next level unchanged.
+func BuildAirdropTree(leaves []ids.ID) (ids.ID, [][]ids.ID, error) {
+	if len(leaves) == 0 {
+		return ids.Empty, nil, ErrNoAirdropLeaves
+	}
+	proofs := make([][]ids.ID, len(leaves))
+	// [positions] tracks the index of the ancestor of each leaf in [level]
+	positions := make([]int, len(leaves))
+	for i := range positions {
+		positions[i] = i
+	}
+	level := leaves
+	for len(level) > 1 {
+		for i, pos := range positions {
+			sibling := pos ^ 1
+			if sibling < len(level) {
+				proofs[i] = append(proofs[i], level[sibling])
+			}
+			positions[i] = pos / 2
+		}
+		next := make([]ids.ID, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, airdropNode(level[i], level[i+1]))
+		}
+		level = next
+	}
+	if len(proofs[0]) > MaxAirdropProofSize {
+		return ids.Empty, nil, ErrTooManyAirdropLeaves
+	}
+	return level[0], proofs, nil
+}
@@ -45,7 +45,7 @@ func (c *CreateAsset) Execute(
 	}
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetAsset(ctx, db, txID, c.Metadata, 0, actor, false); err != nil {
+	if err := storage.SetAsset(ctx, db, txID, c.Metadata, 0, actor, false, 0); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 
@@ -71,7 +71,6 @@ func UnmarshalCreateAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error
 	return &create, p.Err()
 }
 
-func (*CreateAsset) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*CreateAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateAssetName)
 }
@@ -0,0 +1,72 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateCollection)(nil)
+
+// CreateCollection creates a collection of NFTs (identified by the ID of the
+// transaction) that only the actor can mint with [MintNFT].
+type CreateCollection struct {
+	// Metadata is creator-specified information about the collection.
+	Metadata []byte `json:"metadata"`
+}
+
+func (*CreateCollection) StateKeys(_ chain.Auth, txID ids.ID) [][]byte {
+	return [][]byte{storage.PrefixCollectionKey(txID)}
+}
+
+func (c *CreateCollection) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if len(c.Metadata) > MaxMetadataSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
+	}
+	// It should only be possible to overwrite an existing collection if there
+	// is a hash collision.
+	if err := storage.SetCollection(ctx, db, txID, actor, 0, c.Metadata); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (c *CreateCollection) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return uint64(len(c.Metadata))
+}
+
+func (c *CreateCollection) Marshal(p *codec.Packer) {
+	p.PackBytes(c.Metadata)
+}
+
+func UnmarshalCreateCollection(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateCollection
+	p.UnpackBytes(MaxMetadataSize, false, &create.Metadata)
+	return &create, p.Err()
+}
+
+func (*CreateCollection) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateCollectionName)
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateEscrow)(nil)
+
+// CreateEscrow locks [Amount] of [Asset] from the actor (the payer) until it
+// is sent to [Payee] or back to the payer with [ResolveEscrow].
+//
+// The payer can always release the funds to [Payee] and [Payee] can always
+// refund the payer. Otherwise, [Arbiter] decides who gets the funds until
+// [Deadline], after which anyone can apply the default outcome
+// ([ReleaseOnTimeout]).
+//
+// The escrow is identified by the [TxID] that created it.
+type CreateEscrow struct {
+	// Asset is the asset locked in the escrow.
+	Asset ids.ID `json:"asset"`
+
+	// Payee is the account paid when the escrow is released.
+	Payee crypto.PublicKey `json:"payee"`
+
+	// Arbiter resolves disputes between the payer and [Payee].
+	Arbiter crypto.PublicKey `json:"arbiter"`
+
+	// Amount is the amount locked in the escrow.
+	Amount uint64 `json:"amount"`
+
+	// Deadline is the unix timestamp (in seconds) after which [Arbiter] can no
+	// longer decide the outcome.
+	Deadline int64 `json:"deadline"`
+
+	// ReleaseOnTimeout is true if the funds go to [Payee] after [Deadline]
+	// (otherwise they are refunded to the payer).
+	ReleaseOnTimeout bool `json:"releaseOnTimeout"`
+}
+
+func (c *CreateEscrow) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		controlKeys(c.Asset, actor),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixEscrowKey(txID),
+	)
+}
+
+func (c *CreateEscrow) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Amount == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if actor == c.Payee || actor == c.Arbiter || c.Payee == c.Arbiter {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidEscrowParties}, nil
+	}
+	if c.Deadline <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputEscrowDeadlinePassed}, nil
+	}
+	if output := checkTransferable(ctx, db, c.Asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := spend(ctx, db, rauth, c.Asset, c.Amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetEscrow(
+		ctx, db, txID, c.Asset, actor, c.Payee, c.Arbiter,
+		c.Amount, c.Deadline, c.ReleaseOnTimeout,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateEscrow) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen*2 + consts.Uint64Len*2 + 1
+}
+
+func (c *CreateEscrow) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackPublicKey(c.Payee)
+	p.PackPublicKey(c.Arbiter)
+	p.PackUint64(c.Amount)
+	p.PackInt64(c.Deadline)
+	p.PackBool(c.ReleaseOnTimeout)
+}
+
+func UnmarshalCreateEscrow(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateEscrow
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(true, &create.Payee)
+	p.UnpackPublicKey(true, &create.Arbiter)
+	create.Amount = p.UnpackUint64(true)
+	create.Deadline = p.UnpackInt64(true)
+	create.ReleaseOnTimeout = p.UnpackBool()
+	return &create, p.Err()
+}
+
+func (*CreateEscrow) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateEscrowName)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateFeed)(nil)
+
+// CreateFeed creates a price feed (identified by the ID of the transaction)
+// that only the actor can post to with [UpdateFeed].
+//
+// Prices are fixed-point numbers with [Decimals] decimal places.
+type CreateFeed struct {
+	// Decimals is the number of decimal places of every price posted to the
+	// feed.
+	Decimals uint8 `json:"decimals"`
+
+	// Metadata is creator-specified information about the feed (usually the
+	// pair it tracks).
+	Metadata []byte `json:"metadata"`
+}
+
+func (*CreateFeed) StateKeys(_ chain.Auth, txID ids.ID) [][]byte {
+	return [][]byte{storage.PrefixFeedKey(txID)}
+}
+
+func (c *CreateFeed) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Decimals > MaxFeedDecimals {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidDecimals}, nil
+	}
+	if len(c.Metadata) > MaxMetadataSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
+	}
+	// A feed has no price (or timestamp) until the owner first updates it.
+	//
+	// It should only be possible to overwrite an existing feed if there is a
+	// hash collision.
+	if err := storage.SetFeed(ctx, db, txID, actor, c.Decimals, 0, 0, c.Metadata); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (c *CreateFeed) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return 1 + uint64(len(c.Metadata))
+}
+
+func (c *CreateFeed) Marshal(p *codec.Packer) {
+	p.PackByte(c.Decimals)
+	p.PackBytes(c.Metadata)
+}
+
+func UnmarshalCreateFeed(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateFeed
+	create.Decimals = p.UnpackByte()
+	p.UnpackBytes(MaxMetadataSize, false, &create.Metadata)
+	return &create, p.Err()
+}
+
+func (*CreateFeed) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateFeedName)
+}
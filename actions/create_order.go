@@ -50,10 +50,11 @@ type CreateOrder struct {
 
 func (c *CreateOrder) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
 	actor := auth.GetActor(rauth)
-	return [][]byte{
+	return append(
+		controlKeys(c.Out, actor),
 		storage.PrefixBalanceKey(actor, c.Out),
 		storage.PrefixOrderKey(txID),
-	}
+	)
 }
 
 func (c *CreateOrder) Execute(
@@ -82,7 +83,10 @@ func (c *CreateOrder) Execute(
 	if c.Supply%c.OutTick != 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSupplyMisaligned}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, c.Out, c.Supply); err != nil {
+	if output := checkTradable(ctx, db, c.Out, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := spend(ctx, db, rauth, c.Out, c.Supply); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.SetOrder(ctx, db, txID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, actor); err != nil {
@@ -115,9 +119,8 @@ func UnmarshalCreateOrder(p *codec.Packer, _ *warp.Message) (chain.Action, error
 	return &create, p.Err()
 }
 
-func (*CreateOrder) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*CreateOrder) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateOrderName)
 }
 
 func PairID(in ids.ID, out ids.ID) string {
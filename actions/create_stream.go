@@ -0,0 +1,167 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateStream)(nil)
+
+// CreateStream locks [Amount] of [Asset] from the actor and streams it to
+// [Recipient] every second between [Start] and [End]. The recipient can
+// withdraw whatever has accrued with [WithdrawStream] and the actor can stop
+// the stream with [CancelStream].
+//
+// The stream is identified by the [TxID] that created it.
+type CreateStream struct {
+	// Asset is the asset locked in the stream.
+	Asset ids.ID `json:"asset"`
+
+	// Recipient is the only account that can withdraw from the stream.
+	Recipient crypto.PublicKey `json:"recipient"`
+
+	// Amount is the total amount streamed to [Recipient].
+	Amount uint64 `json:"amount"`
+
+	// Start is the unix timestamp (in seconds) when the stream begins.
+	Start int64 `json:"start"`
+
+	// End is the unix timestamp (in seconds) when [Amount] has been fully
+	// streamed.
+	End int64 `json:"end"`
+}
+
+func (c *CreateStream) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		controlKeys(c.Asset, actor),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixStreamKey(txID),
+	)
+}
+
+func (c *CreateStream) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Amount == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if output := checkTransferable(ctx, db, c.Asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := spend(ctx, db, rauth, c.Asset, c.Amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetStream(
+		ctx, db, txID, c.Asset, actor, c.Recipient,
+		c.Amount, c.Start, c.End, 0,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateStream) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + consts.Uint64Len*3
+}
+
+func (c *CreateStream) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackPublicKey(c.Recipient)
+	p.PackUint64(c.Amount)
+	p.PackInt64(c.Start)
+	p.PackInt64(c.End)
+}
+
+func UnmarshalCreateStream(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateStream
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(true, &create.Recipient)
+	create.Amount = p.UnpackUint64(true)
+	create.Start = p.UnpackInt64(false)
+	create.End = p.UnpackInt64(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidStream(create.Start, create.End) {
+		return nil, ErrInvalidStream
+	}
+	return &create, nil
+}
+
+func (*CreateStream) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateStreamName)
+}
+
+// ValidStream returns true if a stream can run from [start] to [end].
+func ValidStream(start int64, end int64) bool {
+	return start >= 0 && end > start
+}
+
+// StreamedAmount returns how much of [amount] has been streamed at [t]
+// (whether or not it has been withdrawn).
+func StreamedAmount(amount uint64, start int64, end int64, t int64) uint64 {
+	return VestedAmount(amount, start, 0, end-start, t)
+}
+
+// claimStream updates [stream] to stream [amount] between [start] and [end]
+// (of which [withdrawn] has been withdrawn) and sends [claim] of [asset] to
+// [actor]. The stream is deleted once everything it streams has been
+// withdrawn.
+func claimStream(
+	ctx context.Context,
+	db chain.Database,
+	stream ids.ID,
+	asset ids.ID,
+	sender crypto.PublicKey,
+	recipient crypto.PublicKey,
+	amount uint64,
+	start int64,
+	end int64,
+	withdrawn uint64,
+	actor crypto.PublicKey,
+	claim uint64,
+) []byte {
+	if output := checkReceivable(ctx, db, asset, actor); len(output) > 0 {
+		return output
+	}
+	if withdrawn == amount {
+		if err := storage.DeleteStream(ctx, db, stream); err != nil {
+			return utils.ErrBytes(err)
+		}
+	} else {
+		if err := storage.SetStream(
+			ctx, db, stream, asset, sender, recipient,
+			amount, start, end, withdrawn,
+		); err != nil {
+			return utils.ErrBytes(err)
+		}
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, claim); err != nil {
+		return utils.ErrBytes(err)
+	}
+	return nil
+}
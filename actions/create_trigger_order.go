@@ -0,0 +1,194 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"math/bits"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateTriggerOrder)(nil)
+
+// CreateTriggerOrder locks up to [Value] of [In] (plus [Reward]) from the actor
+// until a price condition holds, after which anyone can use it to fill an order
+// selling [Out] for [In] with [TriggerOrder] (and collect [Reward]).
+//
+// The price is the amount of [In] paid per [Out]. If [Feed] is empty, it is
+// the price of the last fill of an order selling [Out] for [In]. Otherwise, it
+// is the price posted to [Feed] (in the decimals of the feed).
+//
+// The trigger price only decides when the order can be used. Anyone can move the
+// last trade (e.g. a keeper filling their own order at an arbitrary price) and
+// the order that is filled is chosen by the keeper, so [MinOut] is the limit
+// price that protects the actor from a bad fill.
+//
+// The trigger order is identified by the [TxID] that created it and can be
+// cancelled by the actor with [CancelTriggerOrder] before it is triggered.
+type CreateTriggerOrder struct {
+	// [In] is the asset the actor trades for [Out] once triggered.
+	In ids.ID `json:"in"`
+
+	// [Out] is the asset the actor receives once triggered.
+	Out ids.ID `json:"out"`
+
+	// [Value] is the max amount of [In] that will be swapped for [Out].
+	Value uint64 `json:"value"`
+
+	// [Reward] is the amount of [In] paid to whoever triggers the order.
+	Reward uint64 `json:"reward"`
+
+	// [MinOut] is the minimum amount of [Out] (after fees) the actor must
+	// receive for all of [Value]. If less than [Value] is filled, the actor
+	// must receive at least the same price.
+	MinOut uint64 `json:"minOut"`
+
+	// [Feed] is the price feed to watch (empty to watch the last trade).
+	Feed ids.ID `json:"feed"`
+
+	// [TriggerIn] of [In] per [TriggerOut] of [Out] is the trigger price.
+	TriggerIn  uint64 `json:"triggerIn"`
+	TriggerOut uint64 `json:"triggerOut"`
+
+	// [Above] is true if the order triggers when the price is at or above the
+	// trigger price (otherwise, when the price is at or below it).
+	Above bool `json:"above"`
+}
+
+func (c *CreateTriggerOrder) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	keys := append(
+		controlKeys(c.In, actor),
+		storage.PrefixBalanceKey(actor, c.In),
+		storage.PrefixTriggerOrderKey(txID),
+	)
+	if c.Feed != ids.Empty {
+		keys = append(keys, storage.PrefixFeedKey(c.Feed))
+	}
+	return keys
+}
+
+func (c *CreateTriggerOrder) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.In == c.Out {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSameInOut}, nil
+	}
+	if c.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if c.MinOut == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMinOutZero}, nil
+	}
+	if c.TriggerIn == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInTickZero}, nil
+	}
+	if c.TriggerOut == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputOutTickZero}, nil
+	}
+	if c.Feed != ids.Empty {
+		exists, _, _, _, _, _, err := storage.GetFeed(ctx, db, c.Feed)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if !exists {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputFeedMissing}, nil
+		}
+	}
+	if output := checkTradable(ctx, db, c.In, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	locked, err := smath.Add64(c.Value, c.Reward)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := spend(ctx, db, rauth, c.In, locked); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetTriggerOrder(
+		ctx, db, txID, c.In, c.Out, actor, c.Value, c.Reward, c.MinOut,
+		c.Feed, c.TriggerIn, c.TriggerOut, c.Above,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateTriggerOrder) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*3 + consts.Uint64Len*5 + 1
+}
+
+func (c *CreateTriggerOrder) Marshal(p *codec.Packer) {
+	p.PackID(c.In)
+	p.PackID(c.Out)
+	p.PackUint64(c.Value)
+	p.PackUint64(c.Reward)
+	p.PackUint64(c.MinOut)
+	p.PackID(c.Feed)
+	p.PackUint64(c.TriggerIn)
+	p.PackUint64(c.TriggerOut)
+	p.PackBool(c.Above)
+}
+
+func UnmarshalCreateTriggerOrder(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateTriggerOrder
+	p.UnpackID(false, &create.In)  // empty ID is the native asset
+	p.UnpackID(false, &create.Out) // empty ID is the native asset
+	create.Value = p.UnpackUint64(true)
+	create.Reward = p.UnpackUint64(false)
+	create.MinOut = p.UnpackUint64(true)
+	p.UnpackID(false, &create.Feed) // empty ID is the last trade
+	create.TriggerIn = p.UnpackUint64(true)
+	create.TriggerOut = p.UnpackUint64(true)
+	create.Above = p.UnpackBool()
+	return &create, p.Err()
+}
+
+func (*CreateTriggerOrder) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateTriggerOrderName)
+}
+
+// LimitMet returns true if receiving [out] for [in] is at least the price of
+// [minOut] for [value].
+func LimitMet(in uint64, out uint64, value uint64, minOut uint64) bool {
+	// We compare the cross products with 128 bits of precision so that the
+	// comparison can never overflow.
+	outHi, outLo := bits.Mul64(out, value)
+	minHi, minLo := bits.Mul64(minOut, in)
+	return outHi > minHi || (outHi == minHi && outLo >= minLo)
+}
+
+// TriggerMet returns true if [priceIn] per [priceOut] is at or [above] (or at
+// or below, if not [above]) [triggerIn] per [triggerOut].
+func TriggerMet(priceIn uint64, priceOut uint64, triggerIn uint64, triggerOut uint64, above bool) bool {
+	// We compare the cross products with 128 bits of precision so that the
+	// comparison can never overflow.
+	priceHi, priceLo := bits.Mul64(priceIn, triggerOut)
+	triggerHi, triggerLo := bits.Mul64(triggerIn, priceOut)
+	if priceHi == triggerHi && priceLo == triggerLo {
+		return true
+	}
+	greater := priceHi > triggerHi || (priceHi == triggerHi && priceLo > triggerLo)
+	return greater == above
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*CreateVesting)(nil)
+
+// CreateVesting locks [Amount] of [Asset] from the actor and releases it to
+// [Beneficiary] linearly over [Duration] seconds from [Start]. Nothing can be
+// claimed until [Cliff] seconds after [Start].
+//
+// The vesting schedule is identified by the [TxID] that created it.
+type CreateVesting struct {
+	// Asset is the asset locked in the schedule.
+	Asset ids.ID `json:"asset"`
+
+	// Beneficiary is the only account that can claim from the schedule.
+	Beneficiary crypto.PublicKey `json:"beneficiary"`
+
+	// Amount is the total amount released by the schedule.
+	Amount uint64 `json:"amount"`
+
+	// Start is the unix timestamp (in seconds) when tokens begin to vest.
+	Start int64 `json:"start"`
+
+	// Cliff is the number of seconds after [Start] before anything can be
+	// claimed.
+	Cliff int64 `json:"cliff"`
+
+	// Duration is the number of seconds after [Start] when [Amount] is fully
+	// vested.
+	Duration int64 `json:"duration"`
+}
+
+func (c *CreateVesting) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		controlKeys(c.Asset, actor),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixVestingKey(txID),
+	)
+}
+
+func (c *CreateVesting) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Amount == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if output := checkTransferable(ctx, db, c.Asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := spend(ctx, db, rauth, c.Asset, c.Amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetVesting(
+		ctx, db, txID, c.Asset, c.Beneficiary,
+		c.Amount, c.Start, c.Cliff, c.Duration, 0,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateVesting) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + consts.Uint64Len*4
+}
+
+func (c *CreateVesting) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackPublicKey(c.Beneficiary)
+	p.PackUint64(c.Amount)
+	p.PackInt64(c.Start)
+	p.PackInt64(c.Cliff)
+	p.PackInt64(c.Duration)
+}
+
+func UnmarshalCreateVesting(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateVesting
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(true, &create.Beneficiary)
+	create.Amount = p.UnpackUint64(true)
+	create.Start = p.UnpackInt64(false)
+	create.Cliff = p.UnpackInt64(false) // no cliff
+	create.Duration = p.UnpackInt64(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidVestingSchedule(create.Start, create.Cliff, create.Duration) {
+		return nil, ErrInvalidVestingSchedule
+	}
+	return &create, nil
+}
+
+func (*CreateVesting) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, CreateVestingName)
+}
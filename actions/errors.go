@@ -5,4 +5,31 @@ package actions
 
 import "errors"
 
-var ErrNoSwapToFill = errors.New("no swap to fill")
+var (
+	ErrNoSwapToFill  = errors.New("no swap to fill")
+	ErrBatchTooLarge = errors.New("batch is too large")
+
+	ErrTooManyDestinations  = errors.New("too many destinations")
+	ErrDuplicateDestination = errors.New("duplicate destination")
+
+	ErrInvalidVestingSchedule = errors.New("invalid vesting schedule")
+	ErrInvalidStream          = errors.New("invalid stream")
+
+	ErrInvalidParam = errors.New("invalid param")
+
+	ErrTooManySessionLimits  = errors.New("too many session limits")
+	ErrDuplicateSessionLimit = errors.New("duplicate session limit")
+
+	ErrTooManyGuardians  = errors.New("too many guardians")
+	ErrDuplicateGuardian = errors.New("duplicate guardian")
+	ErrInvalidThreshold  = errors.New("invalid threshold")
+	ErrInvalidDelay      = errors.New("invalid delay")
+
+	ErrInvalidName = errors.New("invalid name")
+
+	ErrNoAirdropLeaves      = errors.New("no airdrop leaves")
+	ErrTooManyAirdropLeaves = errors.New("too many airdrop leaves")
+	ErrProofTooLarge        = errors.New("proof is too large")
+
+	ErrAssetMissing = errors.New("asset missing")
+)
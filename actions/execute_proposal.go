@@ -0,0 +1,151 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ExecuteProposal)(nil)
+
+// ExecuteProposal applies a proposal that reached quorum with more support
+// than opposition once its voting period and timelock have passed. Anyone can
+// execute a passing proposal until it expires (see [GovernanceConfig.Expiry]).
+//
+// The new value takes effect [GovernanceConfig.ActivationDelay] seconds after
+// execution. Until then, the value set by any earlier proposal stays in effect.
+type ExecuteProposal struct {
+	// Proposal is the [TxID] that created the proposal.
+	Proposal ids.ID `json:"proposal"`
+
+	// Param is the param changed by the proposal. We need to provide this to
+	// populate [StateKeys].
+	Param uint8 `json:"param"`
+
+	// In and Out are the pair changed by a pair param (see [PairParam]). We
+	// need to provide them to populate [StateKeys].
+	In  ids.ID `json:"in"`
+	Out ids.ID `json:"out"`
+}
+
+func (e *ExecuteProposal) StateKeys(chain.Auth, ids.ID) [][]byte {
+	keys := [][]byte{
+		storage.PrefixProposalKey(e.Proposal),
+		ParamKey(e.Param, e.In, e.Out),
+	}
+	if PairParam(e.Param) {
+		keys = append(keys, storage.PrefixProposalPairKey(e.Proposal))
+	}
+	return keys
+}
+
+func (e *ExecuteProposal) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	_ chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := e.MaxUnits(r) // max units == units
+	config, ok := governanceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputGovernanceDisabled}, nil
+	}
+	exists, proposer, param, value, created, yes, no, deposit, executed, err := storage.GetProposal(ctx, db, e.Proposal)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalMissing}, nil
+	}
+	if executed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalExecuted}, nil
+	}
+	if param != e.Param {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongParam}, nil
+	}
+	if t < created+config.VotingPeriod {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputVotingOpen}, nil
+	}
+	if t < created+config.VotingPeriod+config.Timelock {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputTimelockActive}, nil
+	}
+	if t >= proposalExpiry(config, created) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalExpired}, nil
+	}
+	total, err := smath.Add64(yes, no)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if total < config.Quorum {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputQuorumNotReached}, nil
+	}
+	if yes <= no {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalRejected}, nil
+	}
+	if PairParam(param) {
+		in, out, err := storage.GetProposalPair(ctx, db, e.Proposal)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if in != e.In || out != e.Out {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongPair}, nil
+		}
+	}
+	if err := setParam(ctx, db, t, param, e.In, e.Out, value, t+config.ActivationDelay); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetProposal(
+		ctx, db, e.Proposal, proposer, param,
+		value, created, yes, no, deposit, true,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (e *ExecuteProposal) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	if PairParam(e.Param) {
+		return consts.IDLen*3 + 1
+	}
+	return consts.IDLen + 1
+}
+
+func (e *ExecuteProposal) Marshal(p *codec.Packer) {
+	p.PackID(e.Proposal)
+	p.PackByte(e.Param)
+	if PairParam(e.Param) {
+		p.PackID(e.In)
+		p.PackID(e.Out)
+	}
+}
+
+func UnmarshalExecuteProposal(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var execute ExecuteProposal
+	p.UnpackID(true, &execute.Proposal)
+	execute.Param = p.UnpackByte()
+	if PairParam(execute.Param) {
+		p.UnpackID(false, &execute.In) // empty ID is the native asset
+		p.UnpackID(false, &execute.Out)
+	}
+	return &execute, p.Err()
+}
+
+func (*ExecuteProposal) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ExecuteProposalName)
+}
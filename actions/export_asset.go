@@ -49,22 +49,24 @@ func (e *ExportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		keys = [][]byte{
 			storage.PrefixAssetKey(e.Asset),
 			storage.PrefixLoanKey(e.Asset, e.Destination),
+			storage.PrefixLoanDestinationsKey(e.Asset),
+			storage.PrefixWarpDestinationsKey(e.Asset),
 			storage.PrefixBalanceKey(actor, e.Asset),
 		}
 	}
 
-	return keys
+	return append(keys, storage.PrefixFrozenKey(e.Asset, actor))
 }
 
 func (e *ExportAsset) executeReturn(
 	ctx context.Context,
 	r chain.Rules,
 	db chain.Database,
-	actor crypto.PublicKey,
+	rauth chain.Auth,
 	txID ids.ID,
 ) (*chain.Result, error) {
 	unitsUsed := e.MaxUnits(r)
-	exists, metadata, supply, _, isWarp, err := storage.GetAsset(ctx, db, e.Asset)
+	exists, metadata, supply, _, isWarp, _, err := storage.GetAsset(ctx, db, e.Asset)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -90,7 +92,7 @@ func (e *ExportAsset) executeReturn(
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if newSupply > 0 {
-		if err := storage.SetAsset(ctx, db, e.Asset, metadata, newSupply, crypto.EmptyPublicKey, true); err != nil {
+		if err := storage.SetAsset(ctx, db, e.Asset, metadata, newSupply, crypto.EmptyPublicKey, true, 0); err != nil {
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
 	} else {
@@ -98,11 +100,11 @@ func (e *ExportAsset) executeReturn(
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
 	}
-	if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Value); err != nil {
+	if err := spend(ctx, db, rauth, e.Asset, e.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if e.Reward > 0 {
-		if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Reward); err != nil {
+		if err := spend(ctx, db, rauth, e.Asset, e.Reward); err != nil {
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
 	}
@@ -138,11 +140,11 @@ func (e *ExportAsset) executeLoan(
 	ctx context.Context,
 	r chain.Rules,
 	db chain.Database,
-	actor crypto.PublicKey,
+	rauth chain.Auth,
 	txID ids.ID,
 ) (*chain.Result, error) {
 	unitsUsed := e.MaxUnits(r)
-	exists, _, _, _, isWarp, err := storage.GetAsset(ctx, db, e.Asset)
+	exists, _, _, _, isWarp, _, err := storage.GetAsset(ctx, db, e.Asset)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -153,17 +155,24 @@ func (e *ExportAsset) executeLoan(
 		// Cannot export an asset if it was warped in and not returning
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
 	}
+	allowed, err := storage.AllowedWarpDestination(ctx, db, e.Asset, e.Destination)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !allowed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputDestinationNotAllowed}, nil
+	}
 	if err := storage.AddLoan(ctx, db, e.Asset, e.Destination, e.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Value); err != nil {
+	if err := spend(ctx, db, rauth, e.Asset, e.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if e.Reward > 0 {
 		if err := storage.AddLoan(ctx, db, e.Asset, e.Destination, e.Reward); err != nil {
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
-		if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Reward); err != nil {
+		if err := spend(ctx, db, rauth, e.Asset, e.Reward); err != nil {
 			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 		}
 	}
@@ -211,10 +220,13 @@ func (e *ExportAsset) Execute(
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAnycast}, nil
 	}
 	// TODO: check if destination is ourselves
+	if output := checkTransferable(ctx, db, e.Asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
 	if e.Return {
-		return e.executeReturn(ctx, r, db, actor, txID)
+		return e.executeReturn(ctx, r, db, rauth, txID)
 	}
-	return e.executeLoan(ctx, r, db, actor, txID)
+	return e.executeLoan(ctx, r, db, rauth, txID)
 }
 
 func (*ExportAsset) MaxUnits(chain.Rules) uint64 {
@@ -269,7 +281,6 @@ func UnmarshalExportAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error
 	return &export, nil
 }
 
-func (*ExportAsset) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*ExportAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ExportAssetName)
 }
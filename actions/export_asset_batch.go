@@ -0,0 +1,214 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*BatchExportAsset)(nil)
+
+// BatchExportAsset bundles many transfers to the same [Destination] into a
+// single warp message, so only one set of signatures must be collected and
+// verified to import all of them.
+//
+// Each transfer is treated as a return if [Asset] was warped in from
+// [Destination] and as a loan otherwise.
+type BatchExportAsset struct {
+	Transfers   []*BatchTransfer `json:"transfers"`
+	Destination ids.ID           `json:"destination"`
+}
+
+func (b *BatchExportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	keys := make([][]byte, 0, len(b.Transfers)*6)
+	for _, transfer := range b.Transfers {
+		// We don't know whether a transfer is a loan or a return until we read
+		// the asset, so we include the keys for both.
+		keys = append(keys,
+			storage.PrefixAssetKey(transfer.Asset),
+			storage.PrefixLoanKey(transfer.Asset, b.Destination),
+			storage.PrefixLoanDestinationsKey(transfer.Asset),
+			storage.PrefixWarpDestinationsKey(transfer.Asset),
+			storage.PrefixFrozenKey(transfer.Asset, actor),
+			storage.PrefixBalanceKey(actor, transfer.Asset),
+		)
+	}
+	return keys
+}
+
+func (b *BatchExportAsset) executeReturn(
+	ctx context.Context,
+	db chain.Database,
+	rauth chain.Auth,
+	transfer *BatchTransfer,
+	metadata []byte,
+	supply uint64,
+) (*WarpBatchTransferEntry, []byte) {
+	allowedDestination, err := ids.ToID(metadata[consts.IDLen:])
+	if err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if allowedDestination != b.Destination {
+		return nil, OutputWrongDestination
+	}
+	newSupply, err := smath.Sub(supply, transfer.Value)
+	if err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if newSupply > 0 {
+		if err := storage.SetAsset(ctx, db, transfer.Asset, metadata, newSupply, crypto.EmptyPublicKey, true, 0); err != nil {
+			return nil, utils.ErrBytes(err)
+		}
+	} else {
+		if err := storage.DeleteAsset(ctx, db, transfer.Asset); err != nil {
+			return nil, utils.ErrBytes(err)
+		}
+	}
+	if err := spend(ctx, db, rauth, transfer.Asset, transfer.Value); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	originalAsset, err := ids.ToID(metadata[:consts.IDLen])
+	if err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	return &WarpBatchTransferEntry{
+		To:     transfer.To,
+		Asset:  originalAsset,
+		Value:  transfer.Value,
+		Return: true,
+	}, nil
+}
+
+func (b *BatchExportAsset) executeLoan(
+	ctx context.Context,
+	db chain.Database,
+	rauth chain.Auth,
+	transfer *BatchTransfer,
+) (*WarpBatchTransferEntry, []byte) {
+	allowed, err := storage.AllowedWarpDestination(ctx, db, transfer.Asset, b.Destination)
+	if err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if !allowed {
+		return nil, OutputDestinationNotAllowed
+	}
+	if err := storage.AddLoan(ctx, db, transfer.Asset, b.Destination, transfer.Value); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if err := spend(ctx, db, rauth, transfer.Asset, transfer.Value); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	return &WarpBatchTransferEntry{
+		To:    transfer.To,
+		Asset: transfer.Asset,
+		Value: transfer.Value,
+	}, nil
+}
+
+func (b *BatchExportAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := b.MaxUnits(r) // max units == units
+	if b.Destination == ids.Empty {
+		// This would result in multiplying balance export by whoever imports the
+		// transaction.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAnycast}, nil
+	}
+	wbt := &WarpBatchTransfer{
+		Transfers: make([]*WarpBatchTransferEntry, len(b.Transfers)),
+		TxID:      txID,
+	}
+	for i, transfer := range b.Transfers {
+		exists, metadata, supply, _, isWarp, _, err := storage.GetAsset(ctx, db, transfer.Asset)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if !exists {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+		}
+		if output := checkTransferable(ctx, db, transfer.Asset, actor); len(output) > 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+		var (
+			entry  *WarpBatchTransferEntry
+			output []byte
+		)
+		if isWarp {
+			entry, output = b.executeReturn(ctx, db, rauth, transfer, metadata, supply)
+		} else {
+			entry, output = b.executeLoan(ctx, db, rauth, transfer)
+		}
+		if len(output) > 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+		wbt.Transfers[i] = entry
+	}
+	payload, err := wbt.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	wm := &warp.UnsignedMessage{
+		DestinationChainID: b.Destination,
+		// SourceChainID is populated by hypersdk
+		Payload: payload,
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, WarpMessage: wm}, nil
+}
+
+func (b *BatchExportAsset) MaxUnits(chain.Rules) uint64 {
+	return consts.IntLen + uint64(len(b.Transfers))*batchTransferSize + consts.IDLen
+}
+
+func (b *BatchExportAsset) Marshal(p *codec.Packer) {
+	p.PackInt(len(b.Transfers))
+	for _, transfer := range b.Transfers {
+		p.PackPublicKey(transfer.To)
+		p.PackID(transfer.Asset)
+		p.PackUint64(transfer.Value)
+	}
+	p.PackID(b.Destination)
+}
+
+func UnmarshalBatchExportAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var export BatchExportAsset
+	count := p.UnpackInt(true)
+	if count > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	export.Transfers = make([]*BatchTransfer, count)
+	for i := range export.Transfers {
+		var transfer BatchTransfer
+		p.UnpackPublicKey(false, &transfer.To) // can transfer to blackhole
+		p.UnpackID(false, &transfer.Asset)     // may export native
+		transfer.Value = p.UnpackUint64(true)
+		export.Transfers[i] = &transfer
+	}
+	p.UnpackID(true, &export.Destination)
+	return &export, p.Err()
+}
+
+func (*BatchExportAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, BatchExportAssetName)
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
+
+	"tokenvm/storage"
+)
+
+// TreasuryKey is the key used to look up the [TreasuryConfig] with
+// [chain.Rules.FetchCustom].
+const TreasuryKey = "treasury"
+
+// TreasuryConfig determines where fees are sent when they are settled with
+// [SettleFees].
+type TreasuryConfig struct {
+	// Treasury receives its share of transaction fees (see [auth.FeeShareKey])
+	// and [TakerFee] of every fill. If it is [crypto.EmptyPublicKey], all
+	// transaction fees are burned.
+	Treasury crypto.PublicKey
+
+	// TakerFee is the fee (in basis points) charged to the filler of an order
+	// and sent to [Treasury].
+	TakerFee uint64
+
+	// FeeRecipient receives the [TradingFees] of each pair (less any maker
+	// rebates). If it is [crypto.EmptyPublicKey], no trading fees are
+	// charged.
+	FeeRecipient crypto.PublicKey
+}
+
+func treasuryConfig(r chain.Rules) *TreasuryConfig {
+	v, ok := r.FetchCustom(TreasuryKey)
+	if !ok {
+		return &TreasuryConfig{}
+	}
+	c, ok := v.(*TreasuryConfig)
+	if !ok {
+		return &TreasuryConfig{}
+	}
+	return c
+}
+
+// feePoolKeys returns every shard of the fee pool of [asset].
+func feePoolKeys(asset ids.ID) [][]byte {
+	keys := make([][]byte, storage.FeePoolShards)
+	for i := range keys {
+		keys[i] = storage.PrefixFeePoolKey(asset, uint8(i))
+	}
+	return keys
+}
+
+// addToFeePool credits [treasury] and [recipient] of [asset] to the fee pool
+// shard of [payer]. They are sent to the treasury and fee recipient when the
+// pool is settled.
+func addToFeePool(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	payer crypto.PublicKey,
+	treasury uint64,
+	recipient uint64,
+) error {
+	if treasury == 0 && recipient == 0 {
+		return nil
+	}
+	shard := storage.FeePoolShard(payer)
+	burn, pooledTreasury, pooledRecipient, err := storage.GetFeePool(ctx, db, asset, shard)
+	if err != nil {
+		return err
+	}
+	pooledTreasury, err = smath.Add64(pooledTreasury, treasury)
+	if err != nil {
+		return err
+	}
+	pooledRecipient, err = smath.Add64(pooledRecipient, recipient)
+	if err != nil {
+		return err
+	}
+	return storage.SetFeePool(ctx, db, asset, shard, burn, pooledTreasury, pooledRecipient)
+}
+
+// updateSupply adds (or removes) [amount] to (or from) the supply of [asset].
+func updateSupply(ctx context.Context, db chain.Database, asset ids.ID, amount uint64, add bool) error {
+	if amount == 0 {
+		return nil
+	}
+	exists, metadata, supply, owner, warp, flags, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAssetMissing
+	}
+	if add {
+		supply, err = smath.Add64(supply, amount)
+	} else {
+		supply, err = smath.Sub(supply, amount)
+	}
+	if err != nil {
+		return err
+	}
+	return storage.SetAsset(ctx, db, asset, metadata, supply, owner, warp, flags)
+}
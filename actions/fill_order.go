@@ -12,6 +12,7 @@ import (
 
 	"tokenvm/auth"
 	"tokenvm/storage"
+	tutils "tokenvm/utils"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
@@ -48,64 +49,123 @@ type FillOrder struct {
 }
 
 func (f *FillOrder) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
-	actor := auth.GetActor(rauth)
-	return [][]byte{
-		storage.PrefixOrderKey(f.Order),
-		storage.PrefixBalanceKey(f.Owner, f.In),
-		storage.PrefixBalanceKey(actor, f.In),
-		storage.PrefixBalanceKey(actor, f.Out),
+	return fillOrderKeys(f.Order, f.Owner, f.In, f.Out, auth.GetActor(rauth))
+}
+
+// fillOrderKeys returns the keys touched when [taker] fills [order].
+func fillOrderKeys(order ids.ID, owner crypto.PublicKey, in ids.ID, out ids.ID, taker crypto.PublicKey) [][]byte {
+	keys := [][]byte{
+		storage.PrefixOrderKey(order),
+		storage.PrefixBalanceKey(owner, in),
+		storage.PrefixBalanceKey(taker, in),
+		storage.PrefixBalanceKey(taker, out),
 	}
+	keys = append(keys, controlKeys(in, taker)...)
+	keys = append(keys, controlKeys(out, owner)...)
+	keys = append(keys, receiveKeys(in, owner)...)
+	keys = append(keys, receiveKeys(out, taker)...)
+	keys = append(keys, storage.PrefixNFTKey(in), storage.PrefixNFTKey(out))
+	keys = append(keys, storage.PrefixLastTradeKey(in, out))
+	keys = append(keys, tradingFeeKeys(in, out)...)
+	// Trading fees are added to the fee pool shard of [taker] and the maker
+	// rebate is paid to [owner].
+	keys = append(keys,
+		storage.PrefixFeePoolKey(in, storage.FeePoolShard(taker)),
+		storage.PrefixFeePoolKey(out, storage.FeePoolShard(taker)),
+		storage.PrefixBalanceKey(owner, out),
+	)
+	return keys
 }
 
 func (f *FillOrder) Execute(
 	ctx context.Context,
-	_ chain.Rules,
+	r chain.Rules,
 	db chain.Database,
-	_ int64,
+	t int64,
 	rauth chain.Auth,
 	_ ids.ID,
 	_ bool,
 ) (*chain.Result, error) {
 	actor := auth.GetActor(rauth)
-	exists, in, inTick, out, outTick, remaining, owner, err := storage.GetOrder(ctx, db, f.Order)
+	if f.Value == 0 {
+		// This should be guarded via [Unmarshal] but we check anyways.
+		return &chain.Result{Success: false, Units: basePrice, Output: OutputValueZero}, nil
+	}
+	or, output := fillOrder(ctx, r, db, t, f.Order, f.Owner, f.In, f.Out, f.Value, actor, func(amount uint64) error {
+		return spend(ctx, db, rauth, f.In, amount)
+	})
+	if len(output) > 0 {
+		return &chain.Result{Success: false, Units: basePrice, Output: output}, nil
+	}
+	output, err := or.Marshal()
 	if err != nil {
 		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
 	}
+	return &chain.Result{Success: true, Units: basePrice + tradeSucceededPrice, Output: output}, nil
+}
+
+// fillOrder trades up to [value] of [in] for the [out] of [order] on behalf of
+// [taker]. The [in] used by the trade is collected from [taker] with [pay].
+//
+// If the trade fails, the reason is returned as the output.
+func fillOrder(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	order ids.ID,
+	orderOwner crypto.PublicKey,
+	fillIn ids.ID,
+	fillOut ids.ID,
+	value uint64,
+	taker crypto.PublicKey,
+	pay func(uint64) error,
+) (*OrderResult, []byte) {
+	exists, in, inTick, out, outTick, remaining, owner, err := storage.GetOrder(ctx, db, order)
+	if err != nil {
+		return nil, utils.ErrBytes(err)
+	}
 	if !exists {
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputOrderMissing}, nil
+		return nil, OutputOrderMissing
 	}
-	if owner != f.Owner {
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputWrongOwner}, nil
+	if owner != orderOwner {
+		return nil, OutputWrongOwner
 	}
-	if in != f.In {
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputWrongIn}, nil
+	if in != fillIn {
+		return nil, OutputWrongIn
 	}
-	if out != f.Out {
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputWrongOut}, nil
+	if out != fillOut {
+		return nil, OutputWrongOut
 	}
-	if f.Value == 0 {
-		// This should be guarded via [Unmarshal] but we check anyways.
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputValueZero}, nil
+	if value%inTick != 0 {
+		return nil, OutputValueMisaligned
+	}
+	// The order owner's [Out] is escrowed in the order, so we check whether the
+	// owner is still allowed to move it.
+	if output := checkTradable(ctx, db, in, taker); len(output) > 0 {
+		return nil, output
 	}
-	if f.Value%inTick != 0 {
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputValueMisaligned}, nil
+	if output := checkTradable(ctx, db, out, owner); len(output) > 0 {
+		return nil, output
+	}
+	if output := checkReceivable(ctx, db, in, owner); len(output) > 0 {
+		return nil, output
+	}
+	if output := checkReceivable(ctx, db, out, taker); len(output) > 0 {
+		return nil, output
 	}
 	// Determine amount of [Out] counterparty will receive if the trade is
 	// successful.
-	outputAmount, err := smath.Mul64(outTick, f.Value/inTick)
+	outputAmount, err := smath.Mul64(outTick, value/inTick)
 	if err != nil {
-		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+		return nil, utils.ErrBytes(err)
 	}
 	if outputAmount == 0 {
-		// This should never happen because [f.Value] > 0
-		return &chain.Result{
-			Success: false,
-			Units:   basePrice,
-			Output:  OutputInsufficientOutput,
-		}, nil
+		// This should never happen because [value] > 0
+		return nil, OutputInsufficientOutput
 	}
 	var (
-		inputAmount    = f.Value
+		inputAmount    = value
 		shouldDelete   = false
 		orderRemaining uint64
 	)
@@ -128,32 +188,83 @@ func (f *FillOrder) Execute(
 	}
 	if inputAmount == 0 {
 		// Don't allow free trades (can happen due to refund rounding)
-		return &chain.Result{Success: false, Units: basePrice, Output: OutputInsufficientInput}, nil
+		return nil, OutputInsufficientInput
 	}
-	if err := storage.SubBalance(ctx, db, actor, f.In, inputAmount); err != nil {
-		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+	// The maker fee is taken from the [In] received by the owner and the taker
+	// fee is taken from the [Out] received by the filler. A portion of the
+	// taker fee may be rebated to the owner.
+	c := treasuryConfig(r)
+	var makerFee, takerFee, makerRebate uint64
+	if c.FeeRecipient != crypto.EmptyPublicKey {
+		fees, err := tradingFeesAt(ctx, r, db, t, in, out)
+		if err != nil {
+			return nil, utils.ErrBytes(err)
+		}
+		makerFee = tutils.BasisPoints(inputAmount, fees.MakerFee)
+		takerFee = tutils.BasisPoints(outputAmount, fees.TakerFee)
+		makerRebate = tutils.BasisPoints(outputAmount, fees.MakerRebate)
+		if makerRebate > takerFee {
+			makerRebate = takerFee
+		}
 	}
-	if err := storage.AddBalance(ctx, db, f.Owner, f.In, inputAmount); err != nil {
-		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+	var treasuryFee uint64
+	if c.Treasury != crypto.EmptyPublicKey {
+		treasuryFee = tutils.BasisPoints(outputAmount, c.TakerFee)
 	}
-	if err := storage.AddBalance(ctx, db, actor, f.Out, outputAmount); err != nil {
-		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+	if takerFee > outputAmount-treasuryFee {
+		return nil, OutputInsufficientOutput
+	}
+	if err := pay(inputAmount); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if err := storage.AddBalance(ctx, db, owner, in, inputAmount-makerFee); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if err := storage.AddBalance(ctx, db, taker, out, outputAmount-takerFee-treasuryFee); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	// Fees are not credited to [c.Treasury] or [c.FeeRecipient] until the pool
+	// is settled with [SettleFees], which checks that they can receive [in]
+	// and [out] (their keys can't be known when [StateKeys] is called).
+	if err := addToFeePool(ctx, db, in, taker, 0, makerFee); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if err := addToFeePool(ctx, db, out, taker, treasuryFee, takerFee-makerRebate); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if makerRebate > 0 {
+		if err := storage.AddBalance(ctx, db, owner, out, makerRebate); err != nil {
+			return nil, utils.ErrBytes(err)
+		}
+	}
+	// If either asset is an NFT, it now belongs to whoever received it
+	if err := setNFTOwner(ctx, db, in, owner); err != nil {
+		return nil, utils.ErrBytes(err)
+	}
+	if err := setNFTOwner(ctx, db, out, taker); err != nil {
+		return nil, utils.ErrBytes(err)
 	}
 	if shouldDelete {
-		if err := storage.DeleteOrder(ctx, db, f.Order); err != nil {
-			return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+		if err := storage.DeleteOrder(ctx, db, order); err != nil {
+			return nil, utils.ErrBytes(err)
 		}
 	} else {
-		if err := storage.SetOrder(ctx, db, f.Order, in, inTick, out, outTick, orderRemaining, owner); err != nil {
-			return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+		if err := storage.SetOrder(ctx, db, order, in, inTick, out, outTick, orderRemaining, owner); err != nil {
+			return nil, utils.ErrBytes(err)
 		}
 	}
-	or := &OrderResult{In: inputAmount, Out: outputAmount, Remaining: orderRemaining}
-	output, err := or.Marshal()
-	if err != nil {
-		return &chain.Result{Success: false, Units: basePrice, Output: utils.ErrBytes(err)}, nil
+	// Trigger orders on this pair compare against the price of the last trade
+	if err := storage.SetLastTrade(ctx, db, in, out, inputAmount, outputAmount); err != nil {
+		return nil, utils.ErrBytes(err)
 	}
-	return &chain.Result{Success: true, Units: basePrice + tradeSucceededPrice, Output: output}, nil
+	return &OrderResult{
+		In:          inputAmount,
+		Out:         outputAmount,
+		Remaining:   orderRemaining,
+		MakerFee:    makerFee,
+		TakerFee:    takerFee + treasuryFee,
+		MakerRebate: makerRebate,
+	}, nil
 }
 
 func (*FillOrder) MaxUnits(chain.Rules) uint64 {
@@ -178,32 +289,53 @@ func UnmarshalFillOrder(p *codec.Packer, _ *warp.Message) (chain.Action, error)
 	return &fill, p.Err()
 }
 
-func (*FillOrder) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*FillOrder) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, FillOrderName)
 }
 
 // OrderResult is a custom successful response output that provides information
 // about a successful trade.
 type OrderResult struct {
-	In        uint64 `json:"in"`
+	// In is the amount of [In] paid by the filler (before [MakerFee]).
+	In uint64 `json:"in"`
+
+	// Out is the amount of [Out] taken from the order (before [TakerFee]).
 	Out       uint64 `json:"out"`
 	Remaining uint64 `json:"remaining"`
+
+	// MakerFee is the amount of [In] withheld from the owner.
+	MakerFee uint64 `json:"makerFee"`
+
+	// TakerFee is the amount of [Out] withheld from the filler.
+	TakerFee uint64 `json:"takerFee"`
+
+	// MakerRebate is the amount of [Out] returned to the owner from
+	// [TakerFee].
+	MakerRebate uint64 `json:"makerRebate"`
 }
 
 func UnmarshalOrderResult(b []byte) (*OrderResult, error) {
-	p := codec.NewReader(b, consts.Uint64Len*3)
+	p := codec.NewReader(b, consts.Uint64Len*6)
 	var result OrderResult
 	result.In = p.UnpackUint64(true)
 	result.Out = p.UnpackUint64(true)
 	result.Remaining = p.UnpackUint64(false) // if 0, deleted
+	if !p.Empty() {
+		// Results created before trading fees were added do not include fees
+		result.MakerFee = p.UnpackUint64(false)
+		result.TakerFee = p.UnpackUint64(false)
+		result.MakerRebate = p.UnpackUint64(false)
+	}
 	return &result, p.Err()
 }
 
 func (o *OrderResult) Marshal() ([]byte, error) {
-	p := codec.NewWriter(consts.Uint64Len * 3)
+	p := codec.NewWriter(consts.Uint64Len * 6)
 	p.PackUint64(o.In)
 	p.PackUint64(o.Out)
 	p.PackUint64(o.Remaining)
+	p.PackUint64(o.MakerFee)
+	p.PackUint64(o.TakerFee)
+	p.PackUint64(o.MakerRebate)
 	return p.Bytes(), p.Err()
 }
@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*FreezeAccount)(nil)
+
+type FreezeAccount struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Account is the holder whose balance of [Asset] is being frozen.
+	Account crypto.PublicKey `json:"account"`
+
+	// Frozen determines whether [Account] can move its balance of [Asset]. Set
+	// this to false to unfreeze [Account].
+	Frozen bool `json:"frozen"`
+}
+
+func (f *FreezeAccount) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(f.Asset),
+		storage.PrefixFrozenKey(f.Asset, f.Account),
+	}
+}
+
+func (f *FreezeAccount) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := f.MaxUnits(r) // max units == units
+	if f.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	exists, _, _, owner, isWarp, _, err := storage.GetAsset(ctx, db, f.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if isWarp {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetFrozen(ctx, db, f.Asset, f.Account, f.Frozen); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*FreezeAccount) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + 1
+}
+
+func (f *FreezeAccount) Marshal(p *codec.Packer) {
+	p.PackID(f.Asset)
+	p.PackPublicKey(f.Account)
+	p.PackBool(f.Frozen)
+}
+
+func UnmarshalFreezeAccount(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var freeze FreezeAccount
+	p.UnpackID(true, &freeze.Asset)          // empty ID is the native asset
+	p.UnpackPublicKey(true, &freeze.Account) // cannot freeze blackhole
+	freeze.Frozen = p.UnpackBool()
+	return &freeze, p.Err()
+}
+
+func (*FreezeAccount) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, FreezeAccountName)
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/storage"
+	"tokenvm/utils"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// Params that can be changed by governance.
+//
+// Only params read while actions are executed can be changed by governance
+// because they are read from state at the time of each block. Block limits,
+// unit prices, and warp fees can only be changed by a network upgrade (see
+// [genesis.Upgrade]).
+const (
+	ParamMakerFee uint8 = iota
+	ParamTakerFee
+	ParamMakerRebate
+	ParamPairMakerFee
+	ParamPairTakerFee
+	ParamPairMakerRebate
+)
+
+// ParamNames is indexed by param and matches the JSON name of each param in
+// the genesis.
+var ParamNames = []string{
+	"makerFee",
+	"takerFee",
+	"makerRebate",
+	"pairMakerFee",
+	"pairTakerFee",
+	"pairMakerRebate",
+}
+
+// Params returns every param that can be changed by governance.
+func Params() []uint8 {
+	params := make([]uint8, len(ParamNames))
+	for i := range params {
+		params[i] = uint8(i)
+	}
+	return params
+}
+
+// ParamFromName returns the param with the JSON name [name].
+func ParamFromName(name string) (uint8, bool) {
+	for i, n := range ParamNames {
+		if n == name {
+			return uint8(i), true
+		}
+	}
+	return 0, false
+}
+
+// PairParam returns true if [param] is set for a single pair of assets
+// instead of the entire chain.
+func PairParam(param uint8) bool {
+	switch param {
+	case ParamPairMakerFee, ParamPairTakerFee, ParamPairMakerRebate:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidParam returns true if [value] can safely be assigned to [param].
+func ValidParam(param uint8, value uint64) bool {
+	if int(param) >= len(ParamNames) {
+		return false
+	}
+	// Every param is a fee in basis points
+	return value <= utils.MaxBasisPoints
+}
+
+// ParamKey returns the key of [param] (for the [in]-[out] pair if it is a pair
+// param).
+func ParamKey(param uint8, in ids.ID, out ids.ID) []byte {
+	if PairParam(param) {
+		return storage.PrefixPairParamKey(param, in, out)
+	}
+	return storage.PrefixParamKey(param)
+}
+
+// setParam sets [param] to [value] starting at [activation]. If the value set
+// by an earlier proposal is in effect at [t], it stays in effect until then.
+func setParam(
+	ctx context.Context,
+	db chain.Database,
+	t int64,
+	param uint8,
+	in ids.ID,
+	out ids.ID,
+	value uint64,
+	activation int64,
+) error {
+	k := ParamKey(param, in, out)
+	activeSet, active, pending, pendingActivation, err := storage.GetParam(ctx, db, k)
+	if err != nil {
+		return err
+	}
+	active, activeSet = storage.ParamAt(activeSet, active, pending, pendingActivation, t)
+	return storage.SetParam(ctx, db, k, activeSet, active, value, activation)
+}
+
+// tradingFeeKeys returns the keys of the params that determine the trading
+// fees of the [in]-[out] pair.
+func tradingFeeKeys(in ids.ID, out ids.ID) [][]byte {
+	return [][]byte{
+		ParamKey(ParamMakerFee, in, out),
+		ParamKey(ParamTakerFee, in, out),
+		ParamKey(ParamMakerRebate, in, out),
+		ParamKey(ParamPairMakerFee, in, out),
+		ParamKey(ParamPairTakerFee, in, out),
+		ParamKey(ParamPairMakerRebate, in, out),
+	}
+}
+
+// tradingFeesAt returns the trading fees of the [in]-[out] pair in effect at
+// [t].
+//
+// Fees set by governance for the pair take precedence over the fees of the
+// pair in [r], which take precedence over fees set by governance for the
+// chain.
+func tradingFeesAt(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	in ids.ID,
+	out ids.ID,
+) (*TradingFees, error) {
+	base := tradingFees(r, in, out)
+	fees := *base
+	for _, f := range []struct {
+		fee       *uint64
+		param     uint8
+		pairParam uint8
+	}{
+		{&fees.MakerFee, ParamMakerFee, ParamPairMakerFee},
+		{&fees.TakerFee, ParamTakerFee, ParamPairTakerFee},
+		{&fees.MakerRebate, ParamMakerRebate, ParamPairMakerRebate},
+	} {
+		params := []uint8{f.param, f.pairParam}
+		if base.Pair {
+			params = params[1:]
+		}
+		for _, param := range params {
+			activeSet, active, pending, activation, err := storage.GetParam(ctx, db, ParamKey(param, in, out))
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := storage.ParamAt(activeSet, active, pending, activation, t); ok {
+				*f.fee = v
+			}
+		}
+	}
+	return &fees, nil
+}
+
+// GovernanceKey is the key used to look up the [GovernanceConfig] with
+// [chain.Rules.FetchCustom].
+const GovernanceKey = "governance"
+
+// GovernanceConfig determines how proposals are decided.
+type GovernanceConfig struct {
+	// VotingPeriod is how long (in seconds) a proposal accepts votes after it
+	// is created.
+	VotingPeriod int64
+
+	// Timelock is how long (in seconds) after voting ends a proposal must wait
+	// before it can be executed.
+	Timelock int64
+
+	// ActivationDelay is how long (in seconds) after execution a change takes
+	// effect.
+	ActivationDelay int64
+
+	// Quorum is the minimum amount of the native asset that must vote on a
+	// proposal for it to pass.
+	Quorum uint64
+
+	// Deposit is the amount of the native asset locked by [Propose]. It can be
+	// reclaimed with [ReclaimDeposit] once the proposal is executed or
+	// expires.
+	Deposit uint64
+
+	// Expiry is how long (in seconds) after the timelock ends a proposal can
+	// be executed.
+	Expiry int64
+}
+
+// proposalExpiry returns the time at which a proposal created at [created]
+// can no longer be executed.
+func proposalExpiry(c *GovernanceConfig, created int64) int64 {
+	return created + c.VotingPeriod + c.Timelock + c.Expiry
+}
+
+func governanceConfig(r chain.Rules) (*GovernanceConfig, bool) {
+	v, ok := r.FetchCustom(GovernanceKey)
+	if !ok {
+		return nil, false
+	}
+	c, ok := v.(*GovernanceConfig)
+	return c, ok
+}
@@ -44,8 +44,10 @@ func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		assetID = i.warpTransfer.Asset
 		keys = [][]byte{
 			storage.PrefixLoanKey(i.warpTransfer.Asset, i.warpMessage.SourceChainID),
+			storage.PrefixLoanDestinationsKey(i.warpTransfer.Asset),
 			storage.PrefixBalanceKey(i.warpTransfer.To, i.warpTransfer.Asset),
 		}
+		keys = append(keys, receiveKeys(i.warpTransfer.Asset, i.warpTransfer.To)...)
 	} else {
 		assetID = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
 		keys = [][]byte{
@@ -58,6 +60,7 @@ func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 	// sure it is paid.
 	if i.warpTransfer.Reward > 0 {
 		keys = append(keys, storage.PrefixBalanceKey(actor, assetID))
+		keys = append(keys, receiveKeys(assetID, actor)...)
 	}
 
 	// If the [warpTransfer] requests a swap, we add the state keys to transfer
@@ -66,6 +69,10 @@ func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
 		keys = append(keys, storage.PrefixBalanceKey(actor, i.warpTransfer.AssetOut))
 		keys = append(keys, storage.PrefixBalanceKey(actor, assetID))
 		keys = append(keys, storage.PrefixBalanceKey(i.warpTransfer.To, i.warpTransfer.AssetOut))
+		keys = append(keys, controlKeys(assetID, i.warpTransfer.To)...)
+		keys = append(keys, controlKeys(i.warpTransfer.AssetOut, actor)...)
+		keys = append(keys, receiveKeys(assetID, actor)...)
+		keys = append(keys, receiveKeys(i.warpTransfer.AssetOut, i.warpTransfer.To)...)
 	}
 	return keys
 }
@@ -76,7 +83,7 @@ func (i *ImportAsset) executeMint(
 	actor crypto.PublicKey,
 ) []byte {
 	asset := ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
-	exists, metadata, supply, _, warp, err := storage.GetAsset(ctx, db, asset)
+	exists, metadata, supply, _, warp, _, err := storage.GetAsset(ctx, db, asset)
 	if err != nil {
 		return utils.ErrBytes(err)
 	}
@@ -95,7 +102,7 @@ func (i *ImportAsset) executeMint(
 	if err != nil {
 		return utils.ErrBytes(err)
 	}
-	if err := storage.SetAsset(ctx, db, asset, metadata, newSupply, crypto.EmptyPublicKey, true); err != nil {
+	if err := storage.SetAsset(ctx, db, asset, metadata, newSupply, crypto.EmptyPublicKey, true, 0); err != nil {
 		return utils.ErrBytes(err)
 	}
 	if err := storage.AddBalance(ctx, db, i.warpTransfer.To, asset, i.warpTransfer.Value); err != nil {
@@ -114,6 +121,16 @@ func (i *ImportAsset) executeReturn(
 	db chain.Database,
 	actor crypto.PublicKey,
 ) []byte {
+	// Assets minted by [executeMint] are never in allowlist mode, so we only
+	// need to check recipients of returned assets.
+	if output := checkReceivable(ctx, db, i.warpTransfer.Asset, i.warpTransfer.To); len(output) > 0 {
+		return output
+	}
+	if i.warpTransfer.Reward > 0 {
+		if output := checkReceivable(ctx, db, i.warpTransfer.Asset, actor); len(output) > 0 {
+			return output
+		}
+	}
 	if err := storage.SubLoan(
 		ctx, db, i.warpTransfer.Asset,
 		i.warpMessage.SourceChainID, i.warpTransfer.Value,
@@ -190,13 +207,25 @@ func (i *ImportAsset) Execute(
 	} else {
 		assetIn = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
 	}
+	if output := checkTransferable(ctx, db, assetIn, i.warpTransfer.To); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkTransferable(ctx, db, i.warpTransfer.AssetOut, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkReceivable(ctx, db, assetIn, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkReceivable(ctx, db, i.warpTransfer.AssetOut, i.warpTransfer.To); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
 	if err := storage.SubBalance(ctx, db, i.warpTransfer.To, assetIn, i.warpTransfer.SwapIn); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.AddBalance(ctx, db, actor, assetIn, i.warpTransfer.SwapIn); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
+	if err := spend(ctx, db, rauth, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.AddBalance(ctx, db, i.warpTransfer.To, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
@@ -236,7 +265,6 @@ func UnmarshalImportAsset(p *codec.Packer, wm *warp.Message) (chain.Action, erro
 	return &imp, nil
 }
 
-func (*ImportAsset) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*ImportAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ImportAssetName)
 }
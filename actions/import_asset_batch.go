@@ -0,0 +1,165 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*BatchImportAsset)(nil)
+
+// BatchImportAsset credits every recipient of a warp message produced by
+// [BatchExportAsset]. Either all transfers in the batch are credited or none
+// are.
+type BatchImportAsset struct {
+	// warpBatchTransfer is parsed from the inner *warp.Message
+	warpBatchTransfer *WarpBatchTransfer
+
+	// warpMessage is the full *warp.Message parsed from [chain.Transaction]
+	warpMessage *warp.Message
+}
+
+func (i *BatchImportAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
+	keys := make([][]byte, 0, len(i.warpBatchTransfer.Transfers)*5)
+	for _, transfer := range i.warpBatchTransfer.Transfers {
+		if transfer.Return {
+			keys = append(keys,
+				storage.PrefixLoanKey(transfer.Asset, i.warpMessage.SourceChainID),
+				storage.PrefixLoanDestinationsKey(transfer.Asset),
+				storage.PrefixBalanceKey(transfer.To, transfer.Asset),
+			)
+			keys = append(keys, receiveKeys(transfer.Asset, transfer.To)...)
+			continue
+		}
+		assetID := ImportedAssetID(transfer.Asset, i.warpMessage.SourceChainID)
+		keys = append(keys,
+			storage.PrefixAssetKey(assetID),
+			storage.PrefixBalanceKey(transfer.To, assetID),
+		)
+	}
+	return keys
+}
+
+func (i *BatchImportAsset) executeMint(
+	ctx context.Context,
+	db chain.Database,
+	transfer *WarpBatchTransferEntry,
+) []byte {
+	asset := ImportedAssetID(transfer.Asset, i.warpMessage.SourceChainID)
+	exists, metadata, supply, _, warp, _, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if exists && !warp {
+		// Should not be possible
+		return OutputConflictingAsset
+	}
+	if !exists {
+		metadata = ImportedAssetMetadata(transfer.Asset, i.warpMessage.SourceChainID)
+	}
+	newSupply, err := smath.Add64(supply, transfer.Value)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if err := storage.SetAsset(ctx, db, asset, metadata, newSupply, crypto.EmptyPublicKey, true, 0); err != nil {
+		return utils.ErrBytes(err)
+	}
+	if err := storage.AddBalance(ctx, db, transfer.To, asset, transfer.Value); err != nil {
+		return utils.ErrBytes(err)
+	}
+	return nil
+}
+
+func (i *BatchImportAsset) executeReturn(
+	ctx context.Context,
+	db chain.Database,
+	transfer *WarpBatchTransferEntry,
+) []byte {
+	if output := checkReceivable(ctx, db, transfer.Asset, transfer.To); len(output) > 0 {
+		return output
+	}
+	if err := storage.SubLoan(
+		ctx, db, transfer.Asset,
+		i.warpMessage.SourceChainID, transfer.Value,
+	); err != nil {
+		return utils.ErrBytes(err)
+	}
+	if err := storage.AddBalance(
+		ctx, db, transfer.To,
+		transfer.Asset, transfer.Value,
+	); err != nil {
+		return utils.ErrBytes(err)
+	}
+	return nil
+}
+
+func (i *BatchImportAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	_ chain.Auth,
+	_ ids.ID,
+	warpVerified bool,
+) (*chain.Result, error) {
+	unitsUsed := i.MaxUnits(r) // max units == units
+	if !warpVerified {
+		return &chain.Result{
+			Success: false,
+			Units:   unitsUsed,
+			Output:  OutputWarpVerificationFailed,
+		}, nil
+	}
+	for _, transfer := range i.warpBatchTransfer.Transfers {
+		var output []byte
+		if transfer.Return {
+			output = i.executeReturn(ctx, db, transfer)
+		} else {
+			output = i.executeMint(ctx, db, transfer)
+		}
+		if len(output) > 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (i *BatchImportAsset) MaxUnits(chain.Rules) uint64 {
+	return uint64(len(i.warpMessage.Payload))
+}
+
+// Everything needed to execute [BatchImportAsset] is contained in the warp
+// message, so we only encode the type byte from the registry.
+func (*BatchImportAsset) Marshal(*codec.Packer) {}
+
+func UnmarshalBatchImportAsset(p *codec.Packer, wm *warp.Message) (chain.Action, error) {
+	var (
+		imp BatchImportAsset
+		err error
+	)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	imp.warpMessage = wm
+	imp.warpBatchTransfer, err = UnmarshalWarpBatchTransfer(imp.warpMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+func (*BatchImportAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, BatchImportAssetName)
+}
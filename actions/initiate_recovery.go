@@ -0,0 +1,169 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*InitiateRecovery)(nil)
+
+// recoveryPrefix is prepended to the digest signed by guardians so that an
+// approval can never be interpreted as a transaction signature.
+var recoveryPrefix = []byte("tokenvm/recovery")
+
+// RecoveryDigest returns the message a guardian of [account] must sign to
+// approve rotating control of [account] to [key] on [chainID]. [nonce] is the
+// current recovery nonce of [account] (returned by the recovery RPC).
+func RecoveryDigest(chainID ids.ID, account crypto.PublicKey, key crypto.PublicKey, nonce uint64) []byte {
+	p := codec.NewWriter(len(recoveryPrefix) + consts.IDLen + crypto.PublicKeyLen*2 + consts.Uint64Len)
+	p.PackFixedBytes(recoveryPrefix)
+	p.PackID(chainID)
+	p.PackPublicKey(account)
+	p.PackPublicKey(key)
+	p.PackUint64(nonce)
+	return p.Bytes()
+}
+
+// RecoveryApproval is a signature over [RecoveryDigest] by the key that
+// controls [Guardian].
+type RecoveryApproval struct {
+	Guardian  crypto.PublicKey `json:"guardian"`
+	Signature crypto.Signature `json:"signature"`
+}
+
+// InitiateRecovery starts rotating control of [Account] to [Key] once enough
+// guardians of [Account] have approved it. The rotation can be completed with
+// [CompleteRecovery] after the delay set with [SetGuardians].
+//
+// Anyone can submit the approvals (and pay the fee), so the owner of a lost
+// key doesn't need any funds to recover their account.
+type InitiateRecovery struct {
+	// Account is the account to recover.
+	Account crypto.PublicKey `json:"account"`
+
+	// Key is the key that will control [Account].
+	Key crypto.PublicKey `json:"key"`
+
+	// Approvals are signatures from guardians of [Account].
+	Approvals []*RecoveryApproval `json:"approvals"`
+}
+
+func (i *InitiateRecovery) StateKeys(chain.Auth, ids.ID) [][]byte {
+	keys := [][]byte{
+		storage.PrefixGuardiansKey(i.Account),
+		storage.PrefixRecoveryKey(i.Account),
+	}
+	for _, approval := range i.Approvals {
+		keys = append(keys, storage.PrefixAccountKey(approval.Guardian))
+	}
+	return keys
+}
+
+func (i *InitiateRecovery) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	_ chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := i.MaxUnits(r) // max units == units
+	threshold, delay, nonce, guardians, err := storage.GetGuardians(ctx, db, i.Account)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if len(guardians) == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputGuardiansMissing}, nil
+	}
+	pending, _, _, err := storage.GetRecovery(ctx, db, i.Account)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if pending {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputRecoveryPending}, nil
+	}
+	allowed := set.NewSet[crypto.PublicKey](len(guardians))
+	allowed.Add(guardians...)
+	digest := RecoveryDigest(getChainID(r), i.Account, i.Key, nonce)
+	for _, approval := range i.Approvals {
+		if !allowed.Contains(approval.Guardian) {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNotGuardian}, nil
+		}
+		key, err := storage.GetAccountKey(ctx, db, approval.Guardian)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if !crypto.Verify(digest, key, approval.Signature) {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidSignature}, nil
+		}
+	}
+	if len(i.Approvals) < int(threshold) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputThresholdNotMet}, nil
+	}
+	if err := storage.SetRecovery(ctx, db, i.Account, i.Key, t+delay); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	// We increment the nonce so that these approvals can't be used again
+	if err := storage.SetGuardians(ctx, db, i.Account, threshold, delay, nonce+1, guardians); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (i *InitiateRecovery) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen*2 + consts.IntLen +
+		uint64(len(i.Approvals))*(crypto.PublicKeyLen+crypto.SignatureLen)
+}
+
+func (i *InitiateRecovery) Marshal(p *codec.Packer) {
+	p.PackPublicKey(i.Account)
+	p.PackPublicKey(i.Key)
+	p.PackInt(len(i.Approvals))
+	for _, approval := range i.Approvals {
+		p.PackPublicKey(approval.Guardian)
+		p.PackSignature(approval.Signature)
+	}
+}
+
+func UnmarshalInitiateRecovery(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var recovery InitiateRecovery
+	p.UnpackPublicKey(true, &recovery.Account)
+	p.UnpackPublicKey(true, &recovery.Key)
+	count := p.UnpackInt(true)
+	if count > MaxGuardians {
+		return nil, ErrTooManyGuardians
+	}
+	recovery.Approvals = make([]*RecoveryApproval, count)
+	seen := set.NewSet[crypto.PublicKey](count)
+	for i := range recovery.Approvals {
+		var approval RecoveryApproval
+		p.UnpackPublicKey(true, &approval.Guardian)
+		p.UnpackSignature(&approval.Signature)
+		if seen.Contains(approval.Guardian) {
+			return nil, ErrDuplicateGuardian
+		}
+		seen.Add(approval.Guardian)
+		recovery.Approvals[i] = &approval
+	}
+	return &recovery, p.Err()
+}
+
+func (*InitiateRecovery) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, InitiateRecoveryName)
+}
@@ -40,6 +40,7 @@ func (m *MintAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
 	return [][]byte{
 		storage.PrefixAssetKey(m.Asset),
 		storage.PrefixBalanceKey(m.To, m.Asset),
+		storage.PrefixAllowlistKey(m.Asset, m.To),
 	}
 }
 
@@ -60,7 +61,7 @@ func (m *MintAsset) Execute(
 	if m.Value == 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
 	}
-	exists, metadata, supply, owner, isWarp, err := storage.GetAsset(ctx, db, m.Asset)
+	exists, metadata, supply, owner, isWarp, flags, err := storage.GetAsset(ctx, db, m.Asset)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -77,11 +78,14 @@ func (m *MintAsset) Execute(
 			Output:  OutputWrongOwner,
 		}, nil
 	}
+	if output := checkReceivable(ctx, db, m.Asset, m.To); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
 	newSupply, err := smath.Add64(supply, m.Value)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.SetAsset(ctx, db, m.Asset, metadata, newSupply, actor, isWarp); err != nil {
+	if err := storage.SetAsset(ctx, db, m.Asset, metadata, newSupply, actor, isWarp, flags); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.AddBalance(ctx, db, m.To, m.Asset, m.Value); err != nil {
@@ -110,7 +114,6 @@ func UnmarshalMintAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error)
 	return &mint, p.Err()
 }
 
-func (*MintAsset) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*MintAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, MintAssetName)
 }
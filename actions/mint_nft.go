@@ -0,0 +1,151 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*MintNFT)(nil)
+
+var nftIDPrefix = []byte("tokenvm/nft")
+
+// NFTID returns the ID of token [id] of [collection].
+//
+// Each NFT is also an asset with this ID and a supply of 1, so it can be
+// traded on the order book like any other asset.
+func NFTID(collection ids.ID, id uint64) ids.ID {
+	p := codec.NewWriter(len(nftIDPrefix) + consts.IDLen + consts.Uint64Len)
+	p.PackFixedBytes(nftIDPrefix)
+	p.PackID(collection)
+	p.PackUint64(id)
+	return utils.ToID(p.Bytes())
+}
+
+// MintNFT creates token [ID] of [Collection] and gives it to [To]. Only the
+// owner of [Collection] can mint, and an [ID] can never be minted twice (even
+// if it is burned).
+type MintNFT struct {
+	// Collection is the collection to mint from.
+	Collection ids.ID `json:"collection"`
+
+	// ID is the unique ID of the NFT in [Collection].
+	ID uint64 `json:"id"`
+
+	// To is the owner of the NFT.
+	To crypto.PublicKey `json:"to"`
+
+	// Metadata is the metadata (or URI) of the NFT.
+	Metadata []byte `json:"metadata"`
+}
+
+func (m *MintNFT) StateKeys(chain.Auth, ids.ID) [][]byte {
+	nft := NFTID(m.Collection, m.ID)
+	return [][]byte{
+		storage.PrefixCollectionKey(m.Collection),
+		storage.PrefixAssetKey(nft),
+		storage.PrefixNFTKey(nft),
+		storage.PrefixBalanceKey(m.To, nft),
+	}
+}
+
+func (m *MintNFT) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := m.MaxUnits(r) // max units == units
+	if len(m.Metadata) > MaxMetadataSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
+	}
+	exists, owner, supply, metadata, err := storage.GetCollection(ctx, db, m.Collection)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputCollectionMissing}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	nft := NFTID(m.Collection, m.ID)
+	exists, _, _, _, _, _, err = storage.GetAsset(ctx, db, nft)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTAlreadyExists}, nil
+	}
+	newSupply, err := smath.Add64(supply, 1)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetCollection(ctx, db, m.Collection, owner, newSupply, metadata); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	// The asset of an NFT has no owner so that nobody can mint more of it or
+	// otherwise modify it.
+	if err := storage.SetAsset(
+		ctx,
+		db,
+		nft,
+		m.Metadata,
+		1,
+		crypto.EmptyPublicKey,
+		false,
+		storage.AssetNonFungible,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetNFT(ctx, db, nft, m.Collection, m.ID, m.To); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, m.To, nft, 1); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (m *MintNFT) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len + crypto.PublicKeyLen + uint64(len(m.Metadata))
+}
+
+func (m *MintNFT) Marshal(p *codec.Packer) {
+	p.PackID(m.Collection)
+	p.PackUint64(m.ID)
+	p.PackPublicKey(m.To)
+	p.PackBytes(m.Metadata)
+}
+
+func UnmarshalMintNFT(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var mint MintNFT
+	p.UnpackID(true, &mint.Collection)
+	mint.ID = p.UnpackUint64(false) // 0 is a valid ID
+	p.UnpackPublicKey(true, &mint.To)
+	p.UnpackBytes(MaxMetadataSize, false, &mint.Metadata)
+	return &mint, p.Err()
+}
+
+func (*MintNFT) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, MintNFTName)
+}
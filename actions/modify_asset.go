@@ -57,7 +57,7 @@ func (m *ModifyAsset) Execute(
 	if len(m.Metadata) > MaxMetadataSize {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
 	}
-	exists, _, supply, owner, isWarp, err := storage.GetAsset(ctx, db, m.Asset)
+	exists, _, supply, owner, isWarp, flags, err := storage.GetAsset(ctx, db, m.Asset)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
@@ -74,7 +74,7 @@ func (m *ModifyAsset) Execute(
 			Output:  OutputWrongOwner,
 		}, nil
 	}
-	if err := storage.SetAsset(ctx, db, m.Asset, m.Metadata, supply, m.Owner, isWarp); err != nil {
+	if err := storage.SetAsset(ctx, db, m.Asset, m.Metadata, supply, m.Owner, isWarp, flags); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	return &chain.Result{Success: true, Units: unitsUsed}, nil
@@ -100,7 +100,6 @@ func UnmarshalModifyAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error
 	return &modify, p.Err()
 }
 
-func (*ModifyAsset) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*ModifyAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ModifyAssetName)
 }
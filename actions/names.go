@@ -0,0 +1,140 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+)
+
+const (
+	MinNameSize = 3
+
+	// MaxNameSize is small enough that a name can never be mistaken for an
+	// address or an asset ID.
+	MaxNameSize = 32
+)
+
+// ValidName returns true if [name] can be registered. Names may only contain
+// lowercase letters, digits, and hyphens, and may not start or end with a
+// hyphen.
+func ValidName(name string) bool {
+	if len(name) < MinNameSize || len(name) > MaxNameSize {
+		return false
+	}
+	if name[0] == '-' || name[len(name)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// NameServiceKey is the key used to look up the [NameServiceConfig] with
+// [chain.Rules.FetchCustom].
+const NameServiceKey = "names"
+
+// NameServiceConfig determines the price of names.
+type NameServiceConfig struct {
+	// Fee is the amount of the native asset charged to register or renew a
+	// name. It is added to the fee pool and sent to the treasury by
+	// [SettleFees] (or burned if there is no treasury).
+	Fee uint64
+
+	// Period is how long (in seconds) a registration or renewal lasts.
+	Period int64
+}
+
+func nameServiceConfig(r chain.Rules) (*NameServiceConfig, bool) {
+	v, ok := r.FetchCustom(NameServiceKey)
+	if !ok {
+		return nil, false
+	}
+	c, ok := v.(*NameServiceConfig)
+	return c, ok
+}
+
+// nameFeeKeys returns the state keys touched by [payNameFee].
+func nameFeeKeys(actor crypto.PublicKey) [][]byte {
+	return [][]byte{
+		storage.PrefixBalanceKey(actor, ids.Empty),
+		storage.PrefixFeePoolKey(ids.Empty, storage.FeePoolShard(actor)),
+		storage.PrefixAssetKey(ids.Empty),
+	}
+}
+
+// payNameFee charges [fee] to the actor of [rauth] and adds it to the
+// treasury's share of the fee pool. If there is no treasury, [fee] is burned.
+func payNameFee(ctx context.Context, r chain.Rules, db chain.Database, rauth chain.Auth, fee uint64) error {
+	if fee == 0 {
+		return nil
+	}
+	if err := spend(ctx, db, rauth, ids.Empty, fee); err != nil {
+		return err
+	}
+	if treasuryConfig(r).Treasury == crypto.EmptyPublicKey {
+		return updateSupply(ctx, db, ids.Empty, fee, false)
+	}
+	return addToFeePool(ctx, db, ids.Empty, auth.GetActor(rauth), fee, 0)
+}
+
+// reverseNameKeys returns the state keys touched by [setReverseNames].
+func reverseNameKeys(actor crypto.PublicKey, address crypto.PublicKey, asset ids.ID) [][]byte {
+	keys := [][]byte{}
+	if address == actor {
+		keys = append(keys, storage.PrefixReverseNameKey(address))
+	}
+	if asset != ids.Empty {
+		keys = append(keys,
+			storage.PrefixAssetKey(asset),
+			storage.PrefixReverseNameKey(asset),
+		)
+	}
+	return keys
+}
+
+// setReverseNames makes [name] the reverse record of [address] and [asset]
+// if they are controlled by [actor]. This prevents anyone from labelling an
+// account or asset they don't control.
+func setReverseNames(
+	ctx context.Context,
+	db chain.Database,
+	actor crypto.PublicKey,
+	name string,
+	address crypto.PublicKey,
+	asset ids.ID,
+) error {
+	if address == actor {
+		if err := storage.SetReverseName(ctx, db, address, name); err != nil {
+			return err
+		}
+	}
+	if asset == ids.Empty {
+		return nil
+	}
+	exists, _, _, owner, _, _, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	if !exists || owner != actor {
+		return nil
+	}
+	return storage.SetReverseName(ctx, db, asset, name)
+}
+
+// nameSize is the number of units used to price a name.
+func nameSize(name string) uint64 {
+	return consts.Uint16Len + uint64(len(name))
+}
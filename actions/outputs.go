@@ -29,4 +29,71 @@ var (
 	OutputWrongDestination       = []byte("wrong destination")
 	OutputMustFill               = []byte("must fill request")
 	OutputWarpVerificationFailed = []byte("warp verification failed")
+	OutputPayloadEmpty           = []byte("payload is empty")
+	OutputPayloadTooLarge        = []byte("payload is too large")
+	OutputDestinationNotAllowed  = []byte("destination not allowed for asset")
+	OutputAssetPaused            = []byte("asset is paused")
+	OutputAccountFrozen          = []byte("account is frozen")
+	OutputNotAllowlisted         = []byte("account is not allowlisted")
+	OutputVestingMissing         = []byte("vesting is missing")
+	OutputWrongAsset             = []byte("wrong asset")
+	OutputNothingVested          = []byte("nothing to claim")
+	OutputGovernanceDisabled     = []byte("governance is disabled")
+	OutputProposalMissing        = []byte("proposal is missing")
+	OutputProposalExecuted       = []byte("proposal already executed")
+	OutputVotingClosed           = []byte("voting is closed")
+	OutputVotingOpen             = []byte("voting is still open")
+	OutputAlreadyVoted           = []byte("already voted")
+	OutputVoteMissing            = []byte("vote is missing")
+	OutputTimelockActive         = []byte("timelock has not expired")
+	OutputQuorumNotReached       = []byte("quorum not reached")
+	OutputProposalRejected       = []byte("proposal rejected")
+	OutputWrongParam             = []byte("wrong param")
+	OutputWrongPair              = []byte("wrong pair")
+	OutputProposalExpired        = []byte("proposal expired")
+	OutputNotProposer            = []byte("not the proposer")
+	OutputDepositReclaimed       = []byte("deposit already reclaimed")
+	OutputDepositLocked          = []byte("deposit is locked")
+	OutputSelfSwap               = []byte("cannot settle own offer")
+	OutputOfferExpired           = []byte("offer expired")
+	OutputInvalidSignature       = []byte("invalid signature")
+	OutputNonceUsed              = []byte("nonce already used")
+	OutputSessionExpired         = []byte("session key expired")
+	OutputSessionMissing         = []byte("session key is missing")
+	OutputSessionNotAllowed      = []byte("session keys cannot manage session keys")
+	OutputGuardiansMissing       = []byte("account has no guardians")
+	OutputNotGuardian            = []byte("approval not signed by guardian")
+	OutputThresholdNotMet        = []byte("not enough guardian approvals")
+	OutputRecoveryPending        = []byte("recovery already pending")
+	OutputRecoveryMissing        = []byte("recovery is missing")
+	OutputRecoveryNotReady       = []byte("recovery delay has not passed")
+	OutputNameServiceDisabled    = []byte("name service is disabled")
+	OutputNameTaken              = []byte("name already registered")
+	OutputNameMissing            = []byte("name is missing")
+	OutputNameExpired            = []byte("name expired")
+	OutputNonFungible            = []byte("asset is non-fungible")
+	OutputCollectionMissing      = []byte("collection is missing")
+	OutputNFTAlreadyExists       = []byte("nft already exists")
+	OutputNFTMissing             = []byte("nft is missing")
+	OutputStreamMissing          = []byte("stream is missing")
+	OutputStreamEnded            = []byte("stream has ended")
+	OutputNothingStreamed        = []byte("nothing streamed to withdraw")
+	OutputAirdropMissing         = []byte("airdrop is missing")
+	OutputAirdropExpired         = []byte("airdrop expired")
+	OutputAirdropActive          = []byte("airdrop has not expired")
+	OutputAirdropClaimed         = []byte("airdrop already claimed")
+	OutputAirdropDepleted        = []byte("airdrop is depleted")
+	OutputInvalidProof           = []byte("invalid proof")
+	OutputInvalidEscrowParties   = []byte("payer, payee, and arbiter must be different")
+	OutputEscrowDeadlinePassed   = []byte("escrow deadline passed")
+	OutputEscrowMissing          = []byte("escrow is missing")
+	OutputNotEscrowParty         = []byte("recipient is not the payer or payee")
+	OutputInvalidDecimals        = []byte("invalid decimals")
+	OutputFeedMissing            = []byte("feed is missing")
+	OutputTriggerOrderMissing    = []byte("trigger order is missing")
+	OutputWrongFeed              = []byte("wrong feed")
+	OutputTriggerNotMet          = []byte("trigger condition not met")
+	OutputMinOutZero             = []byte("min out is zero")
+	OutputWrongFeeAccounts       = []byte("wrong treasury or fee recipient")
+	OutputWrongTaker             = []byte("actor is not the taker")
 )
@@ -0,0 +1,93 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*PauseAsset)(nil)
+
+type PauseAsset struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Paused determines whether holders of [Asset] can move it. Set this to
+	// false to resume transfers.
+	Paused bool `json:"paused"`
+}
+
+func (pa *PauseAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixAssetKey(pa.Asset)}
+}
+
+func (pa *PauseAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := pa.MaxUnits(r) // max units == units
+	if pa.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	exists, metadata, supply, owner, isWarp, flags, err := storage.GetAsset(ctx, db, pa.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if isWarp {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if pa.Paused {
+		flags |= storage.AssetPaused
+	} else {
+		flags &^= storage.AssetPaused
+	}
+	if err := storage.SetAsset(ctx, db, pa.Asset, metadata, supply, owner, isWarp, flags); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*PauseAsset) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + 1
+}
+
+func (pa *PauseAsset) Marshal(p *codec.Packer) {
+	p.PackID(pa.Asset)
+	p.PackBool(pa.Paused)
+}
+
+func UnmarshalPauseAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var pause PauseAsset
+	p.UnpackID(true, &pause.Asset) // empty ID is the native asset
+	pause.Paused = p.UnpackBool()
+	return &pause, p.Err()
+}
+
+func (*PauseAsset) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, PauseAssetName)
+}
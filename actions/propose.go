@@ -0,0 +1,125 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Propose)(nil)
+
+// Propose opens a vote on changing [Param] to [Value].
+//
+// The proposal is identified by the [TxID] that created it. The actor locks
+// [GovernanceConfig.Deposit] of the native asset, which can be reclaimed with
+// [ReclaimDeposit] once the proposal is executed or expires.
+type Propose struct {
+	// Param is the chain parameter to change.
+	Param uint8 `json:"param"`
+
+	// Value is the new value of [Param].
+	Value uint64 `json:"value"`
+
+	// In and Out identify the pair changed by a pair param (see [PairParam]).
+	// They are ignored for all other params.
+	In  ids.ID `json:"in"`
+	Out ids.ID `json:"out"`
+}
+
+func (pr *Propose) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	keys := [][]byte{
+		storage.PrefixProposalKey(txID),
+		storage.PrefixBalanceKey(auth.GetActor(rauth), ids.Empty),
+	}
+	if PairParam(pr.Param) {
+		keys = append(keys, storage.PrefixProposalPairKey(txID))
+	}
+	return keys
+}
+
+func (pr *Propose) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := pr.MaxUnits(r) // max units == units
+	config, ok := governanceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputGovernanceDisabled}, nil
+	}
+	if config.Deposit > 0 {
+		if err := spend(ctx, db, rauth, ids.Empty, config.Deposit); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if err := storage.SetProposal(
+		ctx, db, txID, actor, pr.Param,
+		pr.Value, t, 0, 0, config.Deposit, false,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if PairParam(pr.Param) {
+		if err := storage.SetProposalPair(ctx, db, txID, pr.In, pr.Out); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (pr *Propose) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	if PairParam(pr.Param) {
+		return 1 + consts.Uint64Len + consts.IDLen*2
+	}
+	return 1 + consts.Uint64Len
+}
+
+func (pr *Propose) Marshal(p *codec.Packer) {
+	p.PackByte(pr.Param)
+	p.PackUint64(pr.Value)
+	if PairParam(pr.Param) {
+		p.PackID(pr.In)
+		p.PackID(pr.Out)
+	}
+}
+
+func UnmarshalPropose(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var propose Propose
+	propose.Param = p.UnpackByte()
+	propose.Value = p.UnpackUint64(false) // some params may be set to 0
+	if PairParam(propose.Param) {
+		p.UnpackID(false, &propose.In) // empty ID is the native asset
+		p.UnpackID(false, &propose.Out)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidParam(propose.Param, propose.Value) {
+		return nil, ErrInvalidParam
+	}
+	if PairParam(propose.Param) && propose.In == propose.Out {
+		return nil, ErrInvalidParam
+	}
+	return &propose, nil
+}
+
+func (*Propose) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ProposeName)
+}
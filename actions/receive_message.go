@@ -0,0 +1,92 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ReceiveMessage)(nil)
+
+// ReceiveMessage records a message produced by [SendMessage] on another chain.
+//
+// Replay protection is provided by the hypersdk, which records every processed
+// warp message under [storage.IncomingWarpKeyPrefix] when the transaction
+// succeeds and rejects any later transaction carrying the same message.
+type ReceiveMessage struct {
+	// message is parsed from the inner *warp.Message
+	message *WarpMessage
+
+	// warpMessage is the full *warp.Message parsed from [chain.Transaction]
+	warpMessage *warp.Message
+}
+
+func (r *ReceiveMessage) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixMessageKey(r.warpMessage.SourceChainID, r.message.TxID),
+	}
+}
+
+func (r *ReceiveMessage) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	db chain.Database,
+	_ int64,
+	_ chain.Auth,
+	_ ids.ID,
+	warpVerified bool,
+) (*chain.Result, error) {
+	unitsUsed := r.MaxUnits(rules) // max units == units
+	if !warpVerified {
+		return &chain.Result{
+			Success: false,
+			Units:   unitsUsed,
+			Output:  OutputWarpVerificationFailed,
+		}, nil
+	}
+	if err := storage.SetMessage(
+		ctx, db, r.warpMessage.SourceChainID, r.message.TxID,
+		r.message.Sender, r.message.Recipient, r.message.Payload,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (r *ReceiveMessage) MaxUnits(chain.Rules) uint64 {
+	return uint64(len(r.warpMessage.Payload))
+}
+
+// Everything needed to execute [ReceiveMessage] is contained in the warp
+// message, so we only encode the type byte from the registry.
+func (*ReceiveMessage) Marshal(*codec.Packer) {}
+
+func UnmarshalReceiveMessage(p *codec.Packer, wm *warp.Message) (chain.Action, error) {
+	var (
+		recv ReceiveMessage
+		err  error
+	)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	recv.warpMessage = wm
+	recv.message, err = UnmarshalWarpMessage(recv.warpMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &recv, nil
+}
+
+func (*ReceiveMessage) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ReceiveMessageName)
+}
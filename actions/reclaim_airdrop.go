@@ -0,0 +1,103 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ReclaimAirdrop)(nil)
+
+// ReclaimAirdrop returns everything that was not claimed from an expired
+// airdrop to the actor that created it.
+type ReclaimAirdrop struct {
+	// Airdrop is the [TxID] that created the airdrop.
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Asset is the asset locked in the airdrop. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (c *ReclaimAirdrop) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		receiveKeys(c.Asset, actor),
+		storage.PrefixAirdropKey(c.Airdrop),
+		storage.PrefixBalanceKey(actor, c.Asset),
+	)
+}
+
+func (c *ReclaimAirdrop) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, asset, creator, _, expiry, remaining, err := storage.GetAirdrop(ctx, db, c.Airdrop)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropMissing}, nil
+	}
+	if creator != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if expiry > t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropActive}, nil
+	}
+	if output := checkReceivable(ctx, db, asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.DeleteAirdrop(ctx, db, c.Airdrop); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if remaining > 0 {
+		if err := storage.AddBalance(ctx, db, actor, asset, remaining); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*ReclaimAirdrop) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (c *ReclaimAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Airdrop)
+	p.PackID(c.Asset)
+}
+
+func UnmarshalReclaimAirdrop(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var reclaim ReclaimAirdrop
+	p.UnpackID(true, &reclaim.Airdrop)
+	p.UnpackID(false, &reclaim.Asset) // empty ID is the native asset
+	return &reclaim, p.Err()
+}
+
+func (*ReclaimAirdrop) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ReclaimAirdropName)
+}
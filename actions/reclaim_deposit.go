@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ReclaimDeposit)(nil)
+
+// ReclaimDeposit returns the native asset locked by [Propose] to the proposer
+// once [Proposal] is executed or expires.
+type ReclaimDeposit struct {
+	// Proposal is the [TxID] that created the proposal.
+	Proposal ids.ID `json:"proposal"`
+}
+
+func (rd *ReclaimDeposit) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixProposalKey(rd.Proposal),
+		storage.PrefixBalanceKey(auth.GetActor(rauth), ids.Empty),
+	}
+}
+
+func (rd *ReclaimDeposit) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := rd.MaxUnits(r) // max units == units
+	config, ok := governanceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputGovernanceDisabled}, nil
+	}
+	exists, proposer, param, value, created, yes, no, deposit, executed, err := storage.GetProposal(ctx, db, rd.Proposal)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalMissing}, nil
+	}
+	if proposer != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNotProposer}, nil
+	}
+	if deposit == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputDepositReclaimed}, nil
+	}
+	if !executed && t < proposalExpiry(config, created) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputDepositLocked}, nil
+	}
+	if err := storage.SetProposal(
+		ctx, db, rd.Proposal, proposer, param,
+		value, created, yes, no, 0, executed,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, ids.Empty, deposit); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*ReclaimDeposit) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen
+}
+
+func (rd *ReclaimDeposit) Marshal(p *codec.Packer) {
+	p.PackID(rd.Proposal)
+}
+
+func UnmarshalReclaimDeposit(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var reclaim ReclaimDeposit
+	p.UnpackID(true, &reclaim.Proposal)
+	return &reclaim, p.Err()
+}
+
+func (*ReclaimDeposit) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ReclaimDepositName)
+}
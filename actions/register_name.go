@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RegisterName)(nil)
+
+// RegisterName gives the actor ownership of [Name] for
+// [NameServiceConfig.Period] seconds in exchange for [NameServiceConfig.Fee].
+// A name can be registered if it has never been registered or if its last
+// registration expired.
+//
+// If [Address] is the actor (or [Asset] is owned by the actor), [Name] also
+// becomes its reverse record.
+type RegisterName struct {
+	// Name is the name to register (see [ValidName]).
+	Name string `json:"name"`
+
+	// Address is the account [Name] resolves to, if any.
+	Address crypto.PublicKey `json:"address"`
+
+	// Asset is the asset [Name] resolves to, if any.
+	Asset ids.ID `json:"asset"`
+}
+
+func (n *RegisterName) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	keys := append(nameFeeKeys(actor), storage.PrefixNameKey(n.Name))
+	return append(keys, reverseNameKeys(actor, n.Address, n.Asset)...)
+}
+
+func (n *RegisterName) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := n.MaxUnits(r) // max units == units
+	config, ok := nameServiceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameServiceDisabled}, nil
+	}
+	exists, _, _, _, expiry, err := storage.GetName(ctx, db, n.Name)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if exists && expiry > t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameTaken}, nil
+	}
+	if err := payNameFee(ctx, r, db, rauth, config.Fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetName(ctx, db, n.Name, actor, n.Address, n.Asset, t+config.Period); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := setReverseNames(ctx, db, actor, n.Name, n.Address, n.Asset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (n *RegisterName) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return nameSize(n.Name) + crypto.PublicKeyLen + consts.IDLen
+}
+
+func (n *RegisterName) Marshal(p *codec.Packer) {
+	p.PackString(n.Name)
+	p.PackPublicKey(n.Address)
+	p.PackID(n.Asset)
+}
+
+func UnmarshalRegisterName(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var register RegisterName
+	register.Name = p.UnpackString(true)
+	p.UnpackPublicKey(false, &register.Address) // name may not resolve to an address
+	p.UnpackID(false, &register.Asset)          // name may not resolve to an asset
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidName(register.Name) {
+		return nil, ErrInvalidName
+	}
+	return &register, nil
+}
+
+func (*RegisterName) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, RegisterNameName)
+}
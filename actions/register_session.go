@@ -0,0 +1,133 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RegisterSession)(nil)
+
+// SessionLimit is the most of [Asset] a session key can spend.
+type SessionLimit struct {
+	Asset  ids.ID `json:"asset"`
+	Amount uint64 `json:"amount"`
+}
+
+// RegisterSession allows [Key] to sign transactions as the actor with
+// [auth.Session] until [Expiry].
+//
+// [Key] may only perform the actions in [Actions] and may only spend (including
+// fees) up to the amount of each asset in [Limits]. Registering a key that is
+// already registered replaces its expiry, actions and remaining limits.
+type RegisterSession struct {
+	// Key is the public key of the session key.
+	Key crypto.PublicKey `json:"key"`
+
+	// Expiry is the unix timestamp (in seconds) after which [Key] can no
+	// longer be used.
+	Expiry int64 `json:"expiry"`
+
+	// Actions are the type IDs (in the action registry) of the actions [Key]
+	// may perform.
+	Actions []uint8 `json:"actions"`
+
+	// Limits are the assets [Key] may spend. Any asset not included can't be
+	// spent by [Key].
+	Limits []*SessionLimit `json:"limits"`
+}
+
+func (s *RegisterSession) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{storage.PrefixSessionKey(actor, s.Key)}
+}
+
+func (s *RegisterSession) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if _, ok := rauth.(*auth.Session); ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionNotAllowed}, nil
+	}
+	if s.Expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionExpired}, nil
+	}
+	assets := make([]ids.ID, len(s.Limits))
+	remaining := make([]uint64, len(s.Limits))
+	for i, limit := range s.Limits {
+		assets[i] = limit.Asset
+		remaining[i] = limit.Amount
+	}
+	if err := storage.SetSession(ctx, db, actor, s.Key, s.Expiry, s.Actions, assets, remaining); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (s *RegisterSession) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.Uint64Len + consts.IntLen*2 +
+		uint64(len(s.Actions)) + uint64(len(s.Limits))*(consts.IDLen+consts.Uint64Len)
+}
+
+func (s *RegisterSession) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Key)
+	p.PackInt64(s.Expiry)
+	p.PackBytes(s.Actions)
+	p.PackInt(len(s.Limits))
+	for _, limit := range s.Limits {
+		p.PackID(limit.Asset)
+		p.PackUint64(limit.Amount)
+	}
+}
+
+func UnmarshalRegisterSession(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var register RegisterSession
+	p.UnpackPublicKey(true, &register.Key)
+	register.Expiry = p.UnpackInt64(true)
+	p.UnpackBytes(MaxSessionActions, true, &register.Actions)
+	count := p.UnpackInt(false) // a session key may not spend anything
+	if count > MaxSessionLimits {
+		return nil, ErrTooManySessionLimits
+	}
+	register.Limits = make([]*SessionLimit, count)
+	assets := make([]ids.ID, count)
+	for i := range register.Limits {
+		var limit SessionLimit
+		p.UnpackID(false, &limit.Asset) // empty ID is the native asset
+		limit.Amount = p.UnpackUint64(true)
+		register.Limits[i] = &limit
+		assets[i] = limit.Asset
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !uniqueIDs(assets) {
+		return nil, ErrDuplicateSessionLimit
+	}
+	return &register, nil
+}
+
+func (*RegisterSession) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, RegisterSessionName)
+}
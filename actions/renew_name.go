@@ -0,0 +1,93 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RenewName)(nil)
+
+// RenewName extends the registration of [Name] by [NameServiceConfig.Period]
+// seconds in exchange for [NameServiceConfig.Fee].
+//
+// Anyone can renew a name, but a name that has already expired must be
+// registered again with [RegisterName].
+type RenewName struct {
+	// Name is the name to renew.
+	Name string `json:"name"`
+}
+
+func (n *RenewName) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(nameFeeKeys(actor), storage.PrefixNameKey(n.Name))
+}
+
+func (n *RenewName) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := n.MaxUnits(r) // max units == units
+	config, ok := nameServiceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameServiceDisabled}, nil
+	}
+	exists, owner, address, asset, expiry, err := storage.GetName(ctx, db, n.Name)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameMissing}, nil
+	}
+	if expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameExpired}, nil
+	}
+	if err := payNameFee(ctx, r, db, rauth, config.Fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetName(ctx, db, n.Name, owner, address, asset, expiry+config.Period); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (n *RenewName) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return nameSize(n.Name)
+}
+
+func (n *RenewName) Marshal(p *codec.Packer) {
+	p.PackString(n.Name)
+}
+
+func UnmarshalRenewName(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var renew RenewName
+	renew.Name = p.UnpackString(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidName(renew.Name) {
+		return nil, ErrInvalidName
+	}
+	return &renew, nil
+}
+
+func (*RenewName) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, RenewNameName)
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ResolveEscrow)(nil)
+
+// ResolveEscrow sends everything locked in an escrow created by
+// [CreateEscrow] to [Recipient], which must be either the payer or the payee.
+//
+// The actor must be:
+// * the payer (if [Recipient] is the payee)
+// * the payee (if [Recipient] is the payer)
+// * the arbiter (before the deadline)
+// * anyone (after the deadline, if [Recipient] gets the default outcome)
+type ResolveEscrow struct {
+	// Escrow is the [TxID] that created the escrow.
+	Escrow ids.ID `json:"escrow"`
+
+	// Asset is the asset locked in the escrow. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Recipient is the party that receives the funds.
+	Recipient crypto.PublicKey `json:"recipient"`
+}
+
+func (e *ResolveEscrow) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return append(
+		receiveKeys(e.Asset, e.Recipient),
+		storage.PrefixEscrowKey(e.Escrow),
+		storage.PrefixBalanceKey(e.Recipient, e.Asset),
+	)
+}
+
+func (e *ResolveEscrow) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := e.MaxUnits(r) // max units == units
+	exists, asset, payer, payee, arbiter, amount, deadline, releaseOnTimeout, err := storage.GetEscrow(ctx, db, e.Escrow)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputEscrowMissing}, nil
+	}
+	if asset != e.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if e.Recipient != payer && e.Recipient != payee {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNotEscrowParty}, nil
+	}
+	release := e.Recipient == payee
+	var authorized bool
+	switch {
+	case actor == payer && release:
+		authorized = true
+	case actor == payee && !release:
+		authorized = true
+	case actor == arbiter && t < deadline:
+		authorized = true
+	case t >= deadline && release == releaseOnTimeout:
+		authorized = true
+	}
+	if !authorized {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if output := checkReceivable(ctx, db, asset, e.Recipient); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.DeleteEscrow(ctx, db, e.Escrow); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, e.Recipient, asset, amount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*ResolveEscrow) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + crypto.PublicKeyLen
+}
+
+func (e *ResolveEscrow) Marshal(p *codec.Packer) {
+	p.PackID(e.Escrow)
+	p.PackID(e.Asset)
+	p.PackPublicKey(e.Recipient)
+}
+
+func UnmarshalResolveEscrow(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var resolve ResolveEscrow
+	p.UnpackID(true, &resolve.Escrow)
+	p.UnpackID(false, &resolve.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(true, &resolve.Recipient)
+	return &resolve, p.Err()
+}
+
+func (*ResolveEscrow) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, ResolveEscrowName)
+}
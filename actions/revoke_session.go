@@ -0,0 +1,79 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RevokeSession)(nil)
+
+// RevokeSession removes a session key registered by the actor with
+// [RegisterSession] before it expires.
+type RevokeSession struct {
+	// Key is the public key of the session key.
+	Key crypto.PublicKey `json:"key"`
+}
+
+func (s *RevokeSession) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{storage.PrefixSessionKey(actor, s.Key)}
+}
+
+func (s *RevokeSession) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if _, ok := rauth.(*auth.Session); ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionNotAllowed}, nil
+	}
+	exists, _, _, _, _, err := storage.GetSession(ctx, db, actor, s.Key)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionMissing}, nil
+	}
+	if err := storage.DeleteSession(ctx, db, actor, s.Key); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*RevokeSession) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen
+}
+
+func (s *RevokeSession) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Key)
+}
+
+func UnmarshalRevokeSession(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var revoke RevokeSession
+	p.UnpackPublicKey(true, &revoke.Key)
+	return &revoke, p.Err()
+}
+
+func (*RevokeSession) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, RevokeSessionName)
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"tokenvm/auth"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SendMessage)(nil)
+
+type SendMessage struct {
+	// Recipient is the account the message is addressed to on [Destination].
+	Recipient crypto.PublicKey `json:"recipient"`
+
+	// Payload is arbitrary application data delivered to [Recipient].
+	Payload []byte `json:"payload"`
+
+	// Destination is the chain that should receive the message.
+	Destination ids.ID `json:"destination"`
+}
+
+func (*SendMessage) StateKeys(chain.Auth, ids.ID) [][]byte {
+	// The only state modified is the outgoing warp message, which is managed by
+	// the hypersdk.
+	return [][]byte{}
+}
+
+func (s *SendMessage) Execute(
+	_ context.Context,
+	r chain.Rules,
+	_ chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if len(s.Payload) == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputPayloadEmpty}, nil
+	}
+	if len(s.Payload) > MaxMessageSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputPayloadTooLarge}, nil
+	}
+	if s.Destination == ids.Empty {
+		// This would allow the message to be received by any chain.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAnycast}, nil
+	}
+	wmsg := &WarpMessage{
+		Sender:    actor,
+		Recipient: s.Recipient,
+		Payload:   s.Payload,
+		TxID:      txID,
+	}
+	payload, err := wmsg.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	wm := &warp.UnsignedMessage{
+		DestinationChainID: s.Destination,
+		// SourceChainID is populated by hypersdk
+		Payload: payload,
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, WarpMessage: wm}, nil
+}
+
+func (s *SendMessage) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + uint64(len(s.Payload)) + consts.IDLen
+}
+
+func (s *SendMessage) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Recipient)
+	p.PackBytes(s.Payload)
+	p.PackID(s.Destination)
+}
+
+func UnmarshalSendMessage(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var send SendMessage
+	p.UnpackPublicKey(false, &send.Recipient) // can message blackhole
+	p.UnpackBytes(MaxMessageSize, true, &send.Payload)
+	p.UnpackID(true, &send.Destination)
+	return &send, p.Err()
+}
+
+func (*SendMessage) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SendMessageName)
+}
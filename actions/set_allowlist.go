@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetAllowlist)(nil)
+
+// SetAllowlist approves (or revokes approval of) [Account] to receive [Asset]
+// while it is in allowlist mode.
+type SetAllowlist struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Account is the address being approved or revoked.
+	Account crypto.PublicKey `json:"account"`
+
+	// Allowed determines whether [Account] can receive [Asset].
+	Allowed bool `json:"allowed"`
+}
+
+func (s *SetAllowlist) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(s.Asset),
+		storage.PrefixAllowlistKey(s.Asset, s.Account),
+	}
+}
+
+func (s *SetAllowlist) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if s.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	exists, _, _, owner, isWarp, _, err := storage.GetAsset(ctx, db, s.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if isWarp {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetAllowlisted(ctx, db, s.Asset, s.Account, s.Allowed); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*SetAllowlist) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + 1
+}
+
+func (s *SetAllowlist) Marshal(p *codec.Packer) {
+	p.PackID(s.Asset)
+	p.PackPublicKey(s.Account)
+	p.PackBool(s.Allowed)
+}
+
+func UnmarshalSetAllowlist(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var allowlist SetAllowlist
+	p.UnpackID(true, &allowlist.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(false, &allowlist.Account)
+	allowlist.Allowed = p.UnpackBool()
+	return &allowlist, p.Err()
+}
+
+func (*SetAllowlist) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SetAllowlistName)
+}
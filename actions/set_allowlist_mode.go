@@ -0,0 +1,93 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetAllowlistMode)(nil)
+
+type SetAllowlistMode struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Enabled determines whether only accounts approved with [SetAllowlist]
+	// can receive [Asset].
+	Enabled bool `json:"enabled"`
+}
+
+func (s *SetAllowlistMode) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixAssetKey(s.Asset)}
+}
+
+func (s *SetAllowlistMode) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if s.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	exists, metadata, supply, owner, isWarp, flags, err := storage.GetAsset(ctx, db, s.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if isWarp {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if s.Enabled {
+		flags |= storage.AssetAllowlist
+	} else {
+		flags &^= storage.AssetAllowlist
+	}
+	if err := storage.SetAsset(ctx, db, s.Asset, metadata, supply, owner, isWarp, flags); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*SetAllowlistMode) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + 1
+}
+
+func (s *SetAllowlistMode) Marshal(p *codec.Packer) {
+	p.PackID(s.Asset)
+	p.PackBool(s.Enabled)
+}
+
+func UnmarshalSetAllowlistMode(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var mode SetAllowlistMode
+	p.UnpackID(true, &mode.Asset) // empty ID is the native asset
+	mode.Enabled = p.UnpackBool()
+	return &mode, p.Err()
+}
+
+func (*SetAllowlistMode) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SetAllowlistModeName)
+}
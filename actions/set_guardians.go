@@ -0,0 +1,119 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetGuardians)(nil)
+
+// SetGuardians allows any [Threshold] of [Guardians] to rotate control of the
+// actor's account to a new key with [InitiateRecovery]. The rotation only
+// takes effect [Delay] seconds later, giving the actor time to cancel it with
+// [CancelRecovery].
+type SetGuardians struct {
+	// Guardians are the accounts that can approve a recovery. If empty, the
+	// account can no longer be recovered.
+	Guardians []crypto.PublicKey `json:"guardians"`
+
+	// Threshold is the number of [Guardians] that must approve a recovery.
+	Threshold uint8 `json:"threshold"`
+
+	// Delay is the number of seconds after a recovery is initiated before it
+	// can be completed.
+	Delay int64 `json:"delay"`
+}
+
+func (s *SetGuardians) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{storage.PrefixGuardiansKey(actor)}
+}
+
+func (s *SetGuardians) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if _, ok := rauth.(*auth.Session); ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionNotAllowed}, nil
+	}
+	_, _, nonce, _, err := storage.GetGuardians(ctx, db, actor)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	// We increment the nonce so that approvals signed for the old guardians
+	// can't be used
+	if err := storage.SetGuardians(ctx, db, actor, s.Threshold, s.Delay, nonce+1, s.Guardians); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (s *SetGuardians) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IntLen + uint64(len(s.Guardians))*crypto.PublicKeyLen + 1 + consts.Uint64Len
+}
+
+func (s *SetGuardians) Marshal(p *codec.Packer) {
+	p.PackInt(len(s.Guardians))
+	for _, guardian := range s.Guardians {
+		p.PackPublicKey(guardian)
+	}
+	p.PackByte(s.Threshold)
+	p.PackInt64(s.Delay)
+}
+
+func UnmarshalSetGuardians(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var guardians SetGuardians
+	count := p.UnpackInt(false) // no guardians disables recovery
+	if count > MaxGuardians {
+		return nil, ErrTooManyGuardians
+	}
+	guardians.Guardians = make([]crypto.PublicKey, count)
+	seen := set.NewSet[crypto.PublicKey](count)
+	for i := range guardians.Guardians {
+		p.UnpackPublicKey(true, &guardians.Guardians[i])
+		if seen.Contains(guardians.Guardians[i]) {
+			return nil, ErrDuplicateGuardian
+		}
+		seen.Add(guardians.Guardians[i])
+	}
+	guardians.Threshold = p.UnpackByte()
+	guardians.Delay = p.UnpackInt64(false) // recovery may be immediate
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if (count == 0 && guardians.Threshold != 0) ||
+		(count > 0 && (guardians.Threshold == 0 || int(guardians.Threshold) > count)) {
+		return nil, ErrInvalidThreshold
+	}
+	if guardians.Delay < 0 {
+		return nil, ErrInvalidDelay
+	}
+	return &guardians, nil
+}
+
+func (*SetGuardians) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SetGuardiansName)
+}
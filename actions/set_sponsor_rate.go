@@ -0,0 +1,85 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetSponsorRate)(nil)
+
+// SetSponsorRate publishes the rate at which the actor will pay the fees of
+// any [auth.Sponsored] transaction that repays it in [Asset].
+type SetSponsorRate struct {
+	// Asset is the asset the actor accepts as repayment.
+	Asset ids.ID `json:"asset"`
+
+	// Rate is the amount of [Asset] charged for each whole native token paid
+	// in fees. A rate of 0 stops the actor from sponsoring fees in [Asset].
+	Rate uint64 `json:"rate"`
+}
+
+func (s *SetSponsorRate) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixAssetKey(s.Asset),
+		storage.PrefixSponsorRateKey(actor, s.Asset),
+	}
+}
+
+func (s *SetSponsorRate) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	exists, _, _, _, _, _, err := storage.GetAsset(ctx, db, s.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if err := storage.SetSponsorRate(ctx, db, actor, s.Asset, s.Rate); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*SetSponsorRate) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len
+}
+
+func (s *SetSponsorRate) Marshal(p *codec.Packer) {
+	p.PackID(s.Asset)
+	p.PackUint64(s.Rate)
+}
+
+func UnmarshalSetSponsorRate(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var set SetSponsorRate
+	p.UnpackID(true, &set.Asset)     // fees are already paid in the native asset
+	set.Rate = p.UnpackUint64(false) // 0 stops sponsoring
+	return &set, p.Err()
+}
+
+func (*SetSponsorRate) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SetSponsorRateName)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetWarpDestinations)(nil)
+
+type SetWarpDestinations struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Destinations are the only chains [Asset] may be exported to.
+	//
+	// If this is empty, [Asset] may be exported to any chain.
+	Destinations []ids.ID `json:"destinations"`
+}
+
+func (s *SetWarpDestinations) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(s.Asset),
+		storage.PrefixWarpDestinationsKey(s.Asset),
+	}
+}
+
+func (s *SetWarpDestinations) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if s.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	exists, _, _, owner, isWarp, _, err := storage.GetAsset(ctx, db, s.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if isWarp {
+		// Warp assets can only be returned to the chain they came from
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetWarpDestinations(ctx, db, s.Asset, s.Destinations); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (s *SetWarpDestinations) MaxUnits(chain.Rules) uint64 {
+	return consts.IDLen + consts.IntLen + uint64(len(s.Destinations))*consts.IDLen
+}
+
+func (s *SetWarpDestinations) Marshal(p *codec.Packer) {
+	p.PackID(s.Asset)
+	p.PackInt(len(s.Destinations))
+	for _, destination := range s.Destinations {
+		p.PackID(destination)
+	}
+}
+
+func UnmarshalSetWarpDestinations(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var allowlist SetWarpDestinations
+	p.UnpackID(false, &allowlist.Asset) // empty ID is the native asset
+	count := p.UnpackInt(false)         // no destinations removes the allowlist
+	if count > MaxWarpDestinations {
+		return nil, ErrTooManyDestinations
+	}
+	allowlist.Destinations = make([]ids.ID, count)
+	for i := range allowlist.Destinations {
+		p.UnpackID(true, &allowlist.Destinations[i]) // cannot allow anycast
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !uniqueIDs(allowlist.Destinations) {
+		return nil, ErrDuplicateDestination
+	}
+	return &allowlist, nil
+}
+
+func uniqueIDs(s []ids.ID) bool {
+	seen := set.NewSet[ids.ID](len(s))
+	for _, id := range s {
+		if seen.Contains(id) {
+			return false
+		}
+		seen.Add(id)
+	}
+	return true
+}
+
+func (*SetWarpDestinations) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SetWarpDestinationsName)
+}
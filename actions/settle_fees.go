@@ -0,0 +1,145 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SettleFees)(nil)
+
+// SettleFees empties the fee pool of [Asset]. Fees are split when they are
+// charged, so settling only burns what was set aside to be burned and sends
+// the rest to [Treasury] and [FeeRecipient]. Anyone can settle the fee pool.
+//
+// When settling the native asset, the fee pool shard of the transaction payer
+// is skipped because it holds the fee of the settling transaction (which may
+// still be partially refunded).
+type SettleFees struct {
+	// Asset is the asset whose fees are settled. Transaction fees are only
+	// collected in the native asset.
+	Asset ids.ID `json:"asset"`
+
+	// Treasury and [FeeRecipient] must match the [TreasuryConfig] in effect.
+	// We need to provide them to populate [StateKeys].
+	Treasury     crypto.PublicKey `json:"treasury"`
+	FeeRecipient crypto.PublicKey `json:"feeRecipient"`
+}
+
+func (s *SettleFees) StateKeys(chain.Auth, ids.ID) [][]byte {
+	keys := feePoolKeys(s.Asset)
+	keys = append(keys,
+		storage.PrefixBalanceKey(s.Treasury, s.Asset),
+		storage.PrefixBalanceKey(s.FeeRecipient, s.Asset),
+	)
+	keys = append(keys, receiveKeys(s.Asset, s.Treasury)...)
+	keys = append(keys, receiveKeys(s.Asset, s.FeeRecipient)...)
+	return keys
+}
+
+func (s *SettleFees) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := s.MaxUnits(r) // max units == units
+	c := treasuryConfig(r)
+	if s.Treasury != c.Treasury || s.FeeRecipient != c.FeeRecipient {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongFeeAccounts}, nil
+	}
+	skip := -1
+	if s.Asset == ids.Empty {
+		skip = int(storage.FeePoolShard(auth.GetSigner(rauth)))
+	}
+	var burn, treasury, recipient uint64
+	for i := uint8(0); i < storage.FeePoolShards; i++ {
+		if int(i) == skip {
+			continue
+		}
+		b, t, rc, err := storage.GetFeePool(ctx, db, s.Asset, i)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		// Shares without a destination stay in the pool until there is one
+		var pooledTreasury, pooledRecipient uint64
+		if c.Treasury == crypto.EmptyPublicKey {
+			pooledTreasury, t = t, 0
+		}
+		if c.FeeRecipient == crypto.EmptyPublicKey {
+			pooledRecipient, rc = rc, 0
+		}
+		if burn, err = smath.Add64(burn, b); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if treasury, err = smath.Add64(treasury, t); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if recipient, err = smath.Add64(recipient, rc); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if err := storage.SetFeePool(ctx, db, s.Asset, i, 0, pooledTreasury, pooledRecipient); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if treasury > 0 {
+		if output := checkReceivable(ctx, db, s.Asset, c.Treasury); len(output) > 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+		if err := storage.AddBalance(ctx, db, c.Treasury, s.Asset, treasury); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if recipient > 0 {
+		if output := checkReceivable(ctx, db, s.Asset, c.FeeRecipient); len(output) > 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+		if err := storage.AddBalance(ctx, db, c.FeeRecipient, s.Asset, recipient); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if err := updateSupply(ctx, db, s.Asset, burn, false); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*SettleFees) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen*2 + storage.FeePoolShards*consts.Uint64Len
+}
+
+func (s *SettleFees) Marshal(p *codec.Packer) {
+	p.PackID(s.Asset)
+	p.PackPublicKey(s.Treasury)
+	p.PackPublicKey(s.FeeRecipient)
+}
+
+func UnmarshalSettleFees(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var settle SettleFees
+	p.UnpackID(false, &settle.Asset)               // empty ID is the native asset
+	p.UnpackPublicKey(false, &settle.Treasury)     // empty if fees are burned
+	p.UnpackPublicKey(false, &settle.FeeRecipient) // empty if there are no trading fees
+	return &settle, p.Err()
+}
+
+func (*SettleFees) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SettleFeesName)
+}
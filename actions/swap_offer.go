@@ -0,0 +1,208 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SwapOffer)(nil)
+
+// swapOfferPrefix is prepended to the digest signed by [Maker] so that a
+// swap offer signature can never be interpreted as a transaction signature.
+var swapOfferPrefix = []byte("tokenvm/swap")
+
+// SwapOffer settles an offer signed off-chain by [Maker] to give
+// [GiveAmount] of [Give] for [WantAmount] of [Want]. The actor pays
+// [WantAmount] of [Want] to [Maker] and receives [GiveAmount] of [Give] in the
+// same action, so neither party can be left with only half of the trade.
+//
+// Each [Nonce] can only be settled once per [Maker], which also allows
+// [Maker] to issue many offers at the same time.
+type SwapOffer struct {
+	// Maker is the account that signed the offer.
+	Maker crypto.PublicKey `json:"maker"`
+
+	// Give is the asset sent by [Maker].
+	Give       ids.ID `json:"give"`
+	GiveAmount uint64 `json:"giveAmount"`
+
+	// Want is the asset sent by the actor.
+	Want       ids.ID `json:"want"`
+	WantAmount uint64 `json:"wantAmount"`
+
+	// Expiry is the unix timestamp (in seconds) after which the offer can no
+	// longer be settled.
+	Expiry int64 `json:"expiry"`
+
+	// Nonce is chosen by [Maker] to prevent the offer from being replayed.
+	Nonce uint64 `json:"nonce"`
+
+	// Taker is the only account that can settle the offer. If it is
+	// [crypto.EmptyPublicKey], anyone can settle it.
+	Taker crypto.PublicKey `json:"taker"`
+
+	// Signature is the signature over [Digest] by the key that controls
+	// [Maker] (which differs from [Maker] once it has been recovered).
+	Signature crypto.Signature `json:"signature"`
+}
+
+// Digest returns the message that [Maker] must sign to authorize the offer on
+// [chainID].
+func (s *SwapOffer) Digest(chainID ids.ID) []byte {
+	p := codec.NewWriter(len(swapOfferPrefix) + crypto.PublicKeyLen*2 + consts.IDLen*3 + consts.Uint64Len*4)
+	p.PackFixedBytes(swapOfferPrefix)
+	p.PackID(chainID)
+	p.PackPublicKey(s.Maker)
+	p.PackID(s.Give)
+	p.PackUint64(s.GiveAmount)
+	p.PackID(s.Want)
+	p.PackUint64(s.WantAmount)
+	p.PackInt64(s.Expiry)
+	p.PackUint64(s.Nonce)
+	p.PackPublicKey(s.Taker)
+	return p.Bytes()
+}
+
+// Sign sets [Maker] and [Signature] using [priv] for an offer on [chainID].
+func (s *SwapOffer) Sign(chainID ids.ID, priv crypto.PrivateKey) {
+	s.SignFor(chainID, priv.PublicKey(), priv)
+}
+
+// SignFor sets [Maker] to [maker] and [Signature] using [priv], the key that
+// controls [maker], for an offer on [chainID].
+func (s *SwapOffer) SignFor(chainID ids.ID, maker crypto.PublicKey, priv crypto.PrivateKey) {
+	s.Maker = maker
+	s.Signature = crypto.Sign(s.Digest(chainID), priv)
+}
+
+func (s *SwapOffer) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	keys := [][]byte{
+		storage.PrefixAccountKey(s.Maker),
+		storage.PrefixSwapNonceKey(s.Maker, s.Nonce),
+		storage.PrefixBalanceKey(s.Maker, s.Give),
+		storage.PrefixBalanceKey(s.Maker, s.Want),
+		storage.PrefixBalanceKey(actor, s.Give),
+		storage.PrefixBalanceKey(actor, s.Want),
+	}
+	keys = append(keys, controlKeys(s.Give, s.Maker)...)
+	keys = append(keys, controlKeys(s.Want, actor)...)
+	keys = append(keys, receiveKeys(s.Give, actor)...)
+	return append(keys, receiveKeys(s.Want, s.Maker)...)
+}
+
+func (s *SwapOffer) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if s.Give == s.Want {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSameInOut}, nil
+	}
+	if actor == s.Maker {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSelfSwap}, nil
+	}
+	if s.Taker != crypto.EmptyPublicKey && actor != s.Taker {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongTaker}, nil
+	}
+	if t > s.Expiry {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputOfferExpired}, nil
+	}
+	key, err := storage.GetAccountKey(ctx, db, s.Maker)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !crypto.Verify(s.Digest(getChainID(r)), key, s.Signature) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidSignature}, nil
+	}
+	used, err := storage.GetSwapNonce(ctx, db, s.Maker, s.Nonce)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if used {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNonceUsed}, nil
+	}
+	if output := checkTransferable(ctx, db, s.Give, s.Maker); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkTransferable(ctx, db, s.Want, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkReceivable(ctx, db, s.Give, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkReceivable(ctx, db, s.Want, s.Maker); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, s.Maker, s.Give, s.GiveAmount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := spend(ctx, db, rauth, s.Want, s.WantAmount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, s.Give, s.GiveAmount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, s.Maker, s.Want, s.WantAmount); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetSwapNonce(ctx, db, s.Maker, s.Nonce); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*SwapOffer) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen*2 + consts.IDLen*2 + consts.Uint64Len*4 + crypto.SignatureLen
+}
+
+func (s *SwapOffer) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Maker)
+	p.PackID(s.Give)
+	p.PackUint64(s.GiveAmount)
+	p.PackID(s.Want)
+	p.PackUint64(s.WantAmount)
+	p.PackInt64(s.Expiry)
+	p.PackUint64(s.Nonce)
+	p.PackPublicKey(s.Taker)
+	p.PackSignature(s.Signature)
+}
+
+func UnmarshalSwapOffer(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var swap SwapOffer
+	p.UnpackPublicKey(true, &swap.Maker)
+	p.UnpackID(false, &swap.Give) // empty ID is the native asset
+	swap.GiveAmount = p.UnpackUint64(true)
+	p.UnpackID(false, &swap.Want) // empty ID is the native asset
+	swap.WantAmount = p.UnpackUint64(true)
+	swap.Expiry = p.UnpackInt64(true)
+	swap.Nonce = p.UnpackUint64(false)
+	p.UnpackPublicKey(false, &swap.Taker) // empty if anyone can settle
+	p.UnpackSignature(&swap.Signature)
+	return &swap, p.Err()
+}
+
+func (*SwapOffer) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, SwapOfferName)
+}
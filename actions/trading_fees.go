@@ -0,0 +1,55 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+const tradingFeesPrefix = "fees/"
+
+// TradingFees are charged (in basis points) when an order is filled.
+type TradingFees struct {
+	// MakerFee is charged on the [In] received by the order owner.
+	MakerFee uint64
+
+	// TakerFee is charged on the [Out] received by the filler.
+	TakerFee uint64
+
+	// MakerRebate is paid to the order owner in [Out] from the [TakerFee].
+	MakerRebate uint64
+
+	// Pair is true if the fees were set for the pair instead of the chain.
+	Pair bool
+}
+
+// TradingFeesKey is the key used to look up the [TradingFees] of the [in]-[out]
+// pair with [chain.Rules.FetchCustom].
+func TradingFeesKey(in ids.ID, out ids.ID) string {
+	return tradingFeesPrefix + PairID(in, out)
+}
+
+// TradingFeesPair returns the pair referenced by [key], if [key] was created
+// with [TradingFeesKey].
+func TradingFeesPair(key string) (string, bool) {
+	if !strings.HasPrefix(key, tradingFeesPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, tradingFeesPrefix), true
+}
+
+func tradingFees(r chain.Rules, in ids.ID, out ids.ID) *TradingFees {
+	v, ok := r.FetchCustom(TradingFeesKey(in, out))
+	if !ok {
+		return &TradingFees{}
+	}
+	fees, ok := v.(*TradingFees)
+	if !ok {
+		return &TradingFees{}
+	}
+	return fees
+}
@@ -32,10 +32,13 @@ type Transfer struct {
 }
 
 func (t *Transfer) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
-	return [][]byte{
-		storage.PrefixBalanceKey(auth.GetActor(rauth), t.Asset),
+	actor := auth.GetActor(rauth)
+	keys := append(
+		controlKeys(t.Asset, actor),
+		storage.PrefixBalanceKey(actor, t.Asset),
 		storage.PrefixBalanceKey(t.To, t.Asset),
-	}
+	)
+	return append(keys, receiveKeys(t.Asset, t.To)...)
 }
 
 func (t *Transfer) Execute(
@@ -52,7 +55,13 @@ func (t *Transfer) Execute(
 	if t.Value == 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
 	}
-	if err := storage.SubBalance(ctx, db, actor, t.Asset, t.Value); err != nil {
+	if output := checkTransferable(ctx, db, t.Asset, actor); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkReceivable(ctx, db, t.Asset, t.To); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := spend(ctx, db, rauth, t.Asset, t.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.AddBalance(ctx, db, t.To, t.Asset, t.Value); err != nil {
@@ -81,7 +90,6 @@ func UnmarshalTransfer(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
 	return &transfer, p.Err()
 }
 
-func (*Transfer) ValidRange(chain.Rules) (int64, int64) {
-	// Returning -1, -1 means that the action is always valid.
-	return -1, -1
+func (*Transfer) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, TransferName)
 }
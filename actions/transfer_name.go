@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*TransferNameOwnership)(nil)
+
+// TransferNameOwnership gives ownership of [Name] to [To] without changing
+// what it resolves to or when it expires. Only the owner of [Name] can
+// transfer it.
+//
+// It can't be called TransferName because that is already the name of
+// [Transfer] in the upgrade schedule.
+type TransferNameOwnership struct {
+	// Name is the name to transfer.
+	Name string `json:"name"`
+
+	// To is the new owner of [Name].
+	To crypto.PublicKey `json:"to"`
+}
+
+func (n *TransferNameOwnership) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixNameKey(n.Name)}
+}
+
+func (n *TransferNameOwnership) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := n.MaxUnits(r) // max units == units
+	exists, owner, address, asset, expiry, err := storage.GetName(ctx, db, n.Name)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameMissing}, nil
+	}
+	if expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameExpired}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetName(ctx, db, n.Name, n.To, address, asset, expiry); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (n *TransferNameOwnership) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return nameSize(n.Name) + crypto.PublicKeyLen
+}
+
+func (n *TransferNameOwnership) Marshal(p *codec.Packer) {
+	p.PackString(n.Name)
+	p.PackPublicKey(n.To)
+}
+
+func UnmarshalTransferNameOwnership(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer TransferNameOwnership
+	transfer.Name = p.UnpackString(true)
+	p.UnpackPublicKey(true, &transfer.To)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidName(transfer.Name) {
+		return nil, ErrInvalidName
+	}
+	return &transfer, nil
+}
+
+func (*TransferNameOwnership) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, TransferNameOwnershipName)
+}
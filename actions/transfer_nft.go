@@ -0,0 +1,112 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*TransferNFT)(nil)
+
+// TransferNFT sends token [ID] of [Collection] from the actor to [To].
+type TransferNFT struct {
+	// Collection is the collection of the NFT.
+	Collection ids.ID `json:"collection"`
+
+	// ID is the ID of the NFT in [Collection].
+	ID uint64 `json:"id"`
+
+	// To is the new owner of the NFT.
+	To crypto.PublicKey `json:"to"`
+}
+
+func (t *TransferNFT) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	nft := NFTID(t.Collection, t.ID)
+	return [][]byte{
+		storage.PrefixNFTKey(nft),
+		storage.PrefixBalanceKey(actor, nft),
+		storage.PrefixBalanceKey(t.To, nft),
+	}
+}
+
+func (t *TransferNFT) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := t.MaxUnits(r) // max units == units
+	nft := NFTID(t.Collection, t.ID)
+	exists, _, _, _, err := storage.GetNFT(ctx, db, nft)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTMissing}, nil
+	}
+	// The owner won't have a balance if the NFT is escrowed in an order
+	if err := spend(ctx, db, rauth, nft, 1); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, t.To, nft, 1); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := setNFTOwner(ctx, db, nft, t.To); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*TransferNFT) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len + crypto.PublicKeyLen
+}
+
+func (t *TransferNFT) Marshal(p *codec.Packer) {
+	p.PackID(t.Collection)
+	p.PackUint64(t.ID)
+	p.PackPublicKey(t.To)
+}
+
+func UnmarshalTransferNFT(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer TransferNFT
+	p.UnpackID(true, &transfer.Collection)
+	transfer.ID = p.UnpackUint64(false) // 0 is a valid ID
+	p.UnpackPublicKey(true, &transfer.To)
+	return &transfer, p.Err()
+}
+
+func (*TransferNFT) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, TransferNFTName)
+}
+
+// setNFTOwner updates the owner record of [asset] if it is an NFT.
+func setNFTOwner(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) error {
+	exists, collection, id, _, err := storage.GetNFT(ctx, db, asset)
+	if err != nil || !exists {
+		return err
+	}
+	return storage.SetNFT(ctx, db, asset, collection, id, owner)
+}
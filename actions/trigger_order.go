@@ -0,0 +1,202 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*TriggerOrder)(nil)
+
+const triggerBasePrice = consts.IDLen*5 + crypto.PublicKeyLen*2
+
+// TriggerOrder uses a trigger order created by [CreateTriggerOrder] whose
+// condition holds to fill [Order] on behalf of [Owner]. Any [In] not used by
+// the fill is refunded to [Owner] and the actor receives the reward of the
+// trigger order.
+//
+// Anyone can trigger an order.
+type TriggerOrder struct {
+	// [Trigger] is the [TxID] that created the trigger order.
+	Trigger ids.ID `json:"trigger"`
+
+	// [Owner] is the owner of the trigger order and the recipient of the
+	// trade proceeds. We need to provide this to populate [StateKeys].
+	Owner crypto.PublicKey `json:"owner"`
+
+	// [In] is the asset locked in the trigger order. We need to provide this
+	// to populate [StateKeys].
+	In ids.ID `json:"in"`
+
+	// [Out] is the asset that will be received from the fill. We need to
+	// provide this to populate [StateKeys].
+	Out ids.ID `json:"out"`
+
+	// [Feed] is the price feed watched by the trigger order. We need to
+	// provide this to populate [StateKeys].
+	Feed ids.ID `json:"feed"`
+
+	// [Order] is the OrderID to fill.
+	Order ids.ID `json:"order"`
+
+	// [OrderOwner] is the owner of [Order].
+	OrderOwner crypto.PublicKey `json:"orderOwner"`
+}
+
+func (t *TriggerOrder) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	keys := fillOrderKeys(t.Order, t.OrderOwner, t.In, t.Out, t.Owner)
+	keys = append(keys, receiveKeys(t.In, actor)...)
+	keys = append(keys, receiveKeys(t.In, t.Owner)...)
+	keys = append(keys,
+		storage.PrefixTriggerOrderKey(t.Trigger),
+		storage.PrefixBalanceKey(actor, t.In),
+	)
+	if t.Feed != ids.Empty {
+		keys = append(keys, storage.PrefixFeedKey(t.Feed))
+	}
+	return keys
+}
+
+func (t *TriggerOrder) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	blockTime int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	exists, in, out, owner, value, reward, minOut, feed, triggerIn, triggerOut, above, err := storage.GetTriggerOrder(ctx, db, t.Trigger)
+	if err != nil {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputTriggerOrderMissing}, nil
+	}
+	if owner != t.Owner {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputWrongOwner}, nil
+	}
+	if in != t.In {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputWrongIn}, nil
+	}
+	if out != t.Out {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputWrongOut}, nil
+	}
+	if feed != t.Feed {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputWrongFeed}, nil
+	}
+
+	// Check the trigger condition
+	var (
+		priceIn  uint64
+		priceOut uint64
+	)
+	if feed == ids.Empty {
+		exists, priceIn, priceOut, err = storage.GetLastTrade(ctx, db, in, out)
+	} else {
+		var timestamp int64
+		exists, _, _, priceIn, timestamp, _, err = storage.GetFeed(ctx, db, feed)
+		// A feed that has never been updated has no price
+		exists = exists && timestamp > 0
+		priceOut = 1
+	}
+	if err != nil {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists || !TriggerMet(priceIn, priceOut, triggerIn, triggerOut, above) {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputTriggerNotMet}, nil
+	}
+
+	// Fill as much of [value] as is aligned with [Order]
+	exists, _, inTick, _, _, _, _, err := storage.GetOrder(ctx, db, t.Order)
+	if err != nil {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputOrderMissing}, nil
+	}
+	fillValue := value - value%inTick
+	if fillValue == 0 {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputInsufficientInput}, nil
+	}
+	if reward > 0 {
+		if output := checkReceivable(ctx, db, in, actor); len(output) > 0 {
+			return &chain.Result{Success: false, Units: triggerBasePrice, Output: output}, nil
+		}
+	}
+	// [In] was already taken from [owner] when the trigger order was created.
+	or, output := fillOrder(ctx, r, db, blockTime, t.Order, t.OrderOwner, in, out, fillValue, owner, func(uint64) error {
+		return nil
+	})
+	if len(output) > 0 {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: output}, nil
+	}
+	if !LimitMet(or.In, or.Out-or.TakerFee, value, minOut) {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: OutputInsufficientOutput}, nil
+	}
+	if err := storage.DeleteTriggerOrder(ctx, db, t.Trigger); err != nil {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	if refund := value - or.In; refund > 0 {
+		if output := checkReceivable(ctx, db, in, owner); len(output) > 0 {
+			return &chain.Result{Success: false, Units: triggerBasePrice, Output: output}, nil
+		}
+		if err := storage.AddBalance(ctx, db, owner, in, refund); err != nil {
+			return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if reward > 0 {
+		if err := storage.AddBalance(ctx, db, actor, in, reward); err != nil {
+			return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	output, err = or.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: triggerBasePrice, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: triggerBasePrice + tradeSucceededPrice, Output: output}, nil
+}
+
+func (*TriggerOrder) MaxUnits(chain.Rules) uint64 {
+	return triggerBasePrice + tradeSucceededPrice
+}
+
+func (t *TriggerOrder) Marshal(p *codec.Packer) {
+	p.PackID(t.Trigger)
+	p.PackPublicKey(t.Owner)
+	p.PackID(t.In)
+	p.PackID(t.Out)
+	p.PackID(t.Feed)
+	p.PackID(t.Order)
+	p.PackPublicKey(t.OrderOwner)
+}
+
+func UnmarshalTriggerOrder(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var trigger TriggerOrder
+	p.UnpackID(true, &trigger.Trigger)
+	p.UnpackPublicKey(true, &trigger.Owner)
+	p.UnpackID(false, &trigger.In)   // empty ID is the native asset
+	p.UnpackID(false, &trigger.Out)  // empty ID is the native asset
+	p.UnpackID(false, &trigger.Feed) // empty ID is the last trade
+	p.UnpackID(true, &trigger.Order)
+	p.UnpackPublicKey(true, &trigger.OrderOwner)
+	return &trigger, p.Err()
+}
+
+func (*TriggerOrder) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, TriggerOrderName)
+}
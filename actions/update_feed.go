@@ -0,0 +1,88 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*UpdateFeed)(nil)
+
+// UpdateFeed posts a new [Price] to a feed created by [CreateFeed]. The price
+// is timestamped with the time of the block that includes it.
+//
+// Only the owner of the feed can update it.
+type UpdateFeed struct {
+	// Feed is the [TxID] that created the feed.
+	Feed ids.ID `json:"feed"`
+
+	// Price is the new price of the feed (with the decimals of the feed).
+	Price uint64 `json:"price"`
+}
+
+func (u *UpdateFeed) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixFeedKey(u.Feed)}
+}
+
+func (u *UpdateFeed) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := u.MaxUnits(r) // max units == units
+	if u.Price == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, owner, decimals, _, _, metadata, err := storage.GetFeed(ctx, db, u.Feed)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputFeedMissing}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetFeed(ctx, db, u.Feed, owner, decimals, u.Price, t, metadata); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*UpdateFeed) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len
+}
+
+func (u *UpdateFeed) Marshal(p *codec.Packer) {
+	p.PackID(u.Feed)
+	p.PackUint64(u.Price)
+}
+
+func UnmarshalUpdateFeed(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var update UpdateFeed
+	p.UnpackID(true, &update.Feed)
+	update.Price = p.UnpackUint64(true)
+	return &update, p.Err()
+}
+
+func (*UpdateFeed) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, UpdateFeedName)
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*UpdateName)(nil)
+
+// UpdateName changes what [Name] resolves to. Only the owner of [Name] can
+// update it.
+type UpdateName struct {
+	// Name is the name to update.
+	Name string `json:"name"`
+
+	// Address is the account [Name] resolves to, if any.
+	Address crypto.PublicKey `json:"address"`
+
+	// Asset is the asset [Name] resolves to, if any.
+	Asset ids.ID `json:"asset"`
+}
+
+func (u *UpdateName) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		[][]byte{storage.PrefixNameKey(u.Name)},
+		reverseNameKeys(actor, u.Address, u.Asset)...,
+	)
+}
+
+func (u *UpdateName) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := u.MaxUnits(r) // max units == units
+	exists, owner, _, _, expiry, err := storage.GetName(ctx, db, u.Name)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameMissing}, nil
+	}
+	if expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameExpired}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetName(ctx, db, u.Name, owner, u.Address, u.Asset, expiry); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := setReverseNames(ctx, db, actor, u.Name, u.Address, u.Asset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (u *UpdateName) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return nameSize(u.Name) + crypto.PublicKeyLen + consts.IDLen
+}
+
+func (u *UpdateName) Marshal(p *codec.Packer) {
+	p.PackString(u.Name)
+	p.PackPublicKey(u.Address)
+	p.PackID(u.Asset)
+}
+
+func UnmarshalUpdateName(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var update UpdateName
+	update.Name = p.UnpackString(true)
+	p.UnpackPublicKey(false, &update.Address) // name may not resolve to an address
+	p.UnpackID(false, &update.Asset)          // name may not resolve to an asset
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidName(update.Name) {
+		return nil, ErrInvalidName
+	}
+	return &update, nil
+}
+
+func (*UpdateName) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, UpdateNameName)
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*Vote)(nil)
+
+// Vote locks [Weight] of the native asset from the actor and adds it to the
+// tally of [Proposal]. Locking the weight prevents the same balance from
+// voting twice. The weight can be withdrawn with [WithdrawVote] once voting
+// closes.
+type Vote struct {
+	// Proposal is the [TxID] that created the proposal.
+	Proposal ids.ID `json:"proposal"`
+
+	// Support is true if the actor is voting in favor of the proposal.
+	Support bool `json:"support"`
+
+	// Weight is the amount of the native asset to vote with.
+	Weight uint64 `json:"weight"`
+}
+
+func (v *Vote) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixProposalKey(v.Proposal),
+		storage.PrefixVoteKey(v.Proposal, actor),
+		storage.PrefixBalanceKey(actor, ids.Empty),
+	}
+}
+
+func (v *Vote) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := v.MaxUnits(r) // max units == units
+	if v.Weight == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	config, ok := governanceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputGovernanceDisabled}, nil
+	}
+	exists, proposer, param, value, created, yes, no, deposit, executed, err := storage.GetProposal(ctx, db, v.Proposal)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalMissing}, nil
+	}
+	if executed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputProposalExecuted}, nil
+	}
+	if t >= created+config.VotingPeriod {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputVotingClosed}, nil
+	}
+	voted, _, _, err := storage.GetVote(ctx, db, v.Proposal, actor)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if voted {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAlreadyVoted}, nil
+	}
+	if v.Support {
+		yes, err = smath.Add64(yes, v.Weight)
+	} else {
+		no, err = smath.Add64(no, v.Weight)
+	}
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := spend(ctx, db, rauth, ids.Empty, v.Weight); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetVote(ctx, db, v.Proposal, actor, v.Weight, v.Support); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetProposal(
+		ctx, db, v.Proposal, proposer, param,
+		value, created, yes, no, deposit, false,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*Vote) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + 1 + consts.Uint64Len
+}
+
+func (v *Vote) Marshal(p *codec.Packer) {
+	p.PackID(v.Proposal)
+	p.PackBool(v.Support)
+	p.PackUint64(v.Weight)
+}
+
+func UnmarshalVote(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var vote Vote
+	p.UnpackID(true, &vote.Proposal)
+	vote.Support = p.UnpackBool()
+	vote.Weight = p.UnpackUint64(true)
+	return &vote, p.Err()
+}
+
+func (*Vote) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, VoteName)
+}
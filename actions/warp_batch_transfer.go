@@ -0,0 +1,86 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+const (
+	batchTransferSize = crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len
+
+	WarpBatchTransferSize = 1 + consts.IntLen +
+		MaxBatchSize*(batchTransferSize+1) + consts.IDLen
+)
+
+// BatchTransfer is a single credit included in a [BatchExportAsset].
+type BatchTransfer struct {
+	To    crypto.PublicKey `json:"to"`
+	Asset ids.ID           `json:"asset"`
+	Value uint64           `json:"value"`
+}
+
+// WarpBatchTransferEntry is a single credit included in a [WarpBatchTransfer].
+type WarpBatchTransferEntry struct {
+	To    crypto.PublicKey `json:"to"`
+	Asset ids.ID           `json:"asset"`
+	Value uint64           `json:"value"`
+
+	// Return is set to true when the entry is sending funds back to the chain
+	// where they were created.
+	Return bool `json:"return"`
+}
+
+type WarpBatchTransfer struct {
+	Transfers []*WarpBatchTransferEntry `json:"transfers"`
+
+	// TxID is the transaction that created this message. This is used to ensure
+	// there is WarpID uniqueness.
+	TxID ids.ID `json:"txID"`
+}
+
+func (w *WarpBatchTransfer) Marshal() ([]byte, error) {
+	p := codec.NewWriter(WarpBatchTransferSize)
+	p.PackByte(warpBatchTransferType)
+	p.PackInt(len(w.Transfers))
+	for _, transfer := range w.Transfers {
+		p.PackPublicKey(transfer.To)
+		p.PackID(transfer.Asset)
+		p.PackUint64(transfer.Value)
+		p.PackBool(transfer.Return)
+	}
+	p.PackID(w.TxID)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalWarpBatchTransfer(b []byte) (*WarpBatchTransfer, error) {
+	var batch WarpBatchTransfer
+	p := codec.NewReader(b, WarpBatchTransferSize)
+	typ := p.UnpackByte()
+	count := p.UnpackInt(true)
+	if count > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	batch.Transfers = make([]*WarpBatchTransferEntry, count)
+	for i := range batch.Transfers {
+		var transfer WarpBatchTransferEntry
+		p.UnpackPublicKey(false, &transfer.To) // can transfer to blackhole
+		p.UnpackID(false, &transfer.Asset)     // may export native
+		transfer.Value = p.UnpackUint64(true)
+		transfer.Return = p.UnpackBool()
+		batch.Transfers[i] = &transfer
+	}
+	p.UnpackID(true, &batch.TxID)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if typ != warpBatchTransferType || !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &batch, nil
+}
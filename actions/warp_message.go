@@ -0,0 +1,58 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+const WarpMessageSize = 1 + crypto.PublicKeyLen*2 +
+	consts.IntLen + MaxMessageSize + consts.IDLen
+
+type WarpMessage struct {
+	// Sender is the actor that issued the [SendMessage] on the source chain.
+	Sender crypto.PublicKey `json:"sender"`
+
+	// Recipient is the account the message is addressed to on the destination
+	// chain. The tokenvm does not interpret it.
+	Recipient crypto.PublicKey `json:"recipient"`
+
+	// Payload is arbitrary application data (at most [MaxMessageSize] bytes).
+	Payload []byte `json:"payload"`
+
+	// TxID is the transaction that created this message. This is used to ensure
+	// there is WarpID uniqueness.
+	TxID ids.ID `json:"txID"`
+}
+
+func (w *WarpMessage) Marshal() ([]byte, error) {
+	p := codec.NewWriter(WarpMessageSize)
+	p.PackByte(warpMessageType)
+	p.PackPublicKey(w.Sender)
+	p.PackPublicKey(w.Recipient)
+	p.PackBytes(w.Payload)
+	p.PackID(w.TxID)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalWarpMessage(b []byte) (*WarpMessage, error) {
+	var msg WarpMessage
+	p := codec.NewReader(b, WarpMessageSize)
+	typ := p.UnpackByte()
+	p.UnpackPublicKey(true, &msg.Sender)
+	p.UnpackPublicKey(false, &msg.Recipient) // can message blackhole
+	p.UnpackBytes(MaxMessageSize, true, &msg.Payload)
+	p.UnpackID(true, &msg.TxID)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if typ != warpMessageType || !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &msg, nil
+}
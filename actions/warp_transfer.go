@@ -12,10 +12,16 @@ import (
 	"github.com/ava-labs/hypersdk/utils"
 )
 
-const WarpTransferSize = crypto.PublicKeyLen + consts.IDLen +
+const WarpTransferSize = 1 + crypto.PublicKeyLen + consts.IDLen +
 	consts.Uint64Len + 1 + consts.Uint64Len + consts.Uint64Len +
 	consts.IDLen + consts.Uint64Len + consts.Uint64Len + consts.IDLen
 
+// Transfers exported before warp payloads were prefixed with their type have
+// no type byte. Every field after [Return] is a multiple of [consts.Uint64Len]
+// bytes long, so the two formats can always be told apart by their length.
+const legacyWarpTransferRemainder = (crypto.PublicKeyLen + consts.IDLen +
+	consts.Uint64Len + 1) % consts.Uint64Len
+
 type WarpTransfer struct {
 	To    crypto.PublicKey `json:"to"`
 	Asset ids.ID           `json:"asset"`
@@ -46,6 +52,7 @@ type WarpTransfer struct {
 
 func (w *WarpTransfer) Marshal() ([]byte, error) {
 	p := codec.NewWriter(WarpTransferSize)
+	p.PackByte(warpTransferType)
 	p.PackPublicKey(w.To)
 	p.PackID(w.Asset)
 	p.PackUint64(w.Value)
@@ -75,6 +82,10 @@ func ImportedAssetMetadata(assetID ids.ID, sourceChainID ids.ID) []byte {
 func UnmarshalWarpTransfer(b []byte) (*WarpTransfer, error) {
 	var transfer WarpTransfer
 	p := codec.NewReader(b, WarpTransferSize)
+	typ := warpTransferType
+	if len(b)%consts.Uint64Len != legacyWarpTransferRemainder {
+		typ = p.UnpackByte()
+	}
 	p.UnpackPublicKey(false, &transfer.To)
 	p.UnpackID(false, &transfer.Asset)
 	transfer.Value = p.UnpackUint64(true)
@@ -90,7 +101,7 @@ func UnmarshalWarpTransfer(b []byte) (*WarpTransfer, error) {
 	if err := p.Err(); err != nil {
 		return nil, err
 	}
-	if !p.Empty() {
+	if typ != warpTransferType || !p.Empty() {
 		return nil, chain.ErrInvalidObject
 	}
 	// Handle swap checks
@@ -0,0 +1,99 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*WithdrawStream)(nil)
+
+// WithdrawStream sends everything that has been streamed (and has not yet
+// been withdrawn) from a stream created by [CreateStream] to its recipient.
+type WithdrawStream struct {
+	// Stream is the [TxID] that created the stream.
+	Stream ids.ID `json:"stream"`
+
+	// Asset is the asset locked in the stream. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (w *WithdrawStream) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append(
+		receiveKeys(w.Asset, actor),
+		storage.PrefixStreamKey(w.Stream),
+		storage.PrefixBalanceKey(actor, w.Asset),
+	)
+}
+
+func (w *WithdrawStream) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := w.MaxUnits(r) // max units == units
+	exists, asset, sender, recipient, amount, start, end, withdrawn, err := storage.GetStream(ctx, db, w.Stream)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputStreamMissing}, nil
+	}
+	if recipient != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != w.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	streamed := StreamedAmount(amount, start, end, t)
+	if streamed <= withdrawn {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNothingStreamed}, nil
+	}
+	if output := claimStream(
+		ctx, db, w.Stream, asset, sender, recipient,
+		amount, start, end, streamed, actor, streamed-withdrawn,
+	); len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*WithdrawStream) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (w *WithdrawStream) Marshal(p *codec.Packer) {
+	p.PackID(w.Stream)
+	p.PackID(w.Asset)
+}
+
+func UnmarshalWithdrawStream(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var withdraw WithdrawStream
+	p.UnpackID(true, &withdraw.Stream)
+	p.UnpackID(false, &withdraw.Asset) // empty ID is the native asset
+	return &withdraw, p.Err()
+}
+
+func (*WithdrawStream) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, WithdrawStreamName)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"tokenvm/auth"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*WithdrawVote)(nil)
+
+// WithdrawVote returns the native asset locked by [Vote] once voting on
+// [Proposal] has closed. The vote still counts towards the proposal's tally.
+type WithdrawVote struct {
+	// Proposal is the [TxID] that created the proposal.
+	Proposal ids.ID `json:"proposal"`
+}
+
+func (w *WithdrawVote) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixProposalKey(w.Proposal),
+		storage.PrefixVoteKey(w.Proposal, actor),
+		storage.PrefixBalanceKey(actor, ids.Empty),
+	}
+}
+
+func (w *WithdrawVote) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := w.MaxUnits(r) // max units == units
+	config, ok := governanceConfig(r)
+	if !ok {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputGovernanceDisabled}, nil
+	}
+	voted, weight, _, err := storage.GetVote(ctx, db, w.Proposal, actor)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !voted {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputVoteMissing}, nil
+	}
+	exists, _, _, _, created, _, _, _, _, err := storage.GetProposal(ctx, db, w.Proposal)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if exists && t < created+config.VotingPeriod {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputVotingOpen}, nil
+	}
+	if err := storage.DeleteVote(ctx, db, w.Proposal, actor); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, ids.Empty, weight); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*WithdrawVote) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen
+}
+
+func (w *WithdrawVote) Marshal(p *codec.Packer) {
+	p.PackID(w.Proposal)
+}
+
+func UnmarshalWithdrawVote(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var withdraw WithdrawVote
+	p.UnpackID(true, &withdraw.Proposal)
+	return &withdraw, p.Err()
+}
+
+func (*WithdrawVote) ValidRange(r chain.Rules) (int64, int64) {
+	return validRange(r, WithdrawVoteName)
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+var _ chain.Auth = (*Delegated)(nil)
+
+// Delegated authorizes a transaction on behalf of [Actor] while [FeePayer]
+// pays its fees.
+type Delegated struct {
+	// Actor authorizes the action and is returned by [GetActor].
+	Actor          crypto.PublicKey `json:"actor"`
+	ActorSignature crypto.Signature `json:"actorSignature"`
+
+	// FeePayer is charged all fees and is returned by [GetSigner].
+	FeePayer          crypto.PublicKey `json:"feePayer"`
+	FeePayerSignature crypto.Signature `json:"feePayerSignature"`
+
+	feeSplit
+}
+
+// Signatures of [Delegated] are domain-separated from each other and from
+// every other auth so that they can never be used to authorize the same
+// transaction with a different auth (which would have a different ID).
+var (
+	delegatedActorPrefix    = []byte("tokenvm/delegated/actor")
+	delegatedFeePayerPrefix = []byte("tokenvm/delegated/payer")
+)
+
+// delegatedDigest returns the message signed by one of the parties of
+// [Delegated]. The transaction digest does not include [chain.Auth], so we
+// append the other party to prevent a signature from being reused with another
+// actor or fee payer.
+func delegatedDigest(prefix []byte, msg []byte, other crypto.PublicKey) []byte {
+	p := codec.NewWriter(len(prefix) + crypto.PublicKeyLen + len(msg))
+	p.PackFixedBytes(prefix)
+	p.PackPublicKey(other)
+	p.PackFixedBytes(msg)
+	return p.Bytes()
+}
+
+func (*Delegated) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return (crypto.PublicKeyLen + crypto.SignatureLen*5) * 2 // make signatures more expensive
+}
+
+func (*Delegated) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *Delegated) StateKeys() [][]byte {
+	keys := append(feeKeys(d.FeePayer), accountKeys(d.FeePayer)...)
+	return append(keys, accountKeys(d.Actor)...)
+}
+
+func (d *Delegated) AsyncVerify(msg []byte) error {
+	if !crypto.Verify(delegatedDigest(delegatedActorPrefix, msg, d.FeePayer), d.Actor, d.ActorSignature) {
+		return ErrInvalidSignature
+	}
+	if !crypto.Verify(delegatedDigest(delegatedFeePayerPrefix, msg, d.Actor), d.FeePayer, d.FeePayerSignature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (d *Delegated) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	d.setFeeShare(r)
+	// Neither signer can authorize transactions once its account is recovered
+	if err := checkAccountKey(ctx, db, d.Actor, d.Actor); err != nil {
+		return 0, err
+	}
+	if err := checkAccountKey(ctx, db, d.FeePayer, d.FeePayer); err != nil {
+		return 0, err
+	}
+	return d.MaxUnits(r), nil
+}
+
+func (d *Delegated) Payer() []byte {
+	return d.FeePayer[:]
+}
+
+func (d *Delegated) Marshal(p *codec.Packer) {
+	p.PackPublicKey(d.Actor)
+	p.PackSignature(d.ActorSignature)
+	p.PackPublicKey(d.FeePayer)
+	p.PackSignature(d.FeePayerSignature)
+}
+
+func UnmarshalDelegated(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var d Delegated
+	p.UnpackPublicKey(true, &d.Actor)
+	p.UnpackSignature(&d.ActorSignature)
+	p.UnpackPublicKey(true, &d.FeePayer)
+	p.UnpackSignature(&d.FeePayerSignature)
+	return &d, p.Err()
+}
+
+func (d *Delegated) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, d.FeePayer, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (d *Delegated) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return d.deductFee(ctx, db, d.FeePayer, amount)
+}
+
+func (d *Delegated) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return d.refundFee(ctx, db, d.FeePayer, amount)
+}
+
+var _ chain.AuthFactory = (*DelegatedFactory)(nil)
+
+func NewDelegatedFactory(actor crypto.PrivateKey, feePayer crypto.PrivateKey) *DelegatedFactory {
+	return &DelegatedFactory{actor, feePayer}
+}
+
+type DelegatedFactory struct {
+	actor    crypto.PrivateKey
+	feePayer crypto.PrivateKey
+}
+
+func (d *DelegatedFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	actor := d.actor.PublicKey()
+	feePayer := d.feePayer.PublicKey()
+	return &Delegated{
+		Actor:             actor,
+		ActorSignature:    crypto.Sign(delegatedDigest(delegatedActorPrefix, msg, feePayer), d.actor),
+		FeePayer:          feePayer,
+		FeePayerSignature: crypto.Sign(delegatedDigest(delegatedFeePayerPrefix, msg, actor), d.feePayer),
+	}, nil
+}
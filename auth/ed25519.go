@@ -20,6 +20,8 @@ var _ chain.Auth = (*ED25519)(nil)
 type ED25519 struct {
 	Signer    crypto.PublicKey `json:"signer"`
 	Signature crypto.Signature `json:"signature"`
+
+	feeSplit
 }
 
 func (*ED25519) MaxUnits(
@@ -33,10 +35,7 @@ func (*ED25519) ValidRange(chain.Rules) (int64, int64) {
 }
 
 func (d *ED25519) StateKeys() [][]byte {
-	return [][]byte{
-		// We always pay fees with the native asset (which is [ids.Empty])
-		storage.PrefixBalanceKey(d.Signer, ids.Empty),
-	}
+	return append(feeKeys(d.Signer), accountKeys(d.Signer)...)
 }
 
 func (d *ED25519) AsyncVerify(msg []byte) error {
@@ -47,13 +46,17 @@ func (d *ED25519) AsyncVerify(msg []byte) error {
 }
 
 func (d *ED25519) Verify(
-	_ context.Context,
+	ctx context.Context,
 	r chain.Rules,
-	_ chain.Database,
+	db chain.Database,
 	_ chain.Action,
 ) (uint64, error) {
-	// We don't do anything during verify (there is no additional state to check
-	// to authorize the signer other than verifying the signature)
+	d.setFeeShare(r)
+	// [Signer] can no longer authorize transactions once its account is
+	// recovered
+	if err := checkAccountKey(ctx, db, d.Signer, d.Signer); err != nil {
+		return 0, err
+	}
 	return d.MaxUnits(r), nil
 }
 
@@ -93,7 +96,7 @@ func (d *ED25519) Deduct(
 	db chain.Database,
 	amount uint64,
 ) error {
-	return storage.SubBalance(ctx, db, d.Signer, ids.Empty, amount)
+	return d.deductFee(ctx, db, d.Signer, amount)
 }
 
 func (d *ED25519) Refund(
@@ -101,7 +104,7 @@ func (d *ED25519) Refund(
 	db chain.Database,
 	amount uint64,
 ) error {
-	return storage.AddBalance(ctx, db, d.Signer, ids.Empty, amount)
+	return d.refundFee(ctx, db, d.Signer, amount)
 }
 
 var _ chain.AuthFactory = (*ED25519Factory)(nil)
@@ -116,5 +119,5 @@ type ED25519Factory struct {
 
 func (d *ED25519Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
 	sig := crypto.Sign(msg, d.priv)
-	return &ED25519{d.priv.PublicKey(), sig}, nil
+	return &ED25519{Signer: d.priv.PublicKey(), Signature: sig}, nil
 }
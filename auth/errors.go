@@ -5,4 +5,20 @@ package auth
 
 import "errors"
 
-var ErrInvalidSignature = errors.New("invalid signature")
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	ErrSelfSponsored         = errors.New("cannot sponsor self")
+	ErrSponsorMissing        = errors.New("sponsor does not accept asset")
+	ErrSponsorRateTooHigh    = errors.New("sponsor rate too high")
+	ErrSponsorAssetMissing   = errors.New("sponsor asset missing")
+	ErrSponsorAssetLocked    = errors.New("sponsor asset cannot be transferred")
+	ErrSponsorChargeOverflow = errors.New("sponsor charge overflow")
+
+	ErrSessionMissing          = errors.New("session key missing")
+	ErrSessionExpiryMismatch   = errors.New("session key expiry mismatch")
+	ErrSessionActionNotAllowed = errors.New("action not allowed for session key")
+	ErrSessionLimitExceeded    = errors.New("session key spending limit exceeded")
+
+	ErrWrongAccountKey = errors.New("key does not control account")
+)
@@ -0,0 +1,111 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"tokenvm/storage"
+	"tokenvm/utils"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+// FeeShareKey is the key used to look up the share of transaction fees (in
+// basis points) credited to the treasury with [chain.Rules.FetchCustom]. The
+// rest is burned.
+const FeeShareKey = "feeShare"
+
+// feeKeys returns the keys touched when [payer] pays fees.
+//
+// Fees are not sent to the treasury (or burned) directly because every
+// transaction would then modify the same keys. Instead, they are accumulated
+// in the fee pool shard of [payer] until they are settled with
+// [actions.SettleFees].
+func feeKeys(payer crypto.PublicKey) [][]byte {
+	return [][]byte{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(payer, ids.Empty),
+		storage.PrefixFeePoolKey(ids.Empty, storage.FeePoolShard(payer)),
+	}
+}
+
+// feeSplit determines how the fees of a transaction are split between the
+// treasury and the burn.
+//
+// It is set by [Verify], which is always called before [Deduct] and [Refund],
+// so that a refund reverses the same split as the deduction.
+type feeSplit struct {
+	share uint64
+}
+
+func (f *feeSplit) setFeeShare(r chain.Rules) {
+	f.share = 0
+	if v, ok := r.FetchCustom(FeeShareKey); ok {
+		if share, ok := v.(uint64); ok {
+			f.share = share
+		}
+	}
+}
+
+// deductFee charges [amount] of the native asset to [payer] and adds it to
+// the fee pool.
+func (f *feeSplit) deductFee(
+	ctx context.Context,
+	db chain.Database,
+	payer crypto.PublicKey,
+	amount uint64,
+) error {
+	if err := storage.SubBalance(ctx, db, payer, ids.Empty, amount); err != nil {
+		return err
+	}
+	shard := storage.FeePoolShard(payer)
+	burn, treasury, recipient, err := storage.GetFeePool(ctx, db, ids.Empty, shard)
+	if err != nil {
+		return err
+	}
+	share := utils.BasisPoints(amount, f.share)
+	burn, err = smath.Add64(burn, amount-share)
+	if err != nil {
+		return err
+	}
+	treasury, err = smath.Add64(treasury, share)
+	if err != nil {
+		return err
+	}
+	return storage.SetFeePool(ctx, db, ids.Empty, shard, burn, treasury, recipient)
+}
+
+// refundFee returns [amount] of a previously deducted fee to [payer].
+//
+// [actions.SettleFees] never settles the shard of its own payer, so the
+// deducted fee is always still in the fee pool.
+func (f *feeSplit) refundFee(
+	ctx context.Context,
+	db chain.Database,
+	payer crypto.PublicKey,
+	amount uint64,
+) error {
+	shard := storage.FeePoolShard(payer)
+	burn, treasury, recipient, err := storage.GetFeePool(ctx, db, ids.Empty, shard)
+	if err != nil {
+		return err
+	}
+	share := utils.BasisPoints(amount, f.share)
+	burn, err = smath.Sub(burn, amount-share)
+	if err != nil {
+		return err
+	}
+	treasury, err = smath.Sub(treasury, share)
+	if err != nil {
+		return err
+	}
+	if err := storage.SetFeePool(ctx, db, ids.Empty, shard, burn, treasury, recipient); err != nil {
+		return err
+	}
+	return storage.AddBalance(ctx, db, payer, ids.Empty, amount)
+}
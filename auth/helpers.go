@@ -8,19 +8,42 @@ import (
 	"github.com/ava-labs/hypersdk/crypto"
 )
 
+// GetActor returns the account that authorized the action of a transaction.
 func GetActor(auth chain.Auth) crypto.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *Sponsored:
+		return a.Signer
+	case *Delegated:
+		return a.Actor
+	case *SECP256K1:
+		return SECP256K1Actor(a.Address)
+	case *Session:
+		return a.Owner
+	case *Recovered:
+		return a.Account
 	default:
 		return crypto.EmptyPublicKey
 	}
 }
 
+// GetSigner returns the account that pays the fees of a transaction (which may
+// not be the actor).
 func GetSigner(auth chain.Auth) crypto.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *Sponsored:
+		return a.Sponsor
+	case *Delegated:
+		return a.FeePayer
+	case *SECP256K1:
+		return SECP256K1Actor(a.Address)
+	case *Session:
+		return a.Owner
+	case *Recovered:
+		return a.Account
 	default:
 		return crypto.EmptyPublicKey
 	}
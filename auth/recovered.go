@@ -0,0 +1,172 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+var _ chain.Auth = (*Recovered)(nil)
+
+// Recovered authorizes a transaction on behalf of [Account] with [Key] after
+// the guardians of [Account] rotated control of it to [Key] with
+// [actions.InitiateRecovery].
+//
+// Balances (and everything else) remain keyed by [Account], so they are
+// reachable with [Key] once the rotation completes.
+type Recovered struct {
+	// Account is the account [Key] controls and is returned by both
+	// [GetActor] and [GetSigner].
+	Account crypto.PublicKey `json:"account"`
+
+	// Key is the key that signed the transaction.
+	Key crypto.PublicKey `json:"key"`
+
+	Signature crypto.Signature `json:"signature"`
+
+	feeSplit
+}
+
+// accountKeys returns the state keys read by [checkAccountKey].
+func accountKeys(account crypto.PublicKey) [][]byte {
+	return [][]byte{storage.PrefixAccountKey(account)}
+}
+
+// checkAccountKey returns an error if [key] does not control [account]. An
+// account is controlled by its own key until it is recovered.
+func checkAccountKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	key crypto.PublicKey,
+) error {
+	current, err := storage.GetAccountKey(ctx, db, account)
+	if err != nil {
+		return err
+	}
+	if current != key {
+		return ErrWrongAccountKey
+	}
+	return nil
+}
+
+// recoveredDigest returns the message signed by [Key]. The transaction digest
+// does not include [chain.Auth], so we append [Account] to prevent a key that
+// controls multiple accounts from being redirected to another account.
+func recoveredDigest(msg []byte, account crypto.PublicKey) []byte {
+	p := codec.NewWriter(len(msg) + crypto.PublicKeyLen)
+	p.PackFixedBytes(msg)
+	p.PackPublicKey(account)
+	return p.Bytes()
+}
+
+func (*Recovered) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return crypto.PublicKeyLen*2 + crypto.SignatureLen*5 // make signatures more expensive
+}
+
+func (*Recovered) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (r *Recovered) StateKeys() [][]byte {
+	return append(feeKeys(r.Account), accountKeys(r.Account)...)
+}
+
+func (r *Recovered) AsyncVerify(msg []byte) error {
+	if !crypto.Verify(recoveredDigest(msg, r.Account), r.Key, r.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (r *Recovered) Verify(
+	ctx context.Context,
+	rules chain.Rules,
+	db chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	r.setFeeShare(rules)
+	if err := checkAccountKey(ctx, db, r.Account, r.Key); err != nil {
+		return 0, err
+	}
+	return r.MaxUnits(rules), nil
+}
+
+func (r *Recovered) Payer() []byte {
+	return r.Account[:]
+}
+
+func (r *Recovered) Marshal(p *codec.Packer) {
+	p.PackPublicKey(r.Account)
+	p.PackPublicKey(r.Key)
+	p.PackSignature(r.Signature)
+}
+
+func UnmarshalRecovered(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var r Recovered
+	p.UnpackPublicKey(true, &r.Account)
+	p.UnpackPublicKey(true, &r.Key)
+	p.UnpackSignature(&r.Signature)
+	return &r, p.Err()
+}
+
+func (r *Recovered) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, r.Account, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (r *Recovered) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return r.deductFee(ctx, db, r.Account, amount)
+}
+
+func (r *Recovered) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return r.refundFee(ctx, db, r.Account, amount)
+}
+
+var _ chain.AuthFactory = (*RecoveredFactory)(nil)
+
+func NewRecoveredFactory(account crypto.PublicKey, priv crypto.PrivateKey) *RecoveredFactory {
+	return &RecoveredFactory{account, priv}
+}
+
+type RecoveredFactory struct {
+	account crypto.PublicKey
+	priv    crypto.PrivateKey
+}
+
+func (r *RecoveredFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	return &Recovered{
+		Account:   r.account,
+		Key:       r.priv.PublicKey(),
+		Signature: crypto.Sign(recoveredDigest(msg, r.account), r.priv),
+	}, nil
+}
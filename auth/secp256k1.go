@@ -0,0 +1,173 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+var _ chain.Auth = (*SECP256K1)(nil)
+
+const (
+	SECP256K1AddressLen   = common.AddressLength
+	SECP256K1SignatureLen = ethcrypto.SignatureLength
+)
+
+// SECP256K1 authorizes a transaction with a recoverable secp256k1 signature
+// over the Keccak-256 hash of the transaction digest (as Ethereum does). The
+// public key of the signer is recovered from [Signature] and must hash to
+// [Address].
+type SECP256K1 struct {
+	Address   common.Address              `json:"address"`
+	Signature [SECP256K1SignatureLen]byte `json:"signature"`
+
+	feeSplit
+}
+
+// SECP256K1Actor returns the account controlled by the secp256k1 key with
+// Ethereum address [addr]. Accounts are 32 bytes, so [addr] is left-padded
+// with zeros.
+func SECP256K1Actor(addr common.Address) crypto.PublicKey {
+	var pk crypto.PublicKey
+	copy(pk[crypto.PublicKeyLen-SECP256K1AddressLen:], addr[:])
+	return pk
+}
+
+func (*SECP256K1) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return SECP256K1AddressLen + SECP256K1SignatureLen*5 // make signatures more expensive
+}
+
+func (*SECP256K1) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (s *SECP256K1) StateKeys() [][]byte {
+	actor := SECP256K1Actor(s.Address)
+	return append(feeKeys(actor), accountKeys(actor)...)
+}
+
+func (s *SECP256K1) AsyncVerify(msg []byte) error {
+	// Reject malleable signatures (high S) so that the same transaction can't
+	// be included more than once with a different ID.
+	r := new(big.Int).SetBytes(s.Signature[:32])
+	sv := new(big.Int).SetBytes(s.Signature[32:64])
+	if !ethcrypto.ValidateSignatureValues(s.Signature[64], r, sv, true) {
+		return ErrInvalidSignature
+	}
+	pub, err := ethcrypto.SigToPub(ethcrypto.Keccak256(msg), s.Signature[:])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if ethcrypto.PubkeyToAddress(*pub) != s.Address {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *SECP256K1) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	s.setFeeShare(r)
+	// [Address] can no longer authorize transactions once its account is
+	// recovered
+	actor := SECP256K1Actor(s.Address)
+	if err := checkAccountKey(ctx, db, actor, actor); err != nil {
+		return 0, err
+	}
+	return s.MaxUnits(r), nil
+}
+
+func (s *SECP256K1) Payer() []byte {
+	actor := SECP256K1Actor(s.Address)
+	return actor[:]
+}
+
+func (s *SECP256K1) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(s.Address[:])
+	p.PackFixedBytes(s.Signature[:])
+}
+
+func UnmarshalSECP256K1(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var (
+		s         SECP256K1
+		address   = make([]byte, SECP256K1AddressLen)
+		signature = make([]byte, SECP256K1SignatureLen)
+	)
+	p.UnpackFixedBytes(SECP256K1AddressLen, &address)
+	p.UnpackFixedBytes(SECP256K1SignatureLen, &signature)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	copy(s.Address[:], address)
+	copy(s.Signature[:], signature)
+	return &s, nil
+}
+
+func (s *SECP256K1) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, SECP256K1Actor(s.Address), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (s *SECP256K1) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return s.deductFee(ctx, db, SECP256K1Actor(s.Address), amount)
+}
+
+func (s *SECP256K1) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return s.refundFee(ctx, db, SECP256K1Actor(s.Address), amount)
+}
+
+var _ chain.AuthFactory = (*SECP256K1Factory)(nil)
+
+func NewSECP256K1Factory(priv *ecdsa.PrivateKey) *SECP256K1Factory {
+	return &SECP256K1Factory{priv}
+}
+
+type SECP256K1Factory struct {
+	priv *ecdsa.PrivateKey
+}
+
+func (s *SECP256K1Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig, err := ethcrypto.Sign(ethcrypto.Keccak256(msg), s.priv)
+	if err != nil {
+		return nil, err
+	}
+	auth := &SECP256K1{Address: ethcrypto.PubkeyToAddress(s.priv.PublicKey)}
+	copy(auth.Signature[:], sig)
+	return auth, nil
+}
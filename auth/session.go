@@ -0,0 +1,270 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+
+	"tokenvm/consts"
+	"tokenvm/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	hconsts "github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+)
+
+var _ chain.Auth = (*Session)(nil)
+
+// Session authorizes a transaction on behalf of [Owner] with a session key
+// registered by [Owner] with [actions.RegisterSession].
+//
+// A session key may only perform the actions it was registered with and can
+// only spend (including fees) the remaining allowance of each asset it was
+// registered with. Once [Expiry] passes (or the account of [Owner] is
+// recovered), the session key can no longer be used.
+type Session struct {
+	// Owner is the account the session key acts as and is returned by both
+	// [GetActor] and [GetSigner].
+	Owner crypto.PublicKey `json:"owner"`
+
+	// Key is the session key that signed the transaction.
+	Key crypto.PublicKey `json:"key"`
+
+	// Expiry must match the expiry the session key was registered with.
+	Expiry int64 `json:"expiry"`
+
+	Signature crypto.Signature `json:"signature"`
+
+	feeSplit
+}
+
+// sessionDigest returns the message signed by [Key]. The transaction digest
+// does not include [chain.Auth], so we append [Owner] and [Expiry] to prevent
+// a session key registered by multiple accounts from being redirected to
+// another account.
+func sessionDigest(msg []byte, owner crypto.PublicKey, expiry int64) []byte {
+	p := codec.NewWriter(len(msg) + crypto.PublicKeyLen + hconsts.Uint64Len)
+	p.PackFixedBytes(msg)
+	p.PackPublicKey(owner)
+	p.PackInt64(expiry)
+	return p.Bytes()
+}
+
+func (*Session) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return crypto.PublicKeyLen*2 + hconsts.Uint64Len + crypto.SignatureLen*5 // make signatures more expensive
+}
+
+func (s *Session) ValidRange(chain.Rules) (int64, int64) {
+	// The session key can't be used after it expires
+	return -1, s.Expiry
+}
+
+func (s *Session) StateKeys() [][]byte {
+	keys := append(feeKeys(s.Owner), accountKeys(s.Owner)...)
+	return append(keys, storage.PrefixSessionKey(s.Owner, s.Key))
+}
+
+func (s *Session) AsyncVerify(msg []byte) error {
+	if !crypto.Verify(sessionDigest(msg, s.Owner, s.Expiry), s.Key, s.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Session) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	action chain.Action,
+) (uint64, error) {
+	s.setFeeShare(r)
+	// Session keys of [Owner] can no longer authorize transactions once its
+	// account is recovered
+	if err := checkAccountKey(ctx, db, s.Owner, s.Owner); err != nil {
+		return 0, err
+	}
+	exists, expiry, actions, _, _, err := storage.GetSession(ctx, db, s.Owner, s.Key)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrSessionMissing
+	}
+	if expiry != s.Expiry {
+		return 0, ErrSessionExpiryMismatch
+	}
+	typeID, _, _, ok := consts.ActionRegistry.LookupType(action)
+	if !ok {
+		return 0, ErrSessionActionNotAllowed
+	}
+	for _, allowed := range actions {
+		if allowed == typeID {
+			return s.MaxUnits(r), nil
+		}
+	}
+	return 0, ErrSessionActionNotAllowed
+}
+
+func (s *Session) Payer() []byte {
+	return s.Owner[:]
+}
+
+func (s *Session) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Owner)
+	p.PackPublicKey(s.Key)
+	p.PackInt64(s.Expiry)
+	p.PackSignature(s.Signature)
+}
+
+func UnmarshalSession(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var s Session
+	p.UnpackPublicKey(true, &s.Owner)
+	p.UnpackPublicKey(true, &s.Key)
+	s.Expiry = p.UnpackInt64(true)
+	p.UnpackSignature(&s.Signature)
+	return &s, p.Err()
+}
+
+func (s *Session) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, s.Owner, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	remaining, err := s.remaining(ctx, db, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if remaining < amount {
+		return ErrSessionLimitExceeded
+	}
+	return nil
+}
+
+func (s *Session) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	if err := s.spend(ctx, db, ids.Empty, amount, false); err != nil {
+		return err
+	}
+	return s.deductFee(ctx, db, s.Owner, amount)
+}
+
+func (s *Session) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	if err := s.refundFee(ctx, db, s.Owner, amount); err != nil {
+		return err
+	}
+	return s.spend(ctx, db, ids.Empty, amount, true)
+}
+
+// remaining returns how much of [asset] the session key can still spend.
+func (s *Session) remaining(ctx context.Context, db chain.Database, asset ids.ID) (uint64, error) {
+	_, _, _, assets, remaining, err := storage.GetSession(ctx, db, s.Owner, s.Key)
+	if err != nil {
+		return 0, err
+	}
+	for i, a := range assets {
+		if a == asset {
+			return remaining[i], nil
+		}
+	}
+	return 0, nil
+}
+
+// spend subtracts (or adds back) [amount] of [asset] from the remaining
+// allowance of the session key.
+func (s *Session) spend(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	amount uint64,
+	refund bool,
+) error {
+	exists, expiry, actions, assets, remaining, err := storage.GetSession(ctx, db, s.Owner, s.Key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if refund {
+			// Refunds should never fail, so there is nothing to return the
+			// allowance to if the session was removed.
+			return nil
+		}
+		return ErrSessionMissing
+	}
+	for i, a := range assets {
+		if a != asset {
+			continue
+		}
+		if refund {
+			remaining[i], err = smath.Add64(remaining[i], amount)
+		} else {
+			remaining[i], err = smath.Sub(remaining[i], amount)
+		}
+		if err != nil {
+			return ErrSessionLimitExceeded
+		}
+		return storage.SetSession(ctx, db, s.Owner, s.Key, expiry, actions, assets, remaining)
+	}
+	if refund || amount == 0 {
+		return nil
+	}
+	return ErrSessionLimitExceeded
+}
+
+// Spend charges [amount] of [asset] to the remaining allowance of [auth] if it
+// is a [Session]. Actions must call [Spend] whenever they move funds out of
+// the account of the actor.
+func Spend(
+	ctx context.Context,
+	db chain.Database,
+	auth chain.Auth,
+	asset ids.ID,
+	amount uint64,
+) error {
+	s, ok := auth.(*Session)
+	if !ok {
+		return nil
+	}
+	return s.spend(ctx, db, asset, amount, false)
+}
+
+var _ chain.AuthFactory = (*SessionFactory)(nil)
+
+func NewSessionFactory(owner crypto.PublicKey, priv crypto.PrivateKey, expiry int64) *SessionFactory {
+	return &SessionFactory{owner, priv, expiry}
+}
+
+type SessionFactory struct {
+	owner  crypto.PublicKey
+	priv   crypto.PrivateKey
+	expiry int64
+}
+
+func (s *SessionFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	return &Session{
+		Owner:     s.owner,
+		Key:       s.priv.PublicKey(),
+		Expiry:    s.expiry,
+		Signature: crypto.Sign(sessionDigest(msg, s.owner, s.expiry), s.priv),
+	}, nil
+}
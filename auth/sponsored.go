@@ -0,0 +1,297 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"math"
+
+	"tokenvm/storage"
+	"tokenvm/utils"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	hutils "github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Auth = (*Sponsored)(nil)
+
+// sponsorRateUnits is the number of native units the rate published by a
+// sponsor is denominated in (1 whole native token).
+var sponsorRateUnits = uint64(math.Pow10(hutils.NativeDecimals))
+
+// Sponsored allows [Signer] to transact without holding the native asset.
+// [Sponsor] pays the fee in the native asset and is repaid by [Signer] in
+// [Asset] at the rate published by [Sponsor] with [actions.SetSponsorRate].
+type Sponsored struct {
+	Signer  crypto.PublicKey `json:"signer"`
+	Sponsor crypto.PublicKey `json:"sponsor"`
+	Asset   ids.ID           `json:"asset"`
+
+	// MaxRate is the highest rate [Signer] is willing to pay. It prevents
+	// [Sponsor] from raising its rate while the transaction is pending.
+	MaxRate uint64 `json:"maxRate"`
+
+	Signature crypto.Signature `json:"signature"`
+
+	feeSplit
+}
+
+// sponsoredDigest returns the message signed by [Signer]. The transaction
+// digest does not include [chain.Auth], so we append the sponsorship terms to
+// prevent them from being modified by anyone else.
+func sponsoredDigest(msg []byte, sponsor crypto.PublicKey, asset ids.ID, maxRate uint64) []byte {
+	p := codec.NewWriter(len(msg) + crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len)
+	p.PackFixedBytes(msg)
+	p.PackPublicKey(sponsor)
+	p.PackID(asset)
+	p.PackUint64(maxRate)
+	return p.Bytes()
+}
+
+// sponsorCharge returns the amount of [Asset] charged for [fee] at [rate].
+// The charge is rounded up (and refunds are rounded down) so that the
+// sponsor is never underpaid.
+func sponsorCharge(fee uint64, rate uint64, roundUp bool) (uint64, error) {
+	charge, ok := utils.MulDiv(fee, rate, sponsorRateUnits, roundUp)
+	if !ok {
+		return 0, ErrSponsorChargeOverflow
+	}
+	return charge, nil
+}
+
+func (*Sponsored) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return crypto.PublicKeyLen*2 + consts.IDLen + consts.Uint64Len + crypto.SignatureLen*5 // make signatures more expensive
+}
+
+func (*Sponsored) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (s *Sponsored) StateKeys() [][]byte {
+	return append(
+		feeKeys(s.Sponsor),
+		storage.PrefixSponsorRateKey(s.Sponsor, s.Asset),
+		storage.PrefixBalanceKey(s.Signer, s.Asset),
+		storage.PrefixBalanceKey(s.Sponsor, s.Asset),
+		storage.PrefixAssetKey(s.Asset),
+		storage.PrefixFrozenKey(s.Asset, s.Signer),
+		storage.PrefixAllowlistKey(s.Asset, s.Sponsor),
+		storage.PrefixAccountKey(s.Signer),
+	)
+}
+
+func (s *Sponsored) AsyncVerify(msg []byte) error {
+	if !crypto.Verify(sponsoredDigest(msg, s.Sponsor, s.Asset, s.MaxRate), s.Signer, s.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Sponsored) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	s.setFeeShare(r)
+	if s.Signer == s.Sponsor {
+		return 0, ErrSelfSponsored
+	}
+	if err := checkAccountKey(ctx, db, s.Signer, s.Signer); err != nil {
+		return 0, err
+	}
+	if _, err := s.rate(ctx, db); err != nil {
+		return 0, err
+	}
+	// [Signer] must be able to send [Asset] to [Sponsor]
+	exists, _, _, owner, _, flags, err := storage.GetAsset(ctx, db, s.Asset)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrSponsorAssetMissing
+	}
+	if flags&storage.AssetPaused != 0 {
+		return 0, ErrSponsorAssetLocked
+	}
+	frozen, err := storage.GetFrozen(ctx, db, s.Asset, s.Signer)
+	if err != nil {
+		return 0, err
+	}
+	if frozen {
+		return 0, ErrSponsorAssetLocked
+	}
+	if flags&storage.AssetAllowlist != 0 && owner != s.Sponsor {
+		allowed, err := storage.GetAllowlisted(ctx, db, s.Asset, s.Sponsor)
+		if err != nil {
+			return 0, err
+		}
+		if !allowed {
+			return 0, ErrSponsorAssetLocked
+		}
+	}
+	return s.MaxUnits(r), nil
+}
+
+// rate returns the rate published by [Sponsor] for [Asset] if it is not
+// higher than [MaxRate].
+func (s *Sponsored) rate(ctx context.Context, db chain.Database) (uint64, error) {
+	rate, err := storage.GetSponsorRate(ctx, db, s.Sponsor, s.Asset)
+	if err != nil {
+		return 0, err
+	}
+	if rate == 0 {
+		return 0, ErrSponsorMissing
+	}
+	if rate > s.MaxRate {
+		return 0, ErrSponsorRateTooHigh
+	}
+	return rate, nil
+}
+
+func (s *Sponsored) Payer() []byte {
+	return s.Sponsor[:]
+}
+
+func (s *Sponsored) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Signer)
+	p.PackPublicKey(s.Sponsor)
+	p.PackID(s.Asset)
+	p.PackUint64(s.MaxRate)
+	p.PackSignature(s.Signature)
+}
+
+func UnmarshalSponsored(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var s Sponsored
+	p.UnpackPublicKey(true, &s.Signer)
+	p.UnpackPublicKey(true, &s.Sponsor)
+	p.UnpackID(true, &s.Asset) // native fees do not need a sponsor
+	s.MaxRate = p.UnpackUint64(true)
+	p.UnpackSignature(&s.Signature)
+	return &s, p.Err()
+}
+
+func (s *Sponsored) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, s.Sponsor, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	rate, err := s.rate(ctx, db)
+	if err != nil {
+		return err
+	}
+	charge, err := sponsorCharge(amount, rate, true)
+	if err != nil {
+		return err
+	}
+	bal, err = storage.GetBalance(ctx, db, s.Signer, s.Asset)
+	if err != nil {
+		return err
+	}
+	if bal < charge {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (s *Sponsored) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	rate, err := s.rate(ctx, db)
+	if err != nil {
+		return err
+	}
+	charge, err := sponsorCharge(amount, rate, true)
+	if err != nil {
+		return err
+	}
+	if err := s.deductFee(ctx, db, s.Sponsor, amount); err != nil {
+		return err
+	}
+	if err := storage.SubBalance(ctx, db, s.Signer, s.Asset, charge); err != nil {
+		return err
+	}
+	return storage.AddBalance(ctx, db, s.Sponsor, s.Asset, charge)
+}
+
+func (s *Sponsored) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	if err := s.refundFee(ctx, db, s.Sponsor, amount); err != nil {
+		return err
+	}
+	// [Signer] is the actor and can never modify the rate of [Sponsor], so it
+	// is the same as the rate used in [Deduct].
+	rate, err := storage.GetSponsorRate(ctx, db, s.Sponsor, s.Asset)
+	if err != nil {
+		return err
+	}
+	charge, err := sponsorCharge(amount, rate, false)
+	if err != nil {
+		return err
+	}
+	// The action may have reduced the balance of [Sponsor] (e.g. with a
+	// clawback by [Signer]), so we return as much as we can.
+	bal, err := storage.GetBalance(ctx, db, s.Sponsor, s.Asset)
+	if err != nil {
+		return err
+	}
+	if bal < charge {
+		charge = bal
+	}
+	if charge == 0 {
+		return nil
+	}
+	if err := storage.SubBalance(ctx, db, s.Sponsor, s.Asset, charge); err != nil {
+		return err
+	}
+	return storage.AddBalance(ctx, db, s.Signer, s.Asset, charge)
+}
+
+var _ chain.AuthFactory = (*SponsoredFactory)(nil)
+
+func NewSponsoredFactory(
+	priv crypto.PrivateKey,
+	sponsor crypto.PublicKey,
+	asset ids.ID,
+	maxRate uint64,
+) *SponsoredFactory {
+	return &SponsoredFactory{priv, sponsor, asset, maxRate}
+}
+
+type SponsoredFactory struct {
+	priv    crypto.PrivateKey
+	sponsor crypto.PublicKey
+	asset   ids.ID
+	maxRate uint64
+}
+
+func (s *SponsoredFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig := crypto.Sign(sponsoredDigest(msg, s.sponsor, s.asset, s.maxRate), s.priv)
+	return &Sponsored{
+		Signer:    s.priv.PublicKey(),
+		Sponsor:   s.sponsor,
+		Asset:     s.asset,
+		MaxRate:   s.maxRate,
+		Signature: sig,
+	}, nil
+}
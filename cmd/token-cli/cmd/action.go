@@ -6,10 +6,12 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"tokenvm/actions"
+	tconsts "tokenvm/consts"
 	trpc "tokenvm/rpc"
 	"tokenvm/utils"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/rpc"
@@ -41,7 +44,7 @@ var transferCmd = &cobra.Command{
 	Use: "transfer",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		_, priv, factory, cli, tcli, err := defaultActor()
+		_, actor, factory, cli, tcli, err := defaultActor()
 		if err != nil {
 			return err
 		}
@@ -51,7 +54,7 @@ var transferCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		balance, _, err := getAssetInfo(ctx, tcli, priv.PublicKey(), assetID, true)
+		balance, _, err := getAssetInfo(ctx, tcli, actor, assetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -156,7 +159,7 @@ var mintAssetCmd = &cobra.Command{
 	Use: "mint-asset",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		_, priv, factory, cli, tcli, err := defaultActor()
+		_, actor, factory, cli, tcli, err := defaultActor()
 		if err != nil {
 			return err
 		}
@@ -166,7 +169,7 @@ var mintAssetCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		exists, metadata, supply, owner, warp, err := tcli.Asset(ctx, assetID)
+		exists, metadata, supply, owner, warp, _, err := tcli.Asset(ctx, assetID)
 		if err != nil {
 			return err
 		}
@@ -180,7 +183,7 @@ var mintAssetCmd = &cobra.Command{
 			hutils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
 		}
-		if owner != utils.Address(priv.PublicKey()) {
+		if owner != utils.Address(actor) {
 			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", owner, assetID)
 			hutils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
@@ -289,7 +292,7 @@ var createOrderCmd = &cobra.Command{
 	Use: "create-order",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		_, priv, factory, cli, tcli, err := defaultActor()
+		_, actor, factory, cli, tcli, err := defaultActor()
 		if err != nil {
 			return err
 		}
@@ -300,7 +303,7 @@ var createOrderCmd = &cobra.Command{
 			return err
 		}
 		if inAssetID != ids.Empty {
-			exists, metadata, supply, _, warp, err := tcli.Asset(ctx, inAssetID)
+			exists, metadata, supply, _, warp, _, err := tcli.Asset(ctx, inAssetID)
 			if err != nil {
 				return err
 			}
@@ -328,7 +331,7 @@ var createOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		balance, _, err := getAssetInfo(ctx, tcli, priv.PublicKey(), outAssetID, true)
+		balance, _, err := getAssetInfo(ctx, tcli, actor, outAssetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -392,7 +395,7 @@ var fillOrderCmd = &cobra.Command{
 	Use: "fill-order",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		_, priv, factory, cli, tcli, err := defaultActor()
+		_, actor, factory, cli, tcli, err := defaultActor()
 		if err != nil {
 			return err
 		}
@@ -402,7 +405,7 @@ var fillOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		balance, _, err := getAssetInfo(ctx, tcli, priv.PublicKey(), inAssetID, true)
+		balance, _, err := getAssetInfo(ctx, tcli, actor, inAssetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -412,7 +415,7 @@ var fillOrderCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if _, _, err := getAssetInfo(ctx, tcli, priv.PublicKey(), outAssetID, false); err != nil {
+		if _, _, err := getAssetInfo(ctx, tcli, actor, outAssetID, false); err != nil {
 			return err
 		}
 
@@ -525,7 +528,7 @@ func performImport(
 	dcli *rpc.JSONRPCClient,
 	dtcli *trpc.JSONRPCClient,
 	exportTxID ids.ID,
-	priv crypto.PrivateKey,
+	actor crypto.PublicKey,
 	factory chain.AuthFactory,
 ) error {
 	// Select TxID (if not provided)
@@ -538,35 +541,9 @@ func performImport(
 	}
 
 	// Generate warp signature (as long as >= 80% stake)
-	var (
-		msg                     *warp.Message
-		subnetWeight, sigWeight uint64
-	)
-	for ctx.Err() == nil {
-		msg, subnetWeight, sigWeight, err = scli.GenerateAggregateWarpSignature(ctx, exportTxID)
-		if sigWeight >= (subnetWeight*4)/5 && err == nil {
-			break
-		}
-		if err == nil {
-			hutils.Outf(
-				"{{yellow}}waiting for signature weight:{{/}} %d {{yellow}}observed:{{/}} %d\n",
-				subnetWeight,
-				sigWeight,
-			)
-		} else {
-			hutils.Outf("{{red}}encountered error:{{/}} %v\n", err)
-		}
-		cont, err := promptBool("try again")
-		if err != nil {
-			return err
-		}
-		if !cont {
-			hutils.Outf("{{red}}exiting...{{/}}\n")
-			return nil
-		}
-	}
-	if ctx.Err() != nil {
-		return ctx.Err()
+	msg, subnetWeight, sigWeight, err := aggregateWarpSignature(ctx, scli, exportTxID)
+	if msg == nil || err != nil {
+		return err
 	}
 	wt, err := actions.UnmarshalWarpTransfer(msg.UnsignedMessage.Payload)
 	if err != nil {
@@ -620,7 +597,7 @@ func performImport(
 	}
 
 	// Attempt to send dummy transaction if needed
-	if err := submitDummy(ctx, dcli, dtcli, priv.PublicKey(), factory); err != nil {
+	if err := submitDummy(ctx, dcli, dtcli, actor, factory); err != nil {
 		return err
 	}
 
@@ -646,6 +623,48 @@ func performImport(
 	return nil
 }
 
+// aggregateWarpSignature collects signatures for the warp message produced by
+// [txID] until at least 80% of stake has signed. If the user stops waiting,
+// a nil message is returned.
+func aggregateWarpSignature(
+	ctx context.Context,
+	scli *rpc.JSONRPCClient,
+	txID ids.ID,
+) (*warp.Message, uint64, uint64, error) {
+	var (
+		msg                     *warp.Message
+		subnetWeight, sigWeight uint64
+		err                     error
+	)
+	for ctx.Err() == nil {
+		msg, subnetWeight, sigWeight, err = scli.GenerateAggregateWarpSignature(ctx, txID)
+		if sigWeight >= (subnetWeight*4)/5 && err == nil {
+			break
+		}
+		if err == nil {
+			hutils.Outf(
+				"{{yellow}}waiting for signature weight:{{/}} %d {{yellow}}observed:{{/}} %d\n",
+				subnetWeight,
+				sigWeight,
+			)
+		} else {
+			hutils.Outf("{{red}}encountered error:{{/}} %v\n", err)
+		}
+		cont, err := promptBool("try again")
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if !cont {
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil, 0, 0, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, 0, 0, ctx.Err()
+	}
+	return msg, subnetWeight, sigWeight, nil
+}
+
 func submitDummy(
 	ctx context.Context,
 	cli *rpc.JSONRPCClient,
@@ -703,7 +722,7 @@ var importAssetCmd = &cobra.Command{
 	Use: "import-asset",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		currentChainID, priv, factory, dcli, dtcli, err := defaultActor()
+		currentChainID, actor, factory, dcli, dtcli, err := defaultActor()
 		if err != nil {
 			return err
 		}
@@ -716,7 +735,7 @@ var importAssetCmd = &cobra.Command{
 		scli := rpc.NewJSONRPCClient(uris[0])
 
 		// Perform import
-		return performImport(ctx, scli, dcli, dtcli, ids.Empty, priv, factory)
+		return performImport(ctx, scli, dcli, dtcli, ids.Empty, actor, factory)
 	},
 }
 
@@ -724,7 +743,7 @@ var exportAssetCmd = &cobra.Command{
 	Use: "export-asset",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		currentChainID, priv, factory, cli, tcli, err := defaultActor()
+		currentChainID, actor, factory, cli, tcli, err := defaultActor()
 		if err != nil {
 			return err
 		}
@@ -734,7 +753,7 @@ var exportAssetCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		balance, sourceChainID, err := getAssetInfo(ctx, tcli, priv.PublicKey(), assetID, true)
+		balance, sourceChainID, err := getAssetInfo(ctx, tcli, actor, assetID, true)
 		if balance == 0 || err != nil {
 			return err
 		}
@@ -814,7 +833,7 @@ var exportAssetCmd = &cobra.Command{
 		}
 
 		// Attempt to send dummy transaction if needed
-		if err := submitDummy(ctx, cli, tcli, priv.PublicKey(), factory); err != nil {
+		if err := submitDummy(ctx, cli, tcli, actor, factory); err != nil {
 			return err
 		}
 
@@ -857,7 +876,7 @@ var exportAssetCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			if err := performImport(ctx, cli, rpc.NewJSONRPCClient(uris[0]), trpc.NewJSONRPCClient(uris[0], destination), tx.ID(), priv, factory); err != nil {
+			if err := performImport(ctx, cli, rpc.NewJSONRPCClient(uris[0]), trpc.NewJSONRPCClient(uris[0], destination), tx.ID(), actor, factory); err != nil {
 				return err
 			}
 		}
@@ -873,3 +892,2861 @@ var exportAssetCmd = &cobra.Command{
 		return StoreDefault(defaultChainKey, destination[:])
 	},
 }
+
+func performReceive(
+	ctx context.Context,
+	scli *rpc.JSONRPCClient,
+	dcli *rpc.JSONRPCClient,
+	dtcli *trpc.JSONRPCClient,
+	sendTxID ids.ID,
+	actor crypto.PublicKey,
+	factory chain.AuthFactory,
+) error {
+	// Select TxID (if not provided)
+	var err error
+	if sendTxID == ids.Empty {
+		sendTxID, err = promptID("send txID")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Generate warp signature (as long as >= 80% stake)
+	msg, subnetWeight, sigWeight, err := aggregateWarpSignature(ctx, scli, sendTxID)
+	if msg == nil || err != nil {
+		return err
+	}
+	wmsg, err := actions.UnmarshalWarpMessage(msg.UnsignedMessage.Payload)
+	if err != nil {
+		return err
+	}
+	hutils.Outf(
+		"%s {{yellow}}sender:{{/}} %s {{yellow}}recipient:{{/}} %s {{yellow}}payload:{{/}} %d bytes\n",
+		hutils.ToID(
+			msg.UnsignedMessage.Payload,
+		),
+		utils.Address(wmsg.Sender),
+		utils.Address(wmsg.Recipient),
+		len(wmsg.Payload),
+	)
+	hutils.Outf(
+		"{{yellow}}signature weight:{{/}} %d {{yellow}}total weight:{{/}} %d\n",
+		sigWeight,
+		subnetWeight,
+	)
+
+	// Attempt to send dummy transaction if needed
+	if err := submitDummy(ctx, dcli, dtcli, actor, factory); err != nil {
+		return err
+	}
+
+	// Generate transaction
+	parser, err := dtcli.Parser(ctx)
+	if err != nil {
+		return err
+	}
+	submit, tx, _, err := dcli.GenerateTransaction(ctx, parser, msg, &actions.ReceiveMessage{}, factory)
+	if err != nil {
+		return err
+	}
+	if err := submit(ctx); err != nil {
+		return err
+	}
+	success, err := dtcli.WaitForTransaction(ctx, tx.ID())
+	if err != nil {
+		return err
+	}
+	printStatus(tx.ID(), success)
+	return nil
+}
+
+var sendMessageCmd = &cobra.Command{
+	Use: "send-message",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		currentChainID, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Add payload to message
+		promptText := promptui.Prompt{
+			Label: "payload",
+			Validate: func(input string) error {
+				if len(input) == 0 {
+					return ErrInputEmpty
+				}
+				if len(input) > actions.MaxMessageSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		payload, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+
+		// Determine destination
+		destination, _, err := promptChain("destination", set.Set[ids.ID]{currentChainID: {}})
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Attempt to send dummy transaction if needed
+		if err := submitDummy(ctx, cli, tcli, actor, factory); err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.SendMessage{
+			Recipient:   recipient,
+			Payload:     []byte(payload),
+			Destination: destination,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+
+		// Perform receive
+		recv, err := promptBool("perform receive on destination")
+		if err != nil {
+			return err
+		}
+		if !recv {
+			return nil
+		}
+		uris, err := GetChain(destination)
+		if err != nil {
+			return err
+		}
+		return performReceive(ctx, cli, rpc.NewJSONRPCClient(uris[0]), trpc.NewJSONRPCClient(uris[0], destination), tx.ID(), actor, factory)
+	},
+}
+
+var receiveMessageCmd = &cobra.Command{
+	Use: "receive-message",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		currentChainID, actor, factory, dcli, dtcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select source
+		_, uris, err := promptChain("sourceChainID", set.Set[ids.ID]{currentChainID: {}})
+		if err != nil {
+			return err
+		}
+		scli := rpc.NewJSONRPCClient(uris[0])
+
+		// Perform receive
+		return performReceive(ctx, scli, dcli, dtcli, ids.Empty, actor, factory)
+	},
+}
+
+var vestCmd = &cobra.Command{
+	Use: "vest",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to lock
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select beneficiary
+		beneficiary, err := promptAddress("beneficiary")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount("amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select schedule
+		start, err := promptTime("start (unix timestamp)")
+		if err != nil {
+			return err
+		}
+		cliff, err := promptTime("cliff (seconds after start)")
+		if err != nil {
+			return err
+		}
+		duration, err := promptTime("duration (seconds after start)")
+		if err != nil {
+			return err
+		}
+		if !actions.ValidVestingSchedule(start, cliff, duration) {
+			return actions.ErrInvalidVestingSchedule
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateVesting{
+			Asset:       assetID,
+			Beneficiary: beneficiary,
+			Amount:      amount,
+			Start:       start,
+			Cliff:       cliff,
+			Duration:    duration,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if success {
+			hutils.Outf("{{yellow}}vestingID:{{/}} %s\n", tx.ID())
+		}
+		return nil
+	},
+}
+
+var claimVestedCmd = &cobra.Command{
+	Use: "claim-vested",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select vesting schedule
+		vestingID, err := promptID("vestingID")
+		if err != nil {
+			return err
+		}
+		vesting, err := tcli.Vesting(ctx, vestingID)
+		if err != nil {
+			return err
+		}
+		if vesting == nil {
+			hutils.Outf("{{red}}vesting %s does not exist{{/}}\n", vestingID)
+			return nil
+		}
+		vested := actions.VestedAmount(
+			vesting.Amount,
+			vesting.Start,
+			vesting.Cliff,
+			vesting.Duration,
+			time.Now().Unix(),
+		)
+		var claimable uint64
+		if vested > vesting.Claimed {
+			claimable = vested - vesting.Claimed
+		}
+		hutils.Outf(
+			"{{yellow}}beneficiary:{{/}} %s {{yellow}}claimed:{{/}} %s/%s %s {{yellow}}claimable:{{/}} %s\n",
+			vesting.Beneficiary,
+			valueString(vesting.Asset, vesting.Claimed),
+			valueString(vesting.Asset, vesting.Amount),
+			assetString(vesting.Asset),
+			valueString(vesting.Asset, claimable),
+		)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.ClaimVested{
+			Vesting: vestingID,
+			Asset:   vesting.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var proposeCmd = &cobra.Command{
+	Use: "propose",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select param
+		for i, name := range actions.ParamNames {
+			hutils.Outf("%d) {{cyan}}%s{{/}}\n", i, name)
+		}
+		choice, err := promptChoice("param", len(actions.ParamNames))
+		if err != nil {
+			return err
+		}
+		param := uint8(choice)
+
+		// Select value
+		value, err := promptUint64("value", func(input uint64) error {
+			if !actions.ValidParam(param, input) {
+				return actions.ErrInvalidParam
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Select pair
+		var inAssetID, outAssetID ids.ID
+		if actions.PairParam(param) {
+			inAssetID, err = promptAsset("in assetID", true)
+			if err != nil {
+				return err
+			}
+			outAssetID, err = promptAsset("out assetID", true)
+			if err != nil {
+				return err
+			}
+			if inAssetID == outAssetID {
+				return actions.ErrInvalidParam
+			}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.Propose{
+			Param: param,
+			Value: value,
+			In:    inAssetID,
+			Out:   outAssetID,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if success {
+			hutils.Outf("{{yellow}}proposalID:{{/}} %s\n", tx.ID())
+		}
+		return nil
+	},
+}
+
+var voteCmd = &cobra.Command{
+	Use: "vote",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select proposal
+		proposalID, err := promptID("proposalID")
+		if err != nil {
+			return err
+		}
+		proposal, err := getProposal(ctx, tcli, proposalID)
+		if proposal == nil || err != nil {
+			return err
+		}
+
+		// Select vote
+		support, err := promptBool("support")
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, ids.Empty, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+		weight, err := promptAmount("weight", ids.Empty, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.Vote{
+			Proposal: proposalID,
+			Support:  support,
+			Weight:   weight,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var executeProposalCmd = &cobra.Command{
+	Use: "execute-proposal",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select proposal
+		proposalID, err := promptID("proposalID")
+		if err != nil {
+			return err
+		}
+		proposal, err := getProposal(ctx, tcli, proposalID)
+		if proposal == nil || err != nil {
+			return err
+		}
+		param, ok := actions.ParamFromName(proposal.Param)
+		if !ok {
+			return actions.ErrInvalidParam
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.ExecuteProposal{
+			Proposal: proposalID,
+			Param:    param,
+			In:       proposal.In,
+			Out:      proposal.Out,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var withdrawVoteCmd = &cobra.Command{
+	Use: "withdraw-vote",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select proposal
+		proposalID, err := promptID("proposalID")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.WithdrawVote{
+			Proposal: proposalID,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var reclaimDepositCmd = &cobra.Command{
+	Use: "reclaim-deposit",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select proposal
+		proposalID, err := promptID("proposalID")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.ReclaimDeposit{
+			Proposal: proposalID,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var signSwapOfferCmd = &cobra.Command{
+	Use: "sign-swap-offer",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		actor, priv, _, err := GetDefaultKey()
+		if err != nil {
+			return err
+		}
+		if priv == crypto.EmptyPrivateKey {
+			// Offers are verified with ed25519 signatures
+			return ErrUnsupportedKey
+		}
+		chainID, uris, err := GetDefaultChain()
+		if err != nil {
+			return err
+		}
+		tcli := trpc.NewJSONRPCClient(uris[0], chainID)
+
+		// Select token to give
+		giveAssetID, err := promptAsset("give assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, giveAssetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+		giveAmount, err := promptAmount("give amount", giveAssetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select token to receive
+		wantAssetID, err := promptAsset("want assetID", true)
+		if err != nil {
+			return err
+		}
+		if wantAssetID == giveAssetID {
+			return ErrSameAsset
+		}
+		if _, _, err := getAssetInfo(ctx, tcli, actor, wantAssetID, false); err != nil {
+			return err
+		}
+		wantAmount, err := promptAmount("want amount", wantAssetID, consts.MaxUint64, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select expiry
+		expiry, err := promptTime("expiry (unix timestamp)")
+		if err != nil {
+			return err
+		}
+
+		// Select taker
+		restricted, err := promptBool("only allow one taker")
+		if err != nil {
+			return err
+		}
+		taker := crypto.EmptyPublicKey
+		if restricted {
+			taker, err = promptAddress("taker")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Sign offer
+		offer := &actions.SwapOffer{
+			Give:       giveAssetID,
+			GiveAmount: giveAmount,
+			Want:       wantAssetID,
+			WantAmount: wantAmount,
+			Expiry:     expiry,
+			Nonce:      uint64(time.Now().UnixNano()),
+			Taker:      taker,
+		}
+		offer.Sign(chainID, priv)
+		p := codec.NewWriter(int(offer.MaxUnits(nil)))
+		offer.Marshal(p)
+		if err := p.Err(); err != nil {
+			return err
+		}
+		hutils.Outf("{{yellow}}nonce:{{/}} %d\n", offer.Nonce)
+		hutils.Outf("{{yellow}}offer:{{/}} %s\n", hex.EncodeToString(p.Bytes()))
+		return nil
+	},
+}
+
+var swapOfferCmd = &cobra.Command{
+	Use: "swap-offer",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select offer
+		rawOffer, err := promptString("offer")
+		if err != nil {
+			return err
+		}
+		offerBytes, err := hex.DecodeString(rawOffer)
+		if err != nil {
+			return err
+		}
+		action, err := actions.UnmarshalSwapOffer(codec.NewReader(offerBytes, len(offerBytes)), nil)
+		if err != nil {
+			return err
+		}
+		offer := action.(*actions.SwapOffer)
+		hutils.Outf(
+			"{{yellow}}maker:{{/}} %s {{yellow}}give:{{/}} %s %s {{yellow}}want:{{/}} %s %s {{yellow}}expiry:{{/}} %d\n",
+			utils.Address(offer.Maker),
+			valueString(offer.Give, offer.GiveAmount),
+			assetString(offer.Give),
+			valueString(offer.Want, offer.WantAmount),
+			assetString(offer.Want),
+			offer.Expiry,
+		)
+		if offer.Taker != crypto.EmptyPublicKey && offer.Taker != actor {
+			return ErrWrongTaker
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, offer.Want, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+		if balance < offer.WantAmount {
+			return ErrInsufficientBalance
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, offer, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var setSponsorRateCmd = &cobra.Command{
+	Use: "set-sponsor-rate",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to accept
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		if _, _, err := getAssetInfo(ctx, tcli, actor, assetID, false); err != nil {
+			return err
+		}
+		rate, err := tcli.SponsorRate(ctx, utils.Address(actor), assetID)
+		if err != nil {
+			return err
+		}
+		hutils.Outf("{{yellow}}current rate:{{/}} %d\n", rate)
+
+		// Select rate
+		rate, err = promptUint64("rate (per whole native token, 0 to stop)", nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.SetSponsorRate{
+			Asset: assetID,
+			Rate:  rate,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+// sessionActions are the actions a session key can be allowed to perform with
+// register-session.
+var sessionActions = []struct {
+	name   string
+	action chain.Action
+}{
+	{actions.TransferName, &actions.Transfer{}},
+	{actions.CreateOrderName, &actions.CreateOrder{}},
+	{actions.FillOrderName, &actions.FillOrder{}},
+	{actions.CloseOrderName, &actions.CloseOrder{}},
+	{actions.SwapOfferName, &actions.SwapOffer{}},
+}
+
+var registerSessionCmd = &cobra.Command{
+	Use: "register-session",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select session key
+		key, err := promptAddress("session key")
+		if err != nil {
+			return err
+		}
+		expiry, err := promptTime("expiry (unix)")
+		if err != nil {
+			return err
+		}
+
+		// Select allowed actions
+		for i, s := range sessionActions {
+			hutils.Outf("%d) {{cyan}}%s{{/}}\n", i, s.name)
+		}
+		allowed := []uint8{}
+		seen := set.NewSet[uint8](len(sessionActions))
+		for {
+			choice, err := promptChoice("allow action", len(sessionActions))
+			if err != nil {
+				return err
+			}
+			typeID, _, _, ok := tconsts.ActionRegistry.LookupType(sessionActions[choice].action)
+			if !ok {
+				return ErrInvalidChoice
+			}
+			if seen.Contains(typeID) {
+				return ErrDuplicate
+			}
+			seen.Add(typeID)
+			allowed = append(allowed, typeID)
+			more, err := promptBool("allow another action")
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+		}
+
+		// Select spending limits
+		limits := []*actions.SessionLimit{}
+		assets := set.NewSet[ids.ID](0)
+		for {
+			assetID, err := promptAsset("limit assetID", true)
+			if err != nil {
+				return err
+			}
+			if assets.Contains(assetID) {
+				return ErrDuplicate
+			}
+			amount, err := promptAmount("limit", assetID, consts.MaxUint64, nil)
+			if err != nil {
+				return err
+			}
+			assets.Add(assetID)
+			limits = append(limits, &actions.SessionLimit{Asset: assetID, Amount: amount})
+			more, err := promptBool("add another limit")
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.RegisterSession{
+			Key:     key,
+			Expiry:  expiry,
+			Actions: allowed,
+			Limits:  limits,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var revokeSessionCmd = &cobra.Command{
+	Use: "revoke-session",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select session key
+		key, err := promptAddress("session key")
+		if err != nil {
+			return err
+		}
+		s, err := tcli.Session(ctx, utils.Address(actor), utils.Address(key))
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return ErrNoSession
+		}
+		hutils.Outf("{{yellow}}expiry:{{/}} %d\n", s.Expiry)
+		for _, limit := range s.Limits {
+			hutils.Outf("{{yellow}}remaining:{{/}} %s\n", valueString(limit.Asset, limit.Amount))
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.RevokeSession{
+			Key: key,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var setGuardiansCmd = &cobra.Command{
+	Use: "set-guardians",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select guardians
+		guardians := []crypto.PublicKey{}
+		seen := set.NewSet[crypto.PublicKey](0)
+		for {
+			guardian, err := promptAddress("guardian")
+			if err != nil {
+				return err
+			}
+			if seen.Contains(guardian) {
+				return ErrDuplicate
+			}
+			seen.Add(guardian)
+			guardians = append(guardians, guardian)
+			more, err := promptBool("add another guardian")
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+		}
+		threshold, err := promptChoice("threshold", len(guardians)+1)
+		if err != nil {
+			return err
+		}
+		if threshold == 0 {
+			return ErrInvalidChoice
+		}
+		delay, err := promptTime("delay (seconds)")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.SetGuardians{
+			Guardians: guardians,
+			Threshold: uint8(threshold),
+			Delay:     delay,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var signRecoveryCmd = &cobra.Command{
+	Use: "sign-recovery",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, _, err := GetDefaultKey()
+		if err != nil {
+			return err
+		}
+		if priv == crypto.EmptyPrivateKey {
+			// Approvals are verified with ed25519 signatures
+			return ErrUnsupportedKey
+		}
+		chainID, uris, err := GetDefaultChain()
+		if err != nil {
+			return err
+		}
+		tcli := trpc.NewJSONRPCClient(uris[0], chainID)
+
+		// Select account and new key
+		account, err := promptAddress("account")
+		if err != nil {
+			return err
+		}
+		key, err := promptAddress("new key")
+		if err != nil {
+			return err
+		}
+		recovery, err := tcli.Recovery(ctx, utils.Address(account))
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}threshold:{{/}} %d of %d {{yellow}}delay:{{/}} %ds {{yellow}}nonce:{{/}} %d\n",
+			recovery.Threshold,
+			len(recovery.Guardians),
+			recovery.Delay,
+			recovery.Nonce,
+		)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Sign approval
+		sig := crypto.Sign(actions.RecoveryDigest(chainID, account, key, recovery.Nonce), priv)
+		hutils.Outf("{{yellow}}approval:{{/}} %s\n", hex.EncodeToString(sig[:]))
+		return nil
+	},
+}
+
+var initiateRecoveryCmd = &cobra.Command{
+	Use: "initiate-recovery",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		chainID, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select account and new key
+		account, err := promptAddress("account")
+		if err != nil {
+			return err
+		}
+		key, err := promptAddress("new key")
+		if err != nil {
+			return err
+		}
+		recovery, err := tcli.Recovery(ctx, utils.Address(account))
+		if err != nil {
+			return err
+		}
+		if recovery.Threshold == 0 {
+			return ErrNoGuardians
+		}
+
+		// Collect approvals
+		approvals := make([]*actions.RecoveryApproval, recovery.Threshold)
+		for i := range approvals {
+			guardian, err := promptAddress("guardian")
+			if err != nil {
+				return err
+			}
+			rawSig, err := promptString("approval")
+			if err != nil {
+				return err
+			}
+			sigBytes, err := hex.DecodeString(rawSig)
+			if err != nil {
+				return err
+			}
+			if len(sigBytes) != crypto.SignatureLen {
+				return ErrInvalidSignature
+			}
+			approval := &actions.RecoveryApproval{Guardian: guardian}
+			copy(approval.Signature[:], sigBytes)
+			if !crypto.Verify(actions.RecoveryDigest(chainID, account, key, recovery.Nonce), guardian, approval.Signature) {
+				return ErrInvalidSignature
+			}
+			approvals[i] = approval
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.InitiateRecovery{
+			Account:   account,
+			Key:       key,
+			Approvals: approvals,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var cancelRecoveryCmd = &cobra.Command{
+	Use: "cancel-recovery",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+		recovery, err := tcli.Recovery(ctx, utils.Address(actor))
+		if err != nil {
+			return err
+		}
+		if !recovery.Pending {
+			return ErrNoRecovery
+		}
+		hutils.Outf("{{yellow}}new key:{{/}} %s {{yellow}}ready:{{/}} %d\n", recovery.NewKey, recovery.Ready)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CancelRecovery{}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var completeRecoveryCmd = &cobra.Command{
+	Use: "complete-recovery",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select account
+		account, err := promptAddress("account")
+		if err != nil {
+			return err
+		}
+		recovery, err := tcli.Recovery(ctx, utils.Address(account))
+		if err != nil {
+			return err
+		}
+		if !recovery.Pending {
+			return ErrNoRecovery
+		}
+		hutils.Outf("{{yellow}}new key:{{/}} %s {{yellow}}ready:{{/}} %d\n", recovery.NewKey, recovery.Ready)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CompleteRecovery{
+			Account: account,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var registerNameCmd = &cobra.Command{
+	Use: "register-name",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select name
+		name, err := promptName("name")
+		if err != nil {
+			return err
+		}
+		record, err := tcli.Resolve(ctx, name)
+		if err != nil {
+			return err
+		}
+		if record != nil && record.Expiry > time.Now().Unix() {
+			hutils.Outf("{{red}}name is owned by %s until %d{{/}}\n", record.Owner, record.Expiry)
+			return nil
+		}
+		address, asset, err := promptNameTarget()
+		if err != nil {
+			return err
+		}
+		g, err := tcli.Genesis(ctx)
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}fee:{{/}} %s {{yellow}}period:{{/}} %ds\n",
+			valueString(ids.Empty, g.NameFee),
+			g.NamePeriod,
+		)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.RegisterName{
+			Name:    name,
+			Address: address,
+			Asset:   asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var updateNameCmd = &cobra.Command{
+	Use: "update-name",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select name
+		name, err := promptName("name")
+		if err != nil {
+			return err
+		}
+		record, err := tcli.Resolve(ctx, name)
+		if err != nil {
+			return err
+		}
+		if record == nil || record.Expiry <= time.Now().Unix() {
+			return ErrNameNotFound
+		}
+		if record.Owner != utils.Address(actor) {
+			hutils.Outf("{{red}}name is owned by %s{{/}}\n", record.Owner)
+			return nil
+		}
+		address, asset, err := promptNameTarget()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.UpdateName{
+			Name:    name,
+			Address: address,
+			Asset:   asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var transferNameCmd = &cobra.Command{
+	Use: "transfer-name",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select name
+		name, err := promptName("name")
+		if err != nil {
+			return err
+		}
+		record, err := tcli.Resolve(ctx, name)
+		if err != nil {
+			return err
+		}
+		if record == nil || record.Expiry <= time.Now().Unix() {
+			return ErrNameNotFound
+		}
+		if record.Owner != utils.Address(actor) {
+			hutils.Outf("{{red}}name is owned by %s{{/}}\n", record.Owner)
+			return nil
+		}
+		to, err := promptAddress("new owner")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.TransferNameOwnership{
+			Name: name,
+			To:   to,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var renewNameCmd = &cobra.Command{
+	Use: "renew-name",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select name
+		name, err := promptName("name")
+		if err != nil {
+			return err
+		}
+		record, err := tcli.Resolve(ctx, name)
+		if err != nil {
+			return err
+		}
+		if record == nil || record.Expiry <= time.Now().Unix() {
+			return ErrNameNotFound
+		}
+		g, err := tcli.Genesis(ctx)
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}owner:{{/}} %s {{yellow}}expiry:{{/}} %d {{yellow}}new expiry:{{/}} %d\n",
+			record.Owner,
+			record.Expiry,
+			record.Expiry+g.NamePeriod,
+		)
+		hutils.Outf("{{yellow}}fee:{{/}} %s\n", valueString(ids.Empty, g.NameFee))
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.RenewName{
+			Name: name,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createCollectionCmd = &cobra.Command{
+	Use: "create-collection",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Add metadata to collection
+		promptText := promptui.Prompt{
+			Label: "metadata",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxMetadataSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		metadata, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateCollection{
+			Metadata: []byte(metadata),
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var mintNFTCmd = &cobra.Command{
+	Use: "mint-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select collection
+		collection, err := promptID("collectionID")
+		if err != nil {
+			return err
+		}
+		info, err := tcli.Collection(ctx, collection)
+		if err != nil {
+			return err
+		}
+		if info == nil {
+			return ErrCollectionNotFound
+		}
+		if info.Owner != utils.Address(actor) {
+			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", info.Owner, collection)
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %d\n",
+			string(info.Metadata),
+			info.Supply,
+		)
+
+		// Select NFT
+		id, err := promptUint64("token id", nil)
+		if err != nil {
+			return err
+		}
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+		promptText := promptui.Prompt{
+			Label: "metadata",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxMetadataSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		metadata, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.MintNFT{
+			Collection: collection,
+			ID:         id,
+			To:         recipient,
+			Metadata:   []byte(metadata),
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if success {
+			hutils.Outf("{{yellow}}assetID:{{/}} %s\n", actions.NFTID(collection, id))
+		}
+		return nil
+	},
+}
+
+var transferNFTCmd = &cobra.Command{
+	Use: "transfer-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select NFT
+		nft, err := promptNFT(ctx, tcli)
+		if nft == nil || err != nil {
+			return err
+		}
+		if nft.Owner != utils.Address(actor) {
+			hutils.Outf("{{red}}nft is owned by %s{{/}}\n", nft.Owner)
+			return nil
+		}
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.TransferNFT{
+			Collection: nft.Collection,
+			ID:         nft.ID,
+			To:         recipient,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var burnNFTCmd = &cobra.Command{
+	Use: "burn-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select NFT
+		nft, err := promptNFT(ctx, tcli)
+		if nft == nil || err != nil {
+			return err
+		}
+		if nft.Owner != utils.Address(actor) {
+			hutils.Outf("{{red}}nft is owned by %s{{/}}\n", nft.Owner)
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.BurnNFT{
+			Collection: nft.Collection,
+			ID:         nft.ID,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createStreamCmd = &cobra.Command{
+	Use: "create-stream",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to stream
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount("amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select schedule
+		start, err := promptTime("start (unix timestamp)")
+		if err != nil {
+			return err
+		}
+		end, err := promptTime("end (unix timestamp)")
+		if err != nil {
+			return err
+		}
+		if !actions.ValidStream(start, end) {
+			return actions.ErrInvalidStream
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateStream{
+			Asset:     assetID,
+			Recipient: recipient,
+			Amount:    amount,
+			Start:     start,
+			End:       end,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if success {
+			hutils.Outf("{{yellow}}streamID:{{/}} %s\n", tx.ID())
+		}
+		return nil
+	},
+}
+
+var withdrawStreamCmd = &cobra.Command{
+	Use: "withdraw-stream",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select stream
+		streamID, stream, err := promptStream(ctx, tcli)
+		if stream == nil || err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.WithdrawStream{
+			Stream: streamID,
+			Asset:  stream.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var cancelStreamCmd = &cobra.Command{
+	Use: "cancel-stream",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select stream
+		streamID, stream, err := promptStream(ctx, tcli)
+		if stream == nil || err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CancelStream{
+			Stream: streamID,
+			Asset:  stream.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createAirdropCmd = &cobra.Command{
+	Use: "create-airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to airdrop
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Load airdrop built with "token-cli airdrop build"
+		path, err := promptString("airdrop file")
+		if err != nil {
+			return err
+		}
+		airdrop, err := readAirdropFile(path)
+		if err != nil {
+			return err
+		}
+		if airdrop.Amount > balance {
+			return ErrInsufficientBalance
+		}
+		hutils.Outf(
+			"{{yellow}}root:{{/}} %s {{yellow}}recipients:{{/}} %d {{yellow}}amount:{{/}} %s %s\n",
+			airdrop.Root,
+			len(airdrop.Claims),
+			valueString(assetID, airdrop.Amount),
+			assetString(assetID),
+		)
+		expiry, err := promptTime("expiry (unix timestamp)")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateAirdrop{
+			Asset:  assetID,
+			Amount: airdrop.Amount,
+			Root:   airdrop.Root,
+			Expiry: expiry,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if success {
+			hutils.Outf("{{yellow}}airdropID:{{/}} %s\n", tx.ID())
+		}
+		return nil
+	},
+}
+
+var claimAirdropCmd = &cobra.Command{
+	Use: "claim-airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select airdrop
+		airdropID, err := promptID("airdropID")
+		if err != nil {
+			return err
+		}
+		airdrop, err := tcli.Airdrop(ctx, airdropID, utils.Address(actor))
+		if err != nil {
+			return err
+		}
+		if airdrop == nil {
+			hutils.Outf("{{red}}airdrop %s does not exist{{/}}\n", airdropID)
+			return nil
+		}
+		if airdrop.Claimed {
+			hutils.Outf("{{red}}already claimed from airdrop %s{{/}}\n", airdropID)
+			return nil
+		}
+
+		// Find proof of actor
+		path, err := promptString("airdrop file")
+		if err != nil {
+			return err
+		}
+		f, err := readAirdropFile(path)
+		if err != nil {
+			return err
+		}
+		if f.Root != airdrop.Root {
+			hutils.Outf("{{red}}airdrop file does not match root %s{{/}}\n", airdrop.Root)
+			return nil
+		}
+		claim, ok := f.Claims[utils.Address(actor)]
+		if !ok {
+			return ErrNotInAirdrop
+		}
+		hutils.Outf(
+			"{{yellow}}amount:{{/}} %s %s {{yellow}}expiry:{{/}} %d\n",
+			valueString(airdrop.Asset, claim.Amount),
+			assetString(airdrop.Asset),
+			airdrop.Expiry,
+		)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.ClaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   airdrop.Asset,
+			Amount:  claim.Amount,
+			Proof:   claim.Proof,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var reclaimAirdropCmd = &cobra.Command{
+	Use: "reclaim-airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select airdrop
+		airdropID, err := promptID("airdropID")
+		if err != nil {
+			return err
+		}
+		airdrop, err := tcli.Airdrop(ctx, airdropID, "")
+		if err != nil {
+			return err
+		}
+		if airdrop == nil {
+			hutils.Outf("{{red}}airdrop %s does not exist{{/}}\n", airdropID)
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}creator:{{/}} %s {{yellow}}remaining:{{/}} %s %s {{yellow}}expiry:{{/}} %d\n",
+			airdrop.Creator,
+			valueString(airdrop.Asset, airdrop.Remaining),
+			assetString(airdrop.Asset),
+			airdrop.Expiry,
+		)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.ReclaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   airdrop.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createEscrowCmd = &cobra.Command{
+	Use: "create-escrow",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to lock
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select parties
+		payee, err := promptAddress("payee")
+		if err != nil {
+			return err
+		}
+		arbiter, err := promptAddress("arbiter")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount("amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select timeout
+		deadline, err := promptTime("arbitration deadline (unix timestamp)")
+		if err != nil {
+			return err
+		}
+		releaseOnTimeout, err := promptBool("release to payee after deadline")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateEscrow{
+			Asset:            assetID,
+			Payee:            payee,
+			Arbiter:          arbiter,
+			Amount:           amount,
+			Deadline:         deadline,
+			ReleaseOnTimeout: releaseOnTimeout,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if success {
+			hutils.Outf("{{yellow}}escrowID:{{/}} %s\n", tx.ID())
+		}
+		return nil
+	},
+}
+
+var resolveEscrowCmd = &cobra.Command{
+	Use: "resolve-escrow",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select escrow
+		escrowID, err := promptID("escrowID")
+		if err != nil {
+			return err
+		}
+		escrow, err := tcli.Escrow(ctx, escrowID)
+		if err != nil {
+			return err
+		}
+		if escrow == nil {
+			hutils.Outf("{{red}}escrow %s does not exist{{/}}\n", escrowID)
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}amount:{{/}} %s %s {{yellow}}arbiter:{{/}} %s {{yellow}}deadline:{{/}} %d {{yellow}}release on timeout:{{/}} %t\n",
+			valueString(escrow.Asset, escrow.Amount),
+			assetString(escrow.Asset),
+			escrow.Arbiter,
+			escrow.Deadline,
+			escrow.ReleaseOnTimeout,
+		)
+
+		// Select outcome
+		parties := []string{escrow.Payee, escrow.Payer}
+		hutils.Outf("0) {{cyan}}release to payee:{{/}} %s\n", escrow.Payee)
+		hutils.Outf("1) {{cyan}}refund to payer:{{/}} %s\n", escrow.Payer)
+		choice, err := promptChoice("outcome", len(parties))
+		if err != nil {
+			return err
+		}
+		recipient, err := utils.ParseAddress(parties[choice])
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     escrow.Asset,
+			Recipient: recipient,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createFeedCmd = &cobra.Command{
+	Use: "create-feed",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select decimals
+		decimals, err := promptUint64("decimals", func(input uint64) error {
+			if input > actions.MaxFeedDecimals {
+				return errors.New("too many decimals")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Add metadata to feed
+		promptText := promptui.Prompt{
+			Label: "metadata",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxMetadataSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		metadata, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateFeed{
+			Decimals: uint8(decimals),
+			Metadata: []byte(metadata),
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var updateFeedCmd = &cobra.Command{
+	Use: "update-feed",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select feed
+		feedID, err := promptID("feedID")
+		if err != nil {
+			return err
+		}
+		feed, err := tcli.Feed(ctx, feedID)
+		if err != nil {
+			return err
+		}
+		if feed == nil {
+			hutils.Outf("{{red}}feed %s does not exist{{/}}\n", feedID)
+			return nil
+		}
+		if feed.Owner != utils.Address(actor) {
+			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", feed.Owner, feedID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}metadata:{{/}} %s {{yellow}}decimals:{{/}} %d {{yellow}}price:{{/}} %d {{yellow}}timestamp:{{/}} %d\n",
+			string(feed.Metadata),
+			feed.Decimals,
+			feed.Price,
+			feed.Timestamp,
+		)
+
+		// Select price
+		price, err := promptUint64("price", func(input uint64) error {
+			if input == 0 {
+				return errors.New("price is zero")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.UpdateFeed{
+			Feed:  feedID,
+			Price: price,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createTriggerOrderCmd = &cobra.Command{
+	Use: "create-trigger-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select inbound token
+		inAssetID, err := promptAsset("in assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, tcli, actor, inAssetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select outbound token
+		outAssetID, err := promptAsset("out assetID", true)
+		if err != nil {
+			return err
+		}
+		if _, _, err := getAssetInfo(ctx, tcli, actor, outAssetID, false); err != nil {
+			return err
+		}
+
+		// Select price source
+		useFeed, err := promptBool("use price feed")
+		if err != nil {
+			return err
+		}
+		var (
+			feedID     ids.ID
+			triggerIn  uint64
+			triggerOut uint64
+		)
+		if useFeed {
+			feedID, err = promptID("feedID")
+			if err != nil {
+				return err
+			}
+			feed, err := tcli.Feed(ctx, feedID)
+			if err != nil {
+				return err
+			}
+			if feed == nil {
+				hutils.Outf("{{red}}feed %s does not exist{{/}}\n", feedID)
+				return nil
+			}
+			hutils.Outf(
+				"{{yellow}}metadata:{{/}} %s {{yellow}}decimals:{{/}} %d {{yellow}}price:{{/}} %d\n",
+				string(feed.Metadata),
+				feed.Decimals,
+				feed.Price,
+			)
+			triggerIn, err = promptUint64("trigger price", func(input uint64) error {
+				if input == 0 {
+					return errors.New("price is zero")
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			triggerOut = 1
+		} else {
+			exists, lastIn, lastOut, err := tcli.LastTrade(ctx, inAssetID, outAssetID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				hutils.Outf(
+					"{{yellow}}last trade:{{/}} %s %s for %s %s\n",
+					valueString(inAssetID, lastIn),
+					assetString(inAssetID),
+					valueString(outAssetID, lastOut),
+					assetString(outAssetID),
+				)
+			}
+			triggerIn, err = promptAmount("trigger in", inAssetID, consts.MaxUint64, nil)
+			if err != nil {
+				return err
+			}
+			triggerOut, err = promptAmount("trigger out", outAssetID, consts.MaxUint64, nil)
+			if err != nil {
+				return err
+			}
+		}
+		above, err := promptBool("trigger at or above price")
+		if err != nil {
+			return err
+		}
+
+		// Select value to trade
+		value, err := promptAmount("value", inAssetID, balance, nil)
+		if err != nil {
+			return err
+		}
+		reward, err := promptAmount("keeper reward", inAssetID, balance-value, nil)
+		if err != nil {
+			return err
+		}
+		minOut, err := promptAmount("min out", outAssetID, consts.MaxUint64, func(input uint64) error {
+			if input == 0 {
+				return errors.New("min out is zero")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CreateTriggerOrder{
+			In:         inAssetID,
+			Out:        outAssetID,
+			Value:      value,
+			Reward:     reward,
+			MinOut:     minOut,
+			Feed:       feedID,
+			TriggerIn:  triggerIn,
+			TriggerOut: triggerOut,
+			Above:      above,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var triggerOrderCmd = &cobra.Command{
+	Use: "trigger-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select trigger order
+		trigger, err := promptTriggerOrder(ctx, tcli, "")
+		if trigger == nil || err != nil {
+			return err
+		}
+		owner, err := utils.ParseAddress(trigger.Owner)
+		if err != nil {
+			return err
+		}
+
+		// View orders
+		orders, err := tcli.Orders(ctx, actions.PairID(trigger.In, trigger.Out))
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			hutils.Outf("{{red}}no available orders{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf("{{cyan}}available orders:{{/}} %d\n", len(orders))
+		max := 20
+		if len(orders) < max {
+			max = len(orders)
+		}
+		for i := 0; i < max; i++ {
+			order := orders[i]
+			hutils.Outf(
+				"%d) {{cyan}}Rate(in/out):{{/}} %.4f {{cyan}}InTick:{{/}} %s %s {{cyan}}OutTick:{{/}} %s %s {{cyan}}Remaining:{{/}} %s %s\n", //nolint:lll
+				i,
+				float64(order.InTick)/float64(order.OutTick),
+				valueString(trigger.In, order.InTick),
+				assetString(trigger.In),
+				valueString(trigger.Out, order.OutTick),
+				assetString(trigger.Out),
+				valueString(trigger.Out, order.Remaining),
+				assetString(trigger.Out),
+			)
+		}
+
+		// Select order
+		orderIndex, err := promptChoice("select order", max)
+		if err != nil {
+			return err
+		}
+		order := orders[orderIndex]
+		orderOwner, err := utils.ParseAddress(order.Owner)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.TriggerOrder{
+			Trigger:    trigger.ID,
+			Owner:      owner,
+			In:         trigger.In,
+			Out:        trigger.Out,
+			Feed:       trigger.Feed,
+			Order:      order.ID,
+			OrderOwner: orderOwner,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var cancelTriggerOrderCmd = &cobra.Command{
+	Use: "cancel-trigger-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, actor, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select trigger order
+		trigger, err := promptTriggerOrder(ctx, tcli, utils.Address(actor))
+		if trigger == nil || err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.CancelTriggerOrder{
+			Trigger: trigger.ID,
+			In:      trigger.In,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var settleFeesCmd = &cobra.Command{
+	Use: "settle-fees",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, tcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to settle
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+
+		// Lookup where fees are sent
+		g, err := tcli.Genesis(ctx)
+		if err != nil {
+			return err
+		}
+		treasury, err := g.TreasuryAddress()
+		if err != nil {
+			return err
+		}
+		recipient, err := g.FeeRecipientAddress()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		parser, err := tcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, parser, nil, &actions.SettleFees{
+			Asset:        assetID,
+			Treasury:     treasury,
+			FeeRecipient: recipient,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := tcli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
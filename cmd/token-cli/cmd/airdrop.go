@@ -0,0 +1,156 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/crypto"
+	hutils "github.com/ava-labs/hypersdk/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"tokenvm/actions"
+	"tokenvm/utils"
+)
+
+// AirdropClaim is the allocation of a single recipient of an airdrop.
+type AirdropClaim struct {
+	Amount uint64   `json:"amount"`
+	Proof  []ids.ID `json:"proof"`
+}
+
+// AirdropFile is written by [buildAirdropCmd] and contains everything needed
+// to create and claim from an airdrop.
+type AirdropFile struct {
+	Root   ids.ID                   `json:"root"`
+	Amount uint64                   `json:"amount"`
+	Claims map[string]*AirdropClaim `json:"claims"`
+}
+
+func readAirdropFile(path string) (*AirdropFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f AirdropFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+var airdropCmd = &cobra.Command{
+	Use: "airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var buildAirdropCmd = &cobra.Command{
+	Use:   "build [csv file] [options]",
+	Short: "Builds the Merkle root and proofs of an airdrop from address,amount rows",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = 2
+		r.TrimLeadingSpace = true
+
+		var (
+			recipients = []crypto.PublicKey{}
+			amounts    = []uint64{}
+			seen       = set.Set[crypto.PublicKey]{}
+			total      uint64
+		)
+		for row := 1; ; row++ {
+			record, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			amount, err := strconv.ParseUint(strings.TrimSpace(record[1]), 10, 64)
+			if err != nil {
+				// Allow the file to start with a header
+				if row == 1 {
+					continue
+				}
+				return fmt.Errorf("%w: row %d", err, row)
+			}
+			recipient, err := utils.ParseAddress(strings.TrimSpace(record[0]))
+			if err != nil {
+				return fmt.Errorf("%w: row %d", err, row)
+			}
+			if seen.Contains(recipient) {
+				return fmt.Errorf("%w: row %d", ErrDuplicate, row)
+			}
+			if amount == 0 {
+				return fmt.Errorf("%w: row %d", ErrInputEmpty, row)
+			}
+			seen.Add(recipient)
+			total, err = smath.Add64(total, amount)
+			if err != nil {
+				return err
+			}
+			recipients = append(recipients, recipient)
+			amounts = append(amounts, amount)
+		}
+
+		leaves := make([]ids.ID, len(recipients))
+		for i, recipient := range recipients {
+			leaves[i] = actions.AirdropLeaf(recipient, amounts[i])
+		}
+		root, proofs, err := actions.BuildAirdropTree(leaves)
+		if err != nil {
+			return err
+		}
+		out := &AirdropFile{
+			Root:   root,
+			Amount: total,
+			Claims: make(map[string]*AirdropClaim, len(recipients)),
+		}
+		for i, recipient := range recipients {
+			out.Claims[utils.Address(recipient)] = &AirdropClaim{
+				Amount: amounts[i],
+				Proof:  proofs[i],
+			}
+		}
+		b, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(airdropFile, b, fsModeWrite); err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}root:{{/}} %s {{yellow}}recipients:{{/}} %d {{yellow}}amount:{{/}} %d\n",
+			root,
+			len(recipients),
+			total,
+		)
+		color.Green("saved proofs to %s", airdropFile)
+		return nil
+	},
+}
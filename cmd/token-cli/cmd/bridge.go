@@ -0,0 +1,140 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//nolint:lll
+package cmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	hutils "github.com/ava-labs/hypersdk/utils"
+	"github.com/spf13/cobra"
+
+	"tokenvm/actions"
+	trpc "tokenvm/rpc"
+	"tokenvm/storage"
+)
+
+var bridgeCmd = &cobra.Command{
+	Use: "bridge",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var auditBridgeCmd = &cobra.Command{
+	Use: "audit",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		chains, err := GetChains()
+		if err != nil {
+			return err
+		}
+		if len(chains) < 2 {
+			return ErrInsufficientChains
+		}
+
+		// Select asset to audit (must be native to the source chain)
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+
+		// Compare the loans on each chain with the wrapped supply on its peers
+		clis := make(map[ids.ID]*trpc.JSONRPCClient, len(chains))
+		for chainID, uris := range chains {
+			clis[chainID] = trpc.NewJSONRPCClient(uris[0], chainID)
+		}
+		var mismatches int
+		for sourceChainID, scli := range clis {
+			loans, complete, err := scli.BridgeState(ctx, assetID)
+			if err != nil {
+				return err
+			}
+			exists, _, supply, _, _, _, err := scli.Asset(ctx, assetID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				hutils.Outf("{{yellow}}source:{{/}} %s {{yellow}}asset:{{/}} %s (missing, skipping)\n", sourceChainID, assetString(assetID))
+				continue
+			}
+			hutils.Outf(
+				"{{cyan}}source:{{/}} %s {{cyan}}asset:{{/}} %s {{cyan}}supply:{{/}} %s {{cyan}}destinations:{{/}} %d\n",
+				sourceChainID,
+				assetString(assetID),
+				valueString(assetID, supply),
+				len(loans),
+			)
+			var totalLoaned, totalWrapped uint64
+			for _, loan := range loans {
+				totalLoaned, err = smath.Add64(totalLoaned, loan.Amount)
+				if err != nil {
+					return err
+				}
+				dcli, ok := clis[loan.Destination]
+				if !ok {
+					hutils.Outf(
+						"❓ {{yellow}}destination:{{/}} %s {{yellow}}loan:{{/}} %s (unknown chain, skipping)\n",
+						loan.Destination,
+						valueString(assetID, loan.Amount),
+					)
+					continue
+				}
+				wrappedAssetID := actions.ImportedAssetID(assetID, sourceChainID)
+				_, _, wrappedSupply, _, _, _, err := dcli.Asset(ctx, wrappedAssetID)
+				if err != nil {
+					return err
+				}
+				totalWrapped, err = smath.Add64(totalWrapped, wrappedSupply)
+				if err != nil {
+					return err
+				}
+				status := "✅"
+				if wrappedSupply != loan.Amount {
+					status = "⚠️"
+					mismatches++
+				}
+				hutils.Outf(
+					"%s {{yellow}}destination:{{/}} %s {{yellow}}loan:{{/}} %s {{yellow}}wrapped supply (%s):{{/}} %s\n",
+					status,
+					loan.Destination,
+					valueString(assetID, loan.Amount),
+					wrappedAssetID,
+					valueString(assetID, wrappedSupply),
+				)
+			}
+
+			// Loans are locked on the source chain, so they can never exceed
+			// its total supply
+			status := "✅"
+			if totalLoaned > supply {
+				status = "⚠️"
+				mismatches++
+			}
+			hutils.Outf(
+				"%s {{yellow}}total loaned:{{/}} %s {{yellow}}total supply:{{/}} %s {{yellow}}total wrapped supply:{{/}} %s\n",
+				status,
+				valueString(assetID, totalLoaned),
+				valueString(assetID, supply),
+				valueString(assetID, totalWrapped),
+			)
+
+			// Loans to destinations that aren't tracked can't be audited
+			if !complete {
+				hutils.Outf("⚠️ {{yellow}}more than %d destinations, some loans were not audited{{/}}\n", storage.MaxLoanDestinations)
+				mismatches++
+			}
+		}
+		if mismatches > 0 {
+			// A mismatch is expected while an export is waiting to be imported on
+			// its destination.
+			hutils.Outf("{{red}}found %d mismatches (exports that have not been imported yet cause temporary mismatches){{/}}\n", mismatches)
+			return ErrAuditFailed
+		}
+		hutils.Outf("{{green}}all loans are backed by wrapped supply{{/}}\n")
+		return nil
+	},
+}
@@ -327,18 +327,32 @@ var watchChainCmd = &cobra.Command{
 							inAmtStr = utils.FormatBalance(or.In)
 							inStr = consts.Symbol
 						}
+						makerFeeStr := strconv.FormatUint(or.MakerFee, 10)
+						if action.In == ids.Empty {
+							makerFeeStr = utils.FormatBalance(or.MakerFee)
+						}
 						outAmtStr := strconv.FormatUint(or.Out, 10)
 						remainingStr := strconv.FormatUint(or.Remaining, 10)
+						takerFeeStr := strconv.FormatUint(or.TakerFee, 10)
+						rebateStr := strconv.FormatUint(or.MakerRebate, 10)
 						outStr := action.Out.String()
 						if action.Out == ids.Empty {
 							outAmtStr = utils.FormatBalance(or.Out)
 							remainingStr = utils.FormatBalance(or.Remaining)
+							takerFeeStr = utils.FormatBalance(or.TakerFee)
+							rebateStr = utils.FormatBalance(or.MakerRebate)
 							outStr = consts.Symbol
 						}
 						summaryStr = fmt.Sprintf(
 							"%s %s -> %s %s (remaining: %s %s)",
 							inAmtStr, inStr, outAmtStr, outStr, remainingStr, outStr,
 						)
+						if or.MakerFee > 0 || or.TakerFee > 0 {
+							summaryStr += fmt.Sprintf(
+								" | maker fee: %s %s taker fee: %s %s rebate: %s %s",
+								makerFeeStr, inStr, takerFeeStr, outStr, rebateStr, outStr,
+							)
+						}
 					case *actions.CloseOrder:
 						summaryStr = fmt.Sprintf("orderID: %s", action.Order)
 
@@ -378,8 +392,217 @@ var watchChainCmd = &cobra.Command{
 						if wt.SwapIn > 0 {
 							summaryStr += fmt.Sprintf(" | swap in: %s %s swap out: %s %s expiry: %d", valueString(outputAssetID, wt.SwapIn), assetString(outputAssetID), valueString(wt.AssetOut, wt.SwapOut), assetString(wt.AssetOut), wt.SwapExpiry)
 						}
+
+					case *actions.SendMessage:
+						summaryStr = fmt.Sprintf("destination: %s | %d bytes -> %s", action.Destination, len(action.Payload), tutils.Address(action.Recipient))
+					case *actions.ReceiveMessage:
+						wm := tx.WarpMessage
+						signers, _ := wm.Signature.NumSigners()
+						msg, _ := actions.UnmarshalWarpMessage(wm.Payload)
+						summaryStr = fmt.Sprintf("source: %s signers: %d | %s: %d bytes -> %s", wm.SourceChainID, signers, tutils.Address(msg.Sender), len(msg.Payload), tutils.Address(msg.Recipient))
+
+					case *actions.BatchExportAsset:
+						summaryStr = fmt.Sprintf("destination: %s | transfers: %d", action.Destination, len(action.Transfers))
+					case *actions.BatchImportAsset:
+						wm := tx.WarpMessage
+						signers, _ := wm.Signature.NumSigners()
+						wbt, _ := actions.UnmarshalWarpBatchTransfer(wm.Payload)
+						summaryStr = fmt.Sprintf("source: %s signers: %d | transfers: %d", wm.SourceChainID, signers, len(wbt.Transfers))
+					case *actions.SetWarpDestinations:
+						summaryStr = fmt.Sprintf("assetID: %s destinations: %d", action.Asset, len(action.Destinations))
+
+					case *actions.PauseAsset:
+						summaryStr = fmt.Sprintf("assetID: %s paused: %t", action.Asset, action.Paused)
+					case *actions.FreezeAccount:
+						summaryStr = fmt.Sprintf("assetID: %s account: %s frozen: %t", action.Asset, tutils.Address(action.Account), action.Frozen)
+					case *actions.ClawbackAsset:
+						summaryStr = fmt.Sprintf("%s %s <- %s", valueString(action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.From))
+
+					case *actions.SetAllowlistMode:
+						summaryStr = fmt.Sprintf("assetID: %s enabled: %t", action.Asset, action.Enabled)
+					case *actions.SetAllowlist:
+						summaryStr = fmt.Sprintf("assetID: %s account: %s allowed: %t", action.Asset, tutils.Address(action.Account), action.Allowed)
+
+					case *actions.CreateVesting:
+						summaryStr = fmt.Sprintf("%s %s -> %s start: %d cliff: %ds duration: %ds", valueString(action.Asset, action.Amount), assetString(action.Asset), tutils.Address(action.Beneficiary), action.Start, action.Cliff, action.Duration)
+					case *actions.ClaimVested:
+						summaryStr = fmt.Sprintf("vestingID: %s", action.Vesting)
+
+					case *actions.Propose:
+						summaryStr = fmt.Sprintf("param: %s value: %d", actions.ParamNames[action.Param], action.Value)
+						if actions.PairParam(action.Param) {
+							summaryStr += fmt.Sprintf(" pair: %s", actions.PairID(action.In, action.Out))
+						}
+					case *actions.Vote:
+						summaryStr = fmt.Sprintf("proposalID: %s support: %t weight: %s", action.Proposal, action.Support, utils.FormatBalance(action.Weight))
+					case *actions.ExecuteProposal:
+						summaryStr = fmt.Sprintf("proposalID: %s", action.Proposal)
+					case *actions.WithdrawVote:
+						summaryStr = fmt.Sprintf("proposalID: %s", action.Proposal)
+					case *actions.ReclaimDeposit:
+						summaryStr = fmt.Sprintf("proposalID: %s", action.Proposal)
+					case *actions.SwapOffer:
+						summaryStr = fmt.Sprintf(
+							"%s %s -> %s %s (maker: %s nonce: %d)",
+							valueString(action.Want, action.WantAmount), assetString(action.Want),
+							valueString(action.Give, action.GiveAmount), assetString(action.Give),
+							tutils.Address(action.Maker), action.Nonce,
+						)
+					case *actions.SetSponsorRate:
+						summaryStr = fmt.Sprintf("assetID: %s rate: %d", action.Asset, action.Rate)
+					case *actions.RegisterSession:
+						summaryStr = fmt.Sprintf(
+							"key: %s expiry: %d actions: %d limits: %d",
+							tutils.Address(action.Key),
+							action.Expiry,
+							len(action.Actions),
+							len(action.Limits),
+						)
+					case *actions.RevokeSession:
+						summaryStr = fmt.Sprintf("key: %s", tutils.Address(action.Key))
+					case *actions.SetGuardians:
+						summaryStr = fmt.Sprintf(
+							"guardians: %d threshold: %d delay: %ds",
+							len(action.Guardians),
+							action.Threshold,
+							action.Delay,
+						)
+					case *actions.InitiateRecovery:
+						summaryStr = fmt.Sprintf(
+							"account: %s new key: %s approvals: %d",
+							tutils.Address(action.Account),
+							tutils.Address(action.Key),
+							len(action.Approvals),
+						)
+					case *actions.CancelRecovery:
+						summaryStr = "canceled recovery"
+					case *actions.CompleteRecovery:
+						summaryStr = fmt.Sprintf("account: %s", tutils.Address(action.Account))
+					case *actions.RegisterName:
+						summaryStr = fmt.Sprintf(
+							"name: %s address: %s assetID: %s",
+							action.Name,
+							tutils.Address(action.Address),
+							action.Asset,
+						)
+					case *actions.UpdateName:
+						summaryStr = fmt.Sprintf(
+							"name: %s address: %s assetID: %s",
+							action.Name,
+							tutils.Address(action.Address),
+							action.Asset,
+						)
+					case *actions.TransferNameOwnership:
+						summaryStr = fmt.Sprintf("name: %s -> %s", action.Name, tutils.Address(action.To))
+					case *actions.RenewName:
+						summaryStr = fmt.Sprintf("name: %s", action.Name)
+					case *actions.CreateCollection:
+						summaryStr = fmt.Sprintf("collectionID: %s metadata: %s", tx.ID(), string(action.Metadata))
+					case *actions.MintNFT:
+						summaryStr = fmt.Sprintf(
+							"collectionID: %s id: %d -> %s",
+							action.Collection,
+							action.ID,
+							tutils.Address(action.To),
+						)
+					case *actions.TransferNFT:
+						summaryStr = fmt.Sprintf(
+							"collectionID: %s id: %d -> %s",
+							action.Collection,
+							action.ID,
+							tutils.Address(action.To),
+						)
+					case *actions.BurnNFT:
+						summaryStr = fmt.Sprintf("collectionID: %s id: %d", action.Collection, action.ID)
+					case *actions.CreateStream:
+						summaryStr = fmt.Sprintf(
+							"%s %s -> %s start: %d end: %d",
+							valueString(action.Asset, action.Amount),
+							assetString(action.Asset),
+							tutils.Address(action.Recipient),
+							action.Start,
+							action.End,
+						)
+					case *actions.WithdrawStream:
+						summaryStr = fmt.Sprintf("streamID: %s", action.Stream)
+					case *actions.CancelStream:
+						summaryStr = fmt.Sprintf("streamID: %s", action.Stream)
+					case *actions.CreateAirdrop:
+						summaryStr = fmt.Sprintf(
+							"%s %s root: %s expiry: %d",
+							valueString(action.Asset, action.Amount),
+							assetString(action.Asset),
+							action.Root,
+							action.Expiry,
+						)
+					case *actions.ClaimAirdrop:
+						summaryStr = fmt.Sprintf(
+							"airdropID: %s amount: %s %s",
+							action.Airdrop,
+							valueString(action.Asset, action.Amount),
+							assetString(action.Asset),
+						)
+					case *actions.ReclaimAirdrop:
+						summaryStr = fmt.Sprintf("airdropID: %s", action.Airdrop)
+					case *actions.CreateEscrow:
+						summaryStr = fmt.Sprintf(
+							"%s %s -> %s arbiter: %s deadline: %d release on timeout: %t",
+							valueString(action.Asset, action.Amount),
+							assetString(action.Asset),
+							tutils.Address(action.Payee),
+							tutils.Address(action.Arbiter),
+							action.Deadline,
+							action.ReleaseOnTimeout,
+						)
+					case *actions.ResolveEscrow:
+						summaryStr = fmt.Sprintf("escrowID: %s -> %s", action.Escrow, tutils.Address(action.Recipient))
+					case *actions.CreateFeed:
+						summaryStr = fmt.Sprintf("decimals: %d metadata: %s", action.Decimals, string(action.Metadata))
+					case *actions.UpdateFeed:
+						summaryStr = fmt.Sprintf("feedID: %s price: %d", action.Feed, action.Price)
+					case *actions.CreateTriggerOrder:
+						source := "last trade"
+						if action.Feed != ids.Empty {
+							source = action.Feed.String()
+						}
+						summaryStr = fmt.Sprintf(
+							"%s %s -> %s trigger(in/out): %d/%d above: %t source: %s reward: %s",
+							valueString(action.In, action.Value),
+							assetString(action.In),
+							assetString(action.Out),
+							action.TriggerIn,
+							action.TriggerOut,
+							action.Above,
+							source,
+							valueString(action.In, action.Reward),
+						)
+					case *actions.TriggerOrder:
+						or, _ := actions.UnmarshalOrderResult(result.Output)
+						summaryStr = fmt.Sprintf(
+							"triggerID: %s orderID: %s in: %s %s out: %s %s",
+							action.Trigger,
+							action.Order,
+							valueString(action.In, or.In),
+							assetString(action.In),
+							valueString(action.Out, or.Out-or.TakerFee),
+							assetString(action.Out),
+						)
+					case *actions.CancelTriggerOrder:
+						summaryStr = fmt.Sprintf("triggerID: %s", action.Trigger)
+					case *actions.SettleFees:
+						summaryStr = fmt.Sprintf("asset: %s", assetString(action.Asset))
 					}
 				}
+				switch a := tx.Auth.(type) {
+				case *auth.Sponsored:
+					summaryStr += fmt.Sprintf(" | sponsor: %s asset: %s", tutils.Address(a.Sponsor), a.Asset)
+				case *auth.Delegated:
+					summaryStr += fmt.Sprintf(" | fee payer: %s", tutils.Address(a.FeePayer))
+				case *auth.Session:
+					summaryStr += fmt.Sprintf(" | session key: %s", tutils.Address(a.Key))
+				case *auth.Recovered:
+					summaryStr += fmt.Sprintf(" | recovered key: %s", tutils.Address(a.Key))
+				}
 				utils.Outf(
 					"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}units:{{/}} %d {{yellow}}summary (%s):{{/}} [%s]\n",
 					status,
@@ -19,4 +19,20 @@ var (
 	ErrNoKeys              = errors.New("no available keys")
 	ErrNoChains            = errors.New("no available chains")
 	ErrTxFailed            = errors.New("tx failed")
+	ErrInsufficientChains  = errors.New("at least 2 chains required")
+	ErrAuditFailed         = errors.New("audit failed")
+	ErrSameAsset           = errors.New("same asset")
+	ErrNoSponsorRate       = errors.New("sponsor does not accept asset")
+	ErrUnsupportedKey      = errors.New("unsupported key type")
+	ErrInvalidKey          = errors.New("invalid key")
+	ErrNoSession           = errors.New("session key not registered")
+	ErrNoGuardians         = errors.New("account has no guardians")
+	ErrNoRecovery          = errors.New("no pending recovery")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrNameNotFound        = errors.New("name not registered")
+	ErrNameNoAddress       = errors.New("name does not resolve to an address")
+	ErrNameNoAsset         = errors.New("name does not resolve to an asset")
+	ErrCollectionNotFound  = errors.New("collection not found")
+	ErrNotInAirdrop        = errors.New("account is not in airdrop")
+	ErrWrongTaker          = errors.New("offer is for another taker")
 )
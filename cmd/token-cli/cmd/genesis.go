@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"os"
 
+	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
@@ -54,6 +55,35 @@ var genGenesisCmd = &cobra.Command{
 		}
 		g.CustomAllocation = allocs
 
+		if len(customAssetsFile) > 0 {
+			a, err := os.ReadFile(customAssetsFile)
+			if err != nil {
+				return err
+			}
+			assets := []*genesis.CustomAsset{}
+			if err := json.Unmarshal(a, &assets); err != nil {
+				return err
+			}
+			g.CustomAssets = assets
+			for _, asset := range assets {
+				hutils.Outf(
+					"{{yellow}}genesis asset %q:{{/}} %s\n",
+					asset.Metadata,
+					genesis.AssetID(asset.Metadata),
+				)
+			}
+		}
+		for i, alloc := range allocs {
+			if alloc.Lockup == nil {
+				continue
+			}
+			hutils.Outf(
+				"{{yellow}}lockup for %s:{{/}} %s\n",
+				alloc.Address,
+				genesis.LockupID(i),
+			)
+		}
+
 		b, err := json.Marshal(g)
 		if err != nil {
 			return err
@@ -5,17 +5,24 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
+	"os"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/crypto"
 	hutils "github.com/ava-labs/hypersdk/utils"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"tokenvm/auth"
 	trpc "tokenvm/rpc"
 	"tokenvm/utils"
 )
 
+const secp256k1PrivateKeyLen = 32
+
 var keyCmd = &cobra.Command{
 	Use: "key",
 	RunE: func(*cobra.Command, []string) error {
@@ -47,7 +54,7 @@ var genKeyCmd = &cobra.Command{
 }
 
 var importKeyCmd = &cobra.Command{
-	Use: "import [path]",
+	Use: "import [path or hex]",
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
 			return ErrInvalidArgs
@@ -55,14 +62,10 @@ var importKeyCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(_ *cobra.Command, args []string) error {
-		priv, err := crypto.LoadKey(args[0])
+		publicKey, err := importKey(args[0])
 		if err != nil {
 			return err
 		}
-		if err := StoreKey(priv); err != nil {
-			return err
-		}
-		publicKey := priv.PublicKey()
 		if err := StoreDefault(defaultKeyKey, publicKey[:]); err != nil {
 			return err
 		}
@@ -74,6 +77,37 @@ var importKeyCmd = &cobra.Command{
 	},
 }
 
+// importKey stores the key at [path] or, if [path] is not a file, the
+// hex-encoded ed25519 or secp256k1 private key in [path].
+func importKey(path string) (crypto.PublicKey, error) {
+	if _, err := os.Stat(path); err == nil {
+		priv, err := crypto.LoadKey(path)
+		if err != nil {
+			return crypto.EmptyPublicKey, err
+		}
+		return priv.PublicKey(), StoreKey(priv)
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(path, "0x"))
+	if err != nil {
+		return crypto.EmptyPublicKey, err
+	}
+	switch len(b) {
+	case crypto.PrivateKeyLen:
+		priv := crypto.PrivateKey(b)
+		return priv.PublicKey(), StoreKey(priv)
+	case secp256k1PrivateKeyLen:
+		priv, err := ethcrypto.ToECDSA(b)
+		if err != nil {
+			return crypto.EmptyPublicKey, err
+		}
+		addr := ethcrypto.PubkeyToAddress(priv.PublicKey)
+		hutils.Outf("{{yellow}}ethereum address:{{/}} %s\n", addr)
+		return auth.SECP256K1Actor(addr), StoreSECP256K1Key(priv)
+	default:
+		return crypto.EmptyPublicKey, ErrInvalidKey
+	}
+}
+
 var setKeyCmd = &cobra.Command{
 	Use: "set",
 	RunE: func(*cobra.Command, []string) error {
@@ -81,7 +115,18 @@ var setKeyCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if len(keys) == 0 {
+		secpKeys, err := GetSECP256K1Keys()
+		if err != nil {
+			return err
+		}
+		publicKeys := make([]crypto.PublicKey, 0, len(keys)+len(secpKeys))
+		for _, key := range keys {
+			publicKeys = append(publicKeys, key.PublicKey())
+		}
+		for _, key := range secpKeys {
+			publicKeys = append(publicKeys, auth.SECP256K1Actor(ethcrypto.PubkeyToAddress(key.PublicKey)))
+		}
+		if len(publicKeys) == 0 {
 			hutils.Outf("{{red}}no stored keys{{/}}\n")
 			return nil
 		}
@@ -94,9 +139,9 @@ var setKeyCmd = &cobra.Command{
 			return nil
 		}
 		cli := trpc.NewJSONRPCClient(uris[0], chainID)
-		hutils.Outf("{{cyan}}stored keys:{{/}} %d\n", len(keys))
-		for i := 0; i < len(keys); i++ {
-			address := utils.Address(keys[i].PublicKey())
+		hutils.Outf("{{cyan}}stored keys:{{/}} %d\n", len(publicKeys))
+		for i := 0; i < len(publicKeys); i++ {
+			address := utils.Address(publicKeys[i])
 			balance, err := cli.Balance(context.TODO(), address, ids.Empty)
 			if err != nil {
 				return err
@@ -110,12 +155,11 @@ var setKeyCmd = &cobra.Command{
 		}
 
 		// Select key
-		keyIndex, err := promptChoice("set default key", len(keys))
+		keyIndex, err := promptChoice("set default key", len(publicKeys))
 		if err != nil {
 			return err
 		}
-		key := keys[keyIndex]
-		publicKey := key.PublicKey()
+		publicKey := publicKeys[keyIndex]
 		return StoreDefault(defaultKeyKey, publicKey[:])
 	},
 }
@@ -125,7 +169,7 @@ var balanceKeyCmd = &cobra.Command{
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
 
-		priv, err := GetDefaultKey()
+		publicKey, _, _, err := GetDefaultKey()
 		if err != nil {
 			return err
 		}
@@ -145,7 +189,7 @@ var balanceKeyCmd = &cobra.Command{
 		}
 		for _, uri := range uris[:max] {
 			hutils.Outf("{{yellow}}uri:{{/}} %s\n", uri)
-			if _, _, err = getAssetInfo(ctx, trpc.NewJSONRPCClient(uri, chainID), priv.PublicKey(), assetID, true); err != nil {
+			if _, _, err = getAssetInfo(ctx, trpc.NewJSONRPCClient(uri, chainID), publicKey, assetID, true); err != nil {
 				return err
 			}
 		}
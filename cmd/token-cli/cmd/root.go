@@ -18,6 +18,7 @@ const (
 	fsModeWrite     = 0o600
 	defaultDatabase = ".token-cli"
 	defaultGenesis  = "genesis.json"
+	defaultAirdrop  = "airdrop.json"
 )
 
 var (
@@ -25,6 +26,7 @@ var (
 	db     database.Database
 
 	genesisFile        string
+	customAssetsFile   string
 	minUnitPrice       int64
 	maxBlockUnits      int64
 	windowTargetUnits  int64
@@ -34,6 +36,12 @@ var (
 	maxTxBacklog       int
 	deleteOtherChains  bool
 	checkAllChains     bool
+	sponsor            string
+	sponsorAsset       string
+	feePayer           string
+	session            string
+	recovered          string
+	airdropFile        string
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -49,6 +57,8 @@ func init() {
 		keyCmd,
 		chainCmd,
 		actionCmd,
+		bridgeCmd,
+		airdropCmd,
 		spamCmd,
 		metricsCmd,
 	)
@@ -76,6 +86,12 @@ func init() {
 		defaultGenesis,
 		"genesis file path",
 	)
+	genGenesisCmd.PersistentFlags().StringVar(
+		&customAssetsFile,
+		"custom-assets-file",
+		"",
+		"custom assets file path",
+	)
 	genGenesisCmd.PersistentFlags().Int64Var(
 		&minUnitPrice,
 		"min-unit-price",
@@ -141,6 +157,36 @@ func init() {
 	)
 
 	// actions
+	actionCmd.PersistentFlags().StringVar(
+		&sponsor,
+		"sponsor",
+		"",
+		"address of sponsor to pay fees",
+	)
+	actionCmd.PersistentFlags().StringVar(
+		&sponsorAsset,
+		"sponsor-asset",
+		"",
+		"asset used to repay sponsor",
+	)
+	actionCmd.PersistentFlags().StringVar(
+		&feePayer,
+		"fee-payer",
+		"",
+		"address of stored key to pay fees",
+	)
+	actionCmd.PersistentFlags().StringVar(
+		&session,
+		"session",
+		"",
+		"address of account to act as with the default key as a session key",
+	)
+	actionCmd.PersistentFlags().StringVar(
+		&recovered,
+		"recovered",
+		"",
+		"address of recovered account to act as with the default key",
+	)
 	actionCmd.AddCommand(
 		transferCmd,
 
@@ -155,6 +201,78 @@ func init() {
 
 		importAssetCmd,
 		exportAssetCmd,
+
+		sendMessageCmd,
+		receiveMessageCmd,
+
+		vestCmd,
+		claimVestedCmd,
+
+		proposeCmd,
+		voteCmd,
+		executeProposalCmd,
+		withdrawVoteCmd,
+		reclaimDepositCmd,
+
+		signSwapOfferCmd,
+		swapOfferCmd,
+
+		setSponsorRateCmd,
+
+		registerSessionCmd,
+		revokeSessionCmd,
+
+		setGuardiansCmd,
+		signRecoveryCmd,
+		initiateRecoveryCmd,
+		cancelRecoveryCmd,
+		completeRecoveryCmd,
+
+		registerNameCmd,
+		updateNameCmd,
+		transferNameCmd,
+		renewNameCmd,
+
+		createCollectionCmd,
+		mintNFTCmd,
+		transferNFTCmd,
+		burnNFTCmd,
+
+		createStreamCmd,
+		withdrawStreamCmd,
+		cancelStreamCmd,
+
+		createAirdropCmd,
+		claimAirdropCmd,
+		reclaimAirdropCmd,
+
+		createEscrowCmd,
+		resolveEscrowCmd,
+
+		createFeedCmd,
+		updateFeedCmd,
+
+		createTriggerOrderCmd,
+		triggerOrderCmd,
+		cancelTriggerOrderCmd,
+
+		settleFeesCmd,
+	)
+
+	// bridge
+	bridgeCmd.AddCommand(
+		auditBridgeCmd,
+	)
+
+	// airdrop
+	buildAirdropCmd.PersistentFlags().StringVar(
+		&airdropFile,
+		"airdrop-file",
+		defaultAirdrop,
+		"airdrop file path",
+	)
+	airdropCmd.AddCommand(
+		buildAirdropCmd,
 	)
 
 	// spam
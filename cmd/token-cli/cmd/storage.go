@@ -4,19 +4,24 @@
 package cmd
 
 import (
+	"crypto/ecdsa"
 	"errors"
 
+	"tokenvm/auth"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/utils"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
-	defaultPrefix = 0x0
-	keyPrefix     = 0x1
-	chainPrefix   = 0x2
+	defaultPrefix      = 0x0
+	keyPrefix          = 0x1
+	chainPrefix        = 0x2
+	secp256k1KeyPrefix = 0x3
 
 	defaultKeyKey   = "key"
 	defaultChainKey = "chain"
@@ -85,6 +90,50 @@ func GetKeys() ([]crypto.PrivateKey, error) {
 	return privateKeys, iter.Error()
 }
 
+func StoreSECP256K1Key(privateKey *ecdsa.PrivateKey) error {
+	publicKey := auth.SECP256K1Actor(ethcrypto.PubkeyToAddress(privateKey.PublicKey))
+	k := make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = secp256k1KeyPrefix
+	copy(k[1:], publicKey[:])
+	has, err := db.Has(k)
+	if err != nil {
+		return err
+	}
+	if has {
+		return ErrDuplicate
+	}
+	return db.Put(k, ethcrypto.FromECDSA(privateKey))
+}
+
+func GetSECP256K1Key(publicKey crypto.PublicKey) (*ecdsa.PrivateKey, error) {
+	k := make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = secp256k1KeyPrefix
+	copy(k[1:], publicKey[:])
+	v, err := db.Get(k)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.ToECDSA(v)
+}
+
+func GetSECP256K1Keys() ([]*ecdsa.PrivateKey, error) {
+	iter := db.NewIteratorWithPrefix([]byte{secp256k1KeyPrefix})
+	defer iter.Release()
+
+	privateKeys := []*ecdsa.PrivateKey{}
+	for iter.Next() {
+		privateKey, err := ethcrypto.ToECDSA(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		privateKeys = append(privateKeys, privateKey)
+	}
+	return privateKeys, iter.Error()
+}
+
 func StoreChain(chainID ids.ID, rpc string) error {
 	k := make([]byte, 1+consts.IDLen*2)
 	k[0] = chainPrefix
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"tokenvm/actions"
 	"tokenvm/auth"
 	"tokenvm/consts"
 	trpc "tokenvm/rpc"
@@ -16,20 +18,25 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/chain"
 	hconsts "github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/rpc"
 	hutils "github.com/ava-labs/hypersdk/utils"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/manifoldco/promptui"
 )
 
 func promptAddress(label string) (crypto.PublicKey, error) {
 	promptText := promptui.Prompt{
-		Label: label,
+		Label: fmt.Sprintf("%s (address or name)", label),
 		Validate: func(input string) error {
 			if len(input) == 0 {
 				return ErrInputEmpty
 			}
+			if actions.ValidName(input) {
+				return nil
+			}
 			_, err := utils.ParseAddress(input)
 			return err
 		},
@@ -39,7 +46,18 @@ func promptAddress(label string) (crypto.PublicKey, error) {
 		return crypto.EmptyPublicKey, err
 	}
 	recipient = strings.TrimSpace(recipient)
-	return utils.ParseAddress(recipient)
+	if !actions.ValidName(recipient) {
+		return utils.ParseAddress(recipient)
+	}
+	record, err := resolveName(recipient)
+	if err != nil {
+		return crypto.EmptyPublicKey, err
+	}
+	if len(record.Address) == 0 {
+		return crypto.EmptyPublicKey, ErrNameNoAddress
+	}
+	hutils.Outf("{{yellow}}resolved %s:{{/}} %s\n", recipient, record.Address)
+	return utils.ParseAddress(record.Address)
 }
 
 func promptString(label string) (string, error) {
@@ -59,6 +77,54 @@ func promptString(label string) (string, error) {
 	return strings.TrimSpace(text), err
 }
 
+func promptName(label string) (string, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			if !actions.ValidName(input) {
+				return actions.ErrInvalidName
+			}
+			return nil
+		},
+	}
+	name, err := promptText.Run()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// promptNameTarget asks for the address and asset a name should resolve to.
+// Either may be left empty.
+func promptNameTarget() (crypto.PublicKey, ids.ID, error) {
+	address := crypto.EmptyPublicKey
+	resolveAddress, err := promptBool("resolve to an address")
+	if err != nil {
+		return crypto.EmptyPublicKey, ids.Empty, err
+	}
+	if resolveAddress {
+		address, err = promptAddress("address")
+		if err != nil {
+			return crypto.EmptyPublicKey, ids.Empty, err
+		}
+	}
+	asset := ids.Empty
+	resolveAsset, err := promptBool("resolve to an asset")
+	if err != nil {
+		return crypto.EmptyPublicKey, ids.Empty, err
+	}
+	if resolveAsset {
+		asset, err = promptAsset("asset", false)
+		if err != nil {
+			return crypto.EmptyPublicKey, ids.Empty, err
+		}
+	}
+	return address, asset, nil
+}
+
 func promptAsset(label string, allowNative bool) (ids.ID, error) {
 	text := fmt.Sprintf("%s (use TKN for native token)", label)
 	if !allowNative {
@@ -73,6 +139,9 @@ func promptAsset(label string, allowNative bool) (ids.ID, error) {
 			if allowNative && len(input) == 3 && input == consts.Symbol {
 				return nil
 			}
+			if actions.ValidName(input) {
+				return nil
+			}
 			_, err := ids.FromString(input)
 			return err
 		},
@@ -83,7 +152,19 @@ func promptAsset(label string, allowNative bool) (ids.ID, error) {
 	}
 	asset = strings.TrimSpace(asset)
 	var assetID ids.ID
-	if asset != consts.Symbol {
+	switch {
+	case asset == consts.Symbol:
+	case actions.ValidName(asset):
+		record, err := resolveName(asset)
+		if err != nil {
+			return ids.Empty, err
+		}
+		if record.Asset == ids.Empty {
+			return ids.Empty, ErrNameNoAsset
+		}
+		hutils.Outf("{{yellow}}resolved %s:{{/}} %s\n", asset, record.Asset)
+		assetID = record.Asset
+	default:
 		assetID, err = ids.FromString(asset)
 		if err != nil {
 			return ids.Empty, err
@@ -167,6 +248,31 @@ func promptInt(
 	return strconv.Atoi(rawAmount)
 }
 
+func promptUint64(label string, f func(input uint64) error) (uint64, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			value, err := strconv.ParseUint(input, 10, 64)
+			if err != nil {
+				return err
+			}
+			if f != nil {
+				return f(value)
+			}
+			return nil
+		},
+	}
+	rawValue, err := promptText.Run()
+	if err != nil {
+		return 0, err
+	}
+	rawValue = strings.TrimSpace(rawValue)
+	return strconv.ParseUint(rawValue, 10, 64)
+}
+
 func promptChoice(label string, max int) (int, error) {
 	promptText := promptui.Prompt{
 		Label: label,
@@ -340,6 +446,170 @@ func assetString(assetID ids.ID) string {
 	return assetID.String()
 }
 
+func getProposal(ctx context.Context, cli *trpc.JSONRPCClient, proposalID ids.ID) (*trpc.Proposal, error) {
+	proposals, err := cli.Proposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		if proposal.ID == proposalID {
+			if len(proposal.Pair) > 0 {
+				hutils.Outf("{{yellow}}pair:{{/}} %s\n", proposal.Pair)
+			}
+			hutils.Outf(
+				"{{yellow}}param:{{/}} %s {{yellow}}value:{{/}} %d {{yellow}}yes:{{/}} %s {{yellow}}no:{{/}} %s {{yellow}}deposit:{{/}} %s {{yellow}}executed:{{/}} %t\n",
+				proposal.Param,
+				proposal.Value,
+				hutils.FormatBalance(proposal.Yes),
+				hutils.FormatBalance(proposal.No),
+				hutils.FormatBalance(proposal.Deposit),
+				proposal.Executed,
+			)
+			return proposal, nil
+		}
+	}
+	hutils.Outf("{{red}}proposal %s does not exist{{/}}\n", proposalID)
+	return nil, nil
+}
+
+// promptNFT asks for a collection and token ID and prints the NFT. It
+// returns nil if the NFT does not exist.
+func promptNFT(ctx context.Context, cli *trpc.JSONRPCClient) (*trpc.NFT, error) {
+	collection, err := promptID("collectionID")
+	if err != nil {
+		return nil, err
+	}
+	id, err := promptUint64("token id", nil)
+	if err != nil {
+		return nil, err
+	}
+	nft, err := cli.NFT(ctx, collection, id)
+	if err != nil {
+		return nil, err
+	}
+	if nft == nil {
+		hutils.Outf("{{red}}nft %d of %s does not exist{{/}}\n", id, collection)
+		return nil, nil
+	}
+	hutils.Outf(
+		"{{yellow}}assetID:{{/}} %s {{yellow}}owner:{{/}} %s {{yellow}}metadata:{{/}} %s\n",
+		nft.Asset,
+		nft.Owner,
+		string(nft.Metadata),
+	)
+	return nft, nil
+}
+
+// promptStream asks for a stream and prints how much of it has been streamed.
+// It returns nil if the stream does not exist.
+func promptStream(ctx context.Context, cli *trpc.JSONRPCClient) (ids.ID, *trpc.StreamReply, error) {
+	streamID, err := promptID("streamID")
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	stream, err := cli.Stream(ctx, streamID)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	if stream == nil {
+		hutils.Outf("{{red}}stream %s does not exist{{/}}\n", streamID)
+		return ids.Empty, nil, nil
+	}
+	streamed := actions.StreamedAmount(stream.Amount, stream.Start, stream.End, time.Now().Unix())
+	hutils.Outf(
+		"{{yellow}}sender:{{/}} %s {{yellow}}recipient:{{/}} %s {{yellow}}streamed:{{/}} %s/%s %s {{yellow}}withdrawn:{{/}} %s\n",
+		stream.Sender,
+		stream.Recipient,
+		valueString(stream.Asset, streamed),
+		valueString(stream.Asset, stream.Amount),
+		assetString(stream.Asset),
+		valueString(stream.Asset, stream.Withdrawn),
+	)
+	return streamID, stream, nil
+}
+
+// promptTriggerOrder prints the open trigger orders (of [owner], if not empty)
+// and asks for one of them. It returns nil if there are none.
+func promptTriggerOrder(ctx context.Context, cli *trpc.JSONRPCClient, owner string) (*trpc.TriggerOrder, error) {
+	triggers, err := cli.TriggerOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(owner) > 0 {
+		owned := []*trpc.TriggerOrder{}
+		for _, trigger := range triggers {
+			if trigger.Owner == owner {
+				owned = append(owned, trigger)
+			}
+		}
+		triggers = owned
+	}
+	if len(triggers) == 0 {
+		hutils.Outf("{{red}}no available trigger orders{{/}}\n")
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return nil, nil
+	}
+	hutils.Outf("{{cyan}}available trigger orders:{{/}} %d\n", len(triggers))
+	max := 20
+	if len(triggers) < max {
+		max = len(triggers)
+	}
+	for i := 0; i < max; i++ {
+		trigger := triggers[i]
+		source := "last trade"
+		if trigger.Feed != ids.Empty {
+			source = trigger.Feed.String()
+		}
+		direction := "below"
+		if trigger.Above {
+			direction = "above"
+		}
+		hutils.Outf(
+			"%d) {{cyan}}ID:{{/}} %s {{cyan}}Value:{{/}} %s %s {{cyan}}Out:{{/}} %s {{cyan}}Reward:{{/}} %s {{cyan}}Trigger(in/out):{{/}} %s %.4f (%s)\n", //nolint:lll
+			i,
+			trigger.ID,
+			valueString(trigger.In, trigger.Value),
+			assetString(trigger.In),
+			assetString(trigger.Out),
+			valueString(trigger.In, trigger.Reward),
+			direction,
+			float64(trigger.TriggerIn)/float64(trigger.TriggerOut),
+			source,
+		)
+	}
+	index, err := promptChoice("select trigger order", max)
+	if err != nil {
+		return nil, err
+	}
+	return triggers[index], nil
+}
+
+// resolveName returns the record of [name] on the default chain. It returns
+// [ErrNameNotFound] if [name] is not registered or has expired.
+func resolveName(name string) (*trpc.ResolveReply, error) {
+	v, err := GetDefault(defaultChainKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, ErrNoChains
+	}
+	chainID := ids.ID(v)
+	uris, err := GetChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	tcli := trpc.NewJSONRPCClient(uris[0], chainID)
+	record, err := tcli.Resolve(context.Background(), name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Expiry <= time.Now().Unix() {
+		return nil, ErrNameNotFound
+	}
+	return record, nil
+}
+
 func printStatus(txID ids.ID, success bool) {
 	status := "⚠️"
 	if success {
@@ -357,7 +627,7 @@ func getAssetInfo(
 ) (uint64, ids.ID, error) {
 	var sourceChainID ids.ID
 	if assetID != ids.Empty {
-		exists, metadata, supply, _, warp, err := cli.Asset(ctx, assetID)
+		exists, metadata, supply, _, warp, _, err := cli.Asset(ctx, assetID)
 		if err != nil {
 			return 0, ids.Empty, err
 		}
@@ -406,41 +676,141 @@ func getAssetInfo(
 	return balance, sourceChainID, nil
 }
 
-func defaultActor() (ids.ID, crypto.PrivateKey, *auth.ED25519Factory, *rpc.JSONRPCClient, *trpc.JSONRPCClient, error) {
-	priv, err := GetDefaultKey()
+func defaultActor() (ids.ID, crypto.PublicKey, chain.AuthFactory, *rpc.JSONRPCClient, *trpc.JSONRPCClient, error) {
+	publicKey, priv, factory, err := GetDefaultKey()
 	if err != nil {
-		return ids.Empty, crypto.EmptyPrivateKey, nil, nil, nil, err
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
 	}
 	chainID, uris, err := GetDefaultChain()
 	if err != nil {
-		return ids.Empty, crypto.EmptyPrivateKey, nil, nil, nil, err
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
 	}
 	// For [defaultActor], we always send requests to the first returned URI.
-	return chainID, priv, auth.NewED25519Factory(
-			priv,
-		), rpc.NewJSONRPCClient(
-			uris[0],
-		), trpc.NewJSONRPCClient(
-			uris[0],
-			chainID,
-		), nil
+	cli := rpc.NewJSONRPCClient(uris[0])
+	tcli := trpc.NewJSONRPCClient(uris[0], chainID)
+	modes := 0
+	for _, flag := range []string{sponsor, feePayer, session, recovered} {
+		if len(flag) > 0 {
+			modes++
+		}
+	}
+	if modes == 0 {
+		return chainID, publicKey, factory, cli, tcli, nil
+	}
+	if modes > 1 {
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, ErrInvalidArgs
+	}
+	if priv == crypto.EmptyPrivateKey {
+		// Sponsored, delegated, session, and recovered transactions must be
+		// signed by an ed25519 key
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, ErrUnsupportedKey
+	}
+	if len(recovered) > 0 {
+		// Act as [recovered] with the key its guardians rotated control to
+		account, err := utils.ParseAddress(recovered)
+		if err != nil {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+		}
+		hutils.Outf("{{yellow}}recovered account:{{/}} %s\n", recovered)
+		return chainID, account, auth.NewRecoveredFactory(account, priv), cli, tcli, nil
+	}
+	if len(session) > 0 {
+		// Act as [session] using the default key as a session key
+		owner, err := utils.ParseAddress(session)
+		if err != nil {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+		}
+		s, err := tcli.Session(context.Background(), session, utils.Address(publicKey))
+		if err != nil {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+		}
+		if s == nil {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, ErrNoSession
+		}
+		hutils.Outf(
+			"{{yellow}}session owner:{{/}} %s {{yellow}}expiry:{{/}} %d\n",
+			session,
+			s.Expiry,
+		)
+		return chainID, owner, auth.NewSessionFactory(owner, priv, s.Expiry), cli, tcli, nil
+	}
+	if len(feePayer) > 0 {
+		// Pay fees with a different key stored in the database
+		feePayerKey, err := utils.ParseAddress(feePayer)
+		if err != nil {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+		}
+		feePayerPriv, err := GetKey(feePayerKey)
+		if err != nil {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+		}
+		if feePayerPriv == crypto.EmptyPrivateKey {
+			return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, ErrNoKeys
+		}
+		hutils.Outf("{{yellow}}fee payer:{{/}} %s\n", feePayer)
+		return chainID, publicKey, auth.NewDelegatedFactory(priv, feePayerPriv), cli, tcli, nil
+	}
+
+	// Pay fees with [sponsorAsset] at the rate currently published by
+	// [sponsor]
+	sponsorKey, err := utils.ParseAddress(sponsor)
+	if err != nil {
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+	}
+	assetID, err := ids.FromString(sponsorAsset)
+	if err != nil {
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+	}
+	rate, err := tcli.SponsorRate(context.Background(), sponsor, assetID)
+	if err != nil {
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, err
+	}
+	if rate == 0 {
+		return ids.Empty, crypto.EmptyPublicKey, nil, nil, nil, ErrNoSponsorRate
+	}
+	hutils.Outf(
+		"{{yellow}}sponsor:{{/}} %s {{yellow}}rate:{{/}} %d %s per %s\n",
+		sponsor,
+		rate,
+		assetID,
+		consts.Symbol,
+	)
+	return chainID, publicKey, auth.NewSponsoredFactory(priv, sponsorKey, assetID, rate), cli, tcli, nil
 }
 
-func GetDefaultKey() (crypto.PrivateKey, error) {
+// GetDefaultKey returns the default key and a factory that signs with it. If
+// the default key is a secp256k1 key, the returned ed25519 private key is
+// empty.
+func GetDefaultKey() (crypto.PublicKey, crypto.PrivateKey, chain.AuthFactory, error) {
 	v, err := GetDefault(defaultKeyKey)
 	if err != nil {
-		return crypto.EmptyPrivateKey, err
+		return crypto.EmptyPublicKey, crypto.EmptyPrivateKey, nil, err
 	}
 	if len(v) == 0 {
-		return crypto.EmptyPrivateKey, ErrNoKeys
+		return crypto.EmptyPublicKey, crypto.EmptyPrivateKey, nil, ErrNoKeys
 	}
 	publicKey := crypto.PublicKey(v)
 	priv, err := GetKey(publicKey)
 	if err != nil {
-		return crypto.EmptyPrivateKey, err
+		return crypto.EmptyPublicKey, crypto.EmptyPrivateKey, nil, err
 	}
-	hutils.Outf("{{yellow}}address:{{/}} %s\n", utils.Address(publicKey))
-	return priv, nil
+	if priv != crypto.EmptyPrivateKey {
+		hutils.Outf("{{yellow}}address:{{/}} %s\n", utils.Address(publicKey))
+		return publicKey, priv, auth.NewED25519Factory(priv), nil
+	}
+	secpPriv, err := GetSECP256K1Key(publicKey)
+	if err != nil {
+		return crypto.EmptyPublicKey, crypto.EmptyPrivateKey, nil, err
+	}
+	if secpPriv == nil {
+		return crypto.EmptyPublicKey, crypto.EmptyPrivateKey, nil, ErrNoKeys
+	}
+	hutils.Outf(
+		"{{yellow}}address:{{/}} %s {{yellow}}ethereum address:{{/}} %s\n",
+		utils.Address(publicKey),
+		ethcrypto.PubkeyToAddress(secpPriv.PublicKey),
+	)
+	return publicKey, crypto.EmptyPrivateKey, auth.NewSECP256K1Factory(secpPriv), nil
 }
 
 func GetDefaultChain() (ids.ID, []string, error) {
@@ -9,10 +9,13 @@ import (
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/pebble"
 	hrpc "github.com/ava-labs/hypersdk/rpc"
@@ -166,8 +169,7 @@ func (c *Controller) Initialize(
 }
 
 func (c *Controller) Rules(t int64) chain.Rules {
-	// TODO: extend with [UpgradeBytes]
-	return c.genesis.Rules(t)
+	return c.genesis.Rules(t).WithChainID(c.snowCtx.ChainID)
 }
 
 func (c *Controller) StateManager() chain.StateManager {
@@ -178,6 +180,8 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 	batch := c.metaDB.NewBatch()
 	defer batch.Reset()
 
+	// NFTs minted in this block are not yet in [c.metaDB]
+	minted := set.Set[ids.ID]{}
 	results := blk.Results()
 	for i, tx := range blk.Txs {
 		result := results[i]
@@ -215,6 +219,13 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 					// This should never happen
 					return err
 				}
+				actor := auth.GetActor(tx.Auth)
+				if err := c.moveNFTOwner(ctx, batch, minted, action.In, actor, action.Owner); err != nil {
+					return err
+				}
+				if err := c.moveNFTOwner(ctx, batch, minted, action.Out, action.Owner, actor); err != nil {
+					return err
+				}
 				if orderResult.Remaining == 0 {
 					c.orderBook.Remove(action.Order)
 					continue
@@ -227,12 +238,182 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.importAsset.Inc()
 			case *actions.ExportAsset:
 				c.metrics.exportAsset.Inc()
+			case *actions.SendMessage:
+				c.metrics.sendMessage.Inc()
+			case *actions.ReceiveMessage:
+				c.metrics.receiveMessage.Inc()
+			case *actions.BatchExportAsset:
+				c.metrics.batchExportAsset.Inc()
+			case *actions.BatchImportAsset:
+				c.metrics.batchImportAsset.Inc()
+			case *actions.SetWarpDestinations:
+				c.metrics.setWarpDestinations.Inc()
+			case *actions.PauseAsset:
+				c.metrics.pauseAsset.Inc()
+			case *actions.FreezeAccount:
+				c.metrics.freezeAccount.Inc()
+			case *actions.ClawbackAsset:
+				c.metrics.clawbackAsset.Inc()
+			case *actions.SetAllowlistMode:
+				c.metrics.setAllowlistMode.Inc()
+			case *actions.SetAllowlist:
+				c.metrics.setAllowlist.Inc()
+			case *actions.CreateVesting:
+				c.metrics.createVesting.Inc()
+			case *actions.ClaimVested:
+				c.metrics.claimVested.Inc()
+			case *actions.Propose:
+				c.metrics.propose.Inc()
+				if err := storage.StoreProposal(ctx, batch, tx.ID()); err != nil {
+					return err
+				}
+			case *actions.Vote:
+				c.metrics.vote.Inc()
+			case *actions.ExecuteProposal:
+				c.metrics.executeProposal.Inc()
+			case *actions.WithdrawVote:
+				c.metrics.withdrawVote.Inc()
+			case *actions.ReclaimDeposit:
+				c.metrics.reclaimDeposit.Inc()
+			case *actions.SwapOffer:
+				c.metrics.swapOffer.Inc()
+			case *actions.SetSponsorRate:
+				c.metrics.setSponsorRate.Inc()
+			case *actions.RegisterSession:
+				c.metrics.registerSession.Inc()
+			case *actions.RevokeSession:
+				c.metrics.revokeSession.Inc()
+			case *actions.SetGuardians:
+				c.metrics.setGuardians.Inc()
+			case *actions.InitiateRecovery:
+				c.metrics.initiateRecovery.Inc()
+			case *actions.CancelRecovery:
+				c.metrics.cancelRecovery.Inc()
+			case *actions.CompleteRecovery:
+				c.metrics.completeRecovery.Inc()
+			case *actions.RegisterName:
+				c.metrics.registerName.Inc()
+			case *actions.UpdateName:
+				c.metrics.updateName.Inc()
+			case *actions.TransferNameOwnership:
+				c.metrics.transferName.Inc()
+			case *actions.RenewName:
+				c.metrics.renewName.Inc()
+			case *actions.CreateCollection:
+				c.metrics.createCollection.Inc()
+			case *actions.MintNFT:
+				c.metrics.mintNFT.Inc()
+				nft := actions.NFTID(action.Collection, action.ID)
+				minted.Add(nft)
+				if err := storage.StoreNFT(ctx, batch, nft); err != nil {
+					return err
+				}
+				if err := storage.StoreNFTOwner(ctx, batch, action.To, nft); err != nil {
+					return err
+				}
+			case *actions.TransferNFT:
+				c.metrics.transferNFT.Inc()
+				actor := auth.GetActor(tx.Auth)
+				nft := actions.NFTID(action.Collection, action.ID)
+				if err := c.moveNFTOwner(ctx, batch, minted, nft, actor, action.To); err != nil {
+					return err
+				}
+			case *actions.BurnNFT:
+				c.metrics.burnNFT.Inc()
+				actor := auth.GetActor(tx.Auth)
+				nft := actions.NFTID(action.Collection, action.ID)
+				if err := storage.DeleteNFTOwner(ctx, batch, actor, nft); err != nil {
+					return err
+				}
+			case *actions.CreateStream:
+				c.metrics.createStream.Inc()
+			case *actions.WithdrawStream:
+				c.metrics.withdrawStream.Inc()
+			case *actions.CancelStream:
+				c.metrics.cancelStream.Inc()
+			case *actions.CreateAirdrop:
+				c.metrics.createAirdrop.Inc()
+			case *actions.ClaimAirdrop:
+				c.metrics.claimAirdrop.Inc()
+			case *actions.ReclaimAirdrop:
+				c.metrics.reclaimAirdrop.Inc()
+			case *actions.CreateEscrow:
+				c.metrics.createEscrow.Inc()
+			case *actions.ResolveEscrow:
+				c.metrics.resolveEscrow.Inc()
+			case *actions.CreateFeed:
+				c.metrics.createFeed.Inc()
+			case *actions.UpdateFeed:
+				c.metrics.updateFeed.Inc()
+				if err := storage.StoreFeedPrice(ctx, batch, action.Feed, blk.GetTimestamp(), action.Price); err != nil {
+					return err
+				}
+			case *actions.CreateTriggerOrder:
+				c.metrics.createTriggerOrder.Inc()
+				if err := storage.StoreTriggerOrder(ctx, batch, tx.ID()); err != nil {
+					return err
+				}
+			case *actions.TriggerOrder:
+				c.metrics.triggerOrder.Inc()
+				orderResult, err := actions.UnmarshalOrderResult(result.Output)
+				if err != nil {
+					// This should never happen
+					return err
+				}
+				if err := storage.RemoveTriggerOrder(ctx, batch, action.Trigger); err != nil {
+					return err
+				}
+				if err := c.moveNFTOwner(ctx, batch, minted, action.In, action.Owner, action.OrderOwner); err != nil {
+					return err
+				}
+				if err := c.moveNFTOwner(ctx, batch, minted, action.Out, action.OrderOwner, action.Owner); err != nil {
+					return err
+				}
+				if orderResult.Remaining == 0 {
+					c.orderBook.Remove(action.Order)
+					continue
+				}
+				c.orderBook.UpdateRemaining(action.Order, orderResult.Remaining)
+			case *actions.CancelTriggerOrder:
+				c.metrics.cancelTriggerOrder.Inc()
+				if err := storage.RemoveTriggerOrder(ctx, batch, action.Trigger); err != nil {
+					return err
+				}
+			case *actions.SettleFees:
+				c.metrics.settleFees.Inc()
 			}
 		}
 	}
 	return batch.Write()
 }
 
+// moveNFTOwner moves [asset] from [from] to [to] in the NFT owner index if it
+// is an NFT.
+func (c *Controller) moveNFTOwner(
+	ctx context.Context,
+	batch database.Batch,
+	minted set.Set[ids.ID],
+	asset ids.ID,
+	from crypto.PublicKey,
+	to crypto.PublicKey,
+) error {
+	isNFT := minted.Contains(asset)
+	if !isNFT {
+		var err error
+		isNFT, err = storage.IsNFT(ctx, c.metaDB, asset)
+		if err != nil {
+			return err
+		}
+	}
+	if !isNFT {
+		return nil
+	}
+	if err := storage.DeleteNFTOwner(ctx, batch, from, asset); err != nil {
+		return err
+	}
+	return storage.StoreNFTOwner(ctx, batch, to, asset)
+}
+
 func (*Controller) Rejected(context.Context, *chain.StatelessBlock) error {
 	return nil
 }
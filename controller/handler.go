@@ -74,7 +74,7 @@ func (h *Handler) Asset(req *http.Request, args *AssetArgs, reply *AssetReply) e
 	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Asset")
 	defer span.End()
 
-	exists, metadata, supply, owner, warp, err := storage.GetAssetFromState(
+	exists, metadata, supply, owner, warp, _, err := storage.GetAssetFromState(
 		ctx,
 		h.c.inner.ReadState,
 		args.Asset,
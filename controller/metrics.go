@@ -25,6 +25,72 @@ type metrics struct {
 
 	importAsset prometheus.Counter
 	exportAsset prometheus.Counter
+
+	sendMessage    prometheus.Counter
+	receiveMessage prometheus.Counter
+
+	batchExportAsset prometheus.Counter
+	batchImportAsset prometheus.Counter
+
+	setWarpDestinations prometheus.Counter
+
+	pauseAsset    prometheus.Counter
+	freezeAccount prometheus.Counter
+	clawbackAsset prometheus.Counter
+
+	setAllowlistMode prometheus.Counter
+	setAllowlist     prometheus.Counter
+
+	createVesting prometheus.Counter
+	claimVested   prometheus.Counter
+
+	propose         prometheus.Counter
+	vote            prometheus.Counter
+	executeProposal prometheus.Counter
+	withdrawVote    prometheus.Counter
+	reclaimDeposit  prometheus.Counter
+
+	swapOffer prometheus.Counter
+
+	setSponsorRate prometheus.Counter
+
+	registerSession prometheus.Counter
+	revokeSession   prometheus.Counter
+
+	setGuardians     prometheus.Counter
+	initiateRecovery prometheus.Counter
+	cancelRecovery   prometheus.Counter
+	completeRecovery prometheus.Counter
+
+	registerName prometheus.Counter
+	updateName   prometheus.Counter
+	transferName prometheus.Counter
+	renewName    prometheus.Counter
+
+	createCollection prometheus.Counter
+	mintNFT          prometheus.Counter
+	transferNFT      prometheus.Counter
+	burnNFT          prometheus.Counter
+
+	createStream   prometheus.Counter
+	withdrawStream prometheus.Counter
+	cancelStream   prometheus.Counter
+
+	createAirdrop  prometheus.Counter
+	claimAirdrop   prometheus.Counter
+	reclaimAirdrop prometheus.Counter
+
+	createEscrow  prometheus.Counter
+	resolveEscrow prometheus.Counter
+
+	createFeed prometheus.Counter
+	updateFeed prometheus.Counter
+
+	createTriggerOrder prometheus.Counter
+	triggerOrder       prometheus.Counter
+	cancelTriggerOrder prometheus.Counter
+
+	settleFees prometheus.Counter
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
@@ -79,6 +145,241 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "export_asset",
 			Help:      "number of export asset actions",
 		}),
+		sendMessage: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "send_message",
+			Help:      "number of send message actions",
+		}),
+		receiveMessage: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "receive_message",
+			Help:      "number of receive message actions",
+		}),
+		batchExportAsset: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "batch_export_asset",
+			Help:      "number of batch export asset actions",
+		}),
+		batchImportAsset: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "batch_import_asset",
+			Help:      "number of batch import asset actions",
+		}),
+		setWarpDestinations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_warp_destinations",
+			Help:      "number of set warp destinations actions",
+		}),
+		pauseAsset: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "pause_asset",
+			Help:      "number of pause asset actions",
+		}),
+		freezeAccount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "freeze_account",
+			Help:      "number of freeze account actions",
+		}),
+		clawbackAsset: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "clawback_asset",
+			Help:      "number of clawback asset actions",
+		}),
+		setAllowlistMode: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_allowlist_mode",
+			Help:      "number of set allowlist mode actions",
+		}),
+		setAllowlist: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_allowlist",
+			Help:      "number of set allowlist actions",
+		}),
+		createVesting: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_vesting",
+			Help:      "number of create vesting actions",
+		}),
+		claimVested: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_vested",
+			Help:      "number of claim vested actions",
+		}),
+		propose: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "propose",
+			Help:      "number of propose actions",
+		}),
+		vote: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "vote",
+			Help:      "number of vote actions",
+		}),
+		executeProposal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "execute_proposal",
+			Help:      "number of execute proposal actions",
+		}),
+		withdrawVote: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "withdraw_vote",
+			Help:      "number of withdraw vote actions",
+		}),
+		reclaimDeposit: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "reclaim_deposit",
+			Help:      "number of reclaim deposit actions",
+		}),
+		swapOffer: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "swap_offer",
+			Help:      "number of swap offer actions",
+		}),
+		setSponsorRate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_sponsor_rate",
+			Help:      "number of set sponsor rate actions",
+		}),
+		registerSession: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "register_session",
+			Help:      "number of register session actions",
+		}),
+		revokeSession: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "revoke_session",
+			Help:      "number of revoke session actions",
+		}),
+		setGuardians: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_guardians",
+			Help:      "number of set guardians actions",
+		}),
+		initiateRecovery: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "initiate_recovery",
+			Help:      "number of initiate recovery actions",
+		}),
+		cancelRecovery: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "cancel_recovery",
+			Help:      "number of cancel recovery actions",
+		}),
+		completeRecovery: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "complete_recovery",
+			Help:      "number of complete recovery actions",
+		}),
+		registerName: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "register_name",
+			Help:      "number of register name actions",
+		}),
+		updateName: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "update_name",
+			Help:      "number of update name actions",
+		}),
+		transferName: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "transfer_name",
+			Help:      "number of transfer name actions",
+		}),
+		renewName: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "renew_name",
+			Help:      "number of renew name actions",
+		}),
+		createCollection: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_collection",
+			Help:      "number of create collection actions",
+		}),
+		mintNFT: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "mint_nft",
+			Help:      "number of mint nft actions",
+		}),
+		transferNFT: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "transfer_nft",
+			Help:      "number of transfer nft actions",
+		}),
+		burnNFT: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "burn_nft",
+			Help:      "number of burn nft actions",
+		}),
+		createStream: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_stream",
+			Help:      "number of create stream actions",
+		}),
+		withdrawStream: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "withdraw_stream",
+			Help:      "number of withdraw stream actions",
+		}),
+		cancelStream: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "cancel_stream",
+			Help:      "number of cancel stream actions",
+		}),
+		createAirdrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_airdrop",
+			Help:      "number of create airdrop actions",
+		}),
+		claimAirdrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_airdrop",
+			Help:      "number of claim airdrop actions",
+		}),
+		reclaimAirdrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "reclaim_airdrop",
+			Help:      "number of reclaim airdrop actions",
+		}),
+		createEscrow: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_escrow",
+			Help:      "number of create escrow actions",
+		}),
+		resolveEscrow: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "resolve_escrow",
+			Help:      "number of resolve escrow actions",
+		}),
+		createFeed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_feed",
+			Help:      "number of create feed actions",
+		}),
+		updateFeed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "update_feed",
+			Help:      "number of update feed actions",
+		}),
+		createTriggerOrder: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_trigger_order",
+			Help:      "number of create trigger order actions",
+		}),
+		triggerOrder: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "trigger_order",
+			Help:      "number of trigger order actions",
+		}),
+		cancelTriggerOrder: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "cancel_trigger_order",
+			Help:      "number of cancel trigger order actions",
+		}),
+		settleFees: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "settle_fees",
+			Help:      "number of settle fees actions",
+		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
@@ -96,6 +397,72 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 
 		r.Register(m.importAsset),
 		r.Register(m.exportAsset),
+
+		r.Register(m.sendMessage),
+		r.Register(m.receiveMessage),
+
+		r.Register(m.batchExportAsset),
+		r.Register(m.batchImportAsset),
+
+		r.Register(m.setWarpDestinations),
+
+		r.Register(m.pauseAsset),
+		r.Register(m.freezeAccount),
+		r.Register(m.clawbackAsset),
+
+		r.Register(m.setAllowlistMode),
+		r.Register(m.setAllowlist),
+
+		r.Register(m.createVesting),
+		r.Register(m.claimVested),
+
+		r.Register(m.propose),
+		r.Register(m.vote),
+		r.Register(m.executeProposal),
+		r.Register(m.withdrawVote),
+		r.Register(m.reclaimDeposit),
+
+		r.Register(m.swapOffer),
+
+		r.Register(m.setSponsorRate),
+
+		r.Register(m.registerSession),
+		r.Register(m.revokeSession),
+
+		r.Register(m.setGuardians),
+		r.Register(m.initiateRecovery),
+		r.Register(m.cancelRecovery),
+		r.Register(m.completeRecovery),
+
+		r.Register(m.registerName),
+		r.Register(m.updateName),
+		r.Register(m.transferName),
+		r.Register(m.renewName),
+
+		r.Register(m.createCollection),
+		r.Register(m.mintNFT),
+		r.Register(m.transferNFT),
+		r.Register(m.burnNFT),
+
+		r.Register(m.createStream),
+		r.Register(m.withdrawStream),
+		r.Register(m.cancelStream),
+
+		r.Register(m.createAirdrop),
+		r.Register(m.claimAirdrop),
+		r.Register(m.reclaimAirdrop),
+
+		r.Register(m.createEscrow),
+		r.Register(m.resolveEscrow),
+
+		r.Register(m.createFeed),
+		r.Register(m.updateFeed),
+
+		r.Register(m.createTriggerOrder),
+		r.Register(m.triggerOrder),
+		r.Register(m.cancelTriggerOrder),
+
+		r.Register(m.settleFees),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
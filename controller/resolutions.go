@@ -6,6 +6,7 @@ package controller
 import (
 	"context"
 
+	"tokenvm/actions"
 	"tokenvm/genesis"
 	"tokenvm/orderbook"
 	"tokenvm/storage"
@@ -38,7 +39,7 @@ func (c *Controller) GetTransaction(
 func (c *Controller) GetAssetFromState(
 	ctx context.Context,
 	asset ids.ID,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, []byte, uint64, crypto.PublicKey, bool, uint8, error) {
 	return storage.GetAssetFromState(ctx, c.inner.ReadState, asset)
 }
 
@@ -61,3 +62,210 @@ func (c *Controller) GetLoanFromState(
 ) (uint64, error) {
 	return storage.GetLoanFromState(ctx, c.inner.ReadState, asset, destination)
 }
+
+func (c *Controller) GetLoansFromState(
+	ctx context.Context,
+	asset ids.ID,
+) ([]ids.ID, []uint64, bool, error) {
+	return storage.GetLoansFromState(ctx, c.inner.ReadState, asset)
+}
+
+func (c *Controller) GetFrozenFromState(
+	ctx context.Context,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) (bool, error) {
+	return storage.GetFrozenFromState(ctx, c.inner.ReadState, asset, owner)
+}
+
+func (c *Controller) GetAllowlistedFromState(
+	ctx context.Context,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) (bool, error) {
+	return storage.GetAllowlistedFromState(ctx, c.inner.ReadState, asset, owner)
+}
+
+func (c *Controller) GetVestingFromState(
+	ctx context.Context,
+	vesting ids.ID,
+) (bool, ids.ID, crypto.PublicKey, uint64, int64, int64, int64, uint64, error) {
+	return storage.GetVestingFromState(ctx, c.inner.ReadState, vesting)
+}
+
+func (c *Controller) GetProposals(ctx context.Context) ([]ids.ID, error) {
+	return storage.GetProposals(ctx, c.metaDB)
+}
+
+func (c *Controller) GetProposalFromState(
+	ctx context.Context,
+	proposal ids.ID,
+) (bool, crypto.PublicKey, uint8, uint64, int64, uint64, uint64, uint64, bool, error) {
+	return storage.GetProposalFromState(ctx, c.inner.ReadState, proposal)
+}
+
+func (c *Controller) GetProposalPairFromState(
+	ctx context.Context,
+	proposal ids.ID,
+) (ids.ID, ids.ID, error) {
+	return storage.GetProposalPairFromState(ctx, c.inner.ReadState, proposal)
+}
+
+func (c *Controller) GetParamFromState(
+	ctx context.Context,
+	param uint8,
+	in ids.ID,
+	out ids.ID,
+) (bool, uint64, uint64, int64, error) {
+	return storage.GetParamFromState(ctx, c.inner.ReadState, actions.ParamKey(param, in, out))
+}
+
+func (c *Controller) GetWarpDestinationsFromState(
+	ctx context.Context,
+	asset ids.ID,
+) ([]ids.ID, error) {
+	return storage.GetWarpDestinationsFromState(ctx, c.inner.ReadState, asset)
+}
+
+func (c *Controller) GetMessageFromState(
+	ctx context.Context,
+	source ids.ID,
+	txID ids.ID,
+) (bool, crypto.PublicKey, crypto.PublicKey, []byte, error) {
+	return storage.GetMessageFromState(ctx, c.inner.ReadState, source, txID)
+}
+
+func (c *Controller) GetSponsorRateFromState(
+	ctx context.Context,
+	sponsor crypto.PublicKey,
+	asset ids.ID,
+) (uint64, error) {
+	return storage.GetSponsorRateFromState(ctx, c.inner.ReadState, sponsor, asset)
+}
+
+func (c *Controller) GetSessionFromState(
+	ctx context.Context,
+	owner crypto.PublicKey,
+	key crypto.PublicKey,
+) (bool, int64, []uint8, []ids.ID, []uint64, error) {
+	return storage.GetSessionFromState(ctx, c.inner.ReadState, owner, key)
+}
+
+func (c *Controller) GetGuardiansFromState(
+	ctx context.Context,
+	account crypto.PublicKey,
+) (uint8, int64, uint64, []crypto.PublicKey, error) {
+	return storage.GetGuardiansFromState(ctx, c.inner.ReadState, account)
+}
+
+func (c *Controller) GetRecoveryFromState(
+	ctx context.Context,
+	account crypto.PublicKey,
+) (bool, crypto.PublicKey, int64, error) {
+	return storage.GetRecoveryFromState(ctx, c.inner.ReadState, account)
+}
+
+func (c *Controller) GetAccountKeyFromState(
+	ctx context.Context,
+	account crypto.PublicKey,
+) (crypto.PublicKey, error) {
+	return storage.GetAccountKeyFromState(ctx, c.inner.ReadState, account)
+}
+
+func (c *Controller) GetNameFromState(
+	ctx context.Context,
+	name string,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, int64, error) {
+	return storage.GetNameFromState(ctx, c.inner.ReadState, name)
+}
+
+func (c *Controller) GetReverseNameFromState(
+	ctx context.Context,
+	target [32]byte,
+) (string, error) {
+	return storage.GetReverseNameFromState(ctx, c.inner.ReadState, target)
+}
+
+func (c *Controller) GetCollectionFromState(
+	ctx context.Context,
+	collection ids.ID,
+) (bool, crypto.PublicKey, uint64, []byte, error) {
+	return storage.GetCollectionFromState(ctx, c.inner.ReadState, collection)
+}
+
+func (c *Controller) GetNFTFromState(
+	ctx context.Context,
+	nft ids.ID,
+) (bool, ids.ID, uint64, crypto.PublicKey, error) {
+	return storage.GetNFTFromState(ctx, c.inner.ReadState, nft)
+}
+
+func (c *Controller) GetNFTsByOwner(ctx context.Context, owner crypto.PublicKey) ([]ids.ID, error) {
+	return storage.GetNFTsByOwner(ctx, c.metaDB, owner)
+}
+
+func (c *Controller) GetStreamFromState(
+	ctx context.Context,
+	stream ids.ID,
+) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, uint64, int64, int64, uint64, error) {
+	return storage.GetStreamFromState(ctx, c.inner.ReadState, stream)
+}
+
+func (c *Controller) GetAirdropFromState(
+	ctx context.Context,
+	airdrop ids.ID,
+) (bool, ids.ID, crypto.PublicKey, ids.ID, int64, uint64, error) {
+	return storage.GetAirdropFromState(ctx, c.inner.ReadState, airdrop)
+}
+
+func (c *Controller) GetAirdropClaimedFromState(
+	ctx context.Context,
+	airdrop ids.ID,
+	recipient crypto.PublicKey,
+) (bool, error) {
+	return storage.GetAirdropClaimedFromState(ctx, c.inner.ReadState, airdrop, recipient)
+}
+
+func (c *Controller) GetEscrowFromState(
+	ctx context.Context,
+	escrow ids.ID,
+) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, crypto.PublicKey, uint64, int64, bool, error) {
+	return storage.GetEscrowFromState(ctx, c.inner.ReadState, escrow)
+}
+
+func (c *Controller) GetFeedFromState(
+	ctx context.Context,
+	feed ids.ID,
+) (bool, crypto.PublicKey, uint8, uint64, int64, []byte, error) {
+	return storage.GetFeedFromState(ctx, c.inner.ReadState, feed)
+}
+
+func (c *Controller) GetFeedHistory(ctx context.Context, feed ids.ID, limit int) ([]int64, []uint64, error) {
+	return storage.GetFeedHistory(ctx, c.metaDB, feed, limit)
+}
+
+func (c *Controller) GetTriggerOrders(ctx context.Context) ([]ids.ID, error) {
+	return storage.GetTriggerOrders(ctx, c.metaDB)
+}
+
+func (c *Controller) GetTriggerOrderFromState(
+	ctx context.Context,
+	trigger ids.ID,
+) (bool, ids.ID, ids.ID, crypto.PublicKey, uint64, uint64, uint64, ids.ID, uint64, uint64, bool, error) {
+	return storage.GetTriggerOrderFromState(ctx, c.inner.ReadState, trigger)
+}
+
+func (c *Controller) GetLastTradeFromState(
+	ctx context.Context,
+	in ids.ID,
+	out ids.ID,
+) (bool, uint64, uint64, error) {
+	return storage.GetLastTradeFromState(ctx, c.inner.ReadState, in, out)
+}
+
+func (c *Controller) GetFeePoolFromState(
+	ctx context.Context,
+	asset ids.ID,
+) (uint64, uint64, uint64, error) {
+	return storage.GetFeePoolFromState(ctx, c.inner.ReadState, asset)
+}
@@ -8,4 +8,17 @@ import "errors"
 var (
 	ErrInvalidTarget      = errors.New("invalid target")
 	ErrStateLockupMissing = errors.New("state lockup parameter missing")
+	ErrInvalidAsset       = errors.New("invalid asset")
+	ErrDuplicateAsset     = errors.New("duplicate asset")
+	ErrAssetMissing       = errors.New("asset missing")
+	ErrInvalidLockup      = errors.New("invalid lockup")
+
+	ErrUnknownAction         = errors.New("unknown action")
+	ErrInvalidUpgradeTime    = errors.New("invalid upgrade timestamp")
+	ErrConflictingActivation = errors.New("action enabled and disabled in same upgrade")
+	ErrInvalidGovernance     = errors.New("invalid governance config")
+	ErrInvalidTreasury       = errors.New("invalid treasury config")
+	ErrInvalidTradingFees    = errors.New("invalid trading fees")
+	ErrDuplicatePair         = errors.New("duplicate pair")
+	ErrInvalidNameService    = errors.New("invalid name service config")
 )
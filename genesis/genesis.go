@@ -5,6 +5,7 @@ package genesis
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 
@@ -12,20 +13,57 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	smath "github.com/ava-labs/avalanchego/utils/math"
 
+	"tokenvm/actions"
 	"tokenvm/consts"
 	"tokenvm/storage"
 	"tokenvm/utils"
 
 	"github.com/ava-labs/hypersdk/chain"
+	hconsts "github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
+	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
 )
 
 var _ vm.Genesis = (*Genesis)(nil)
 
+var (
+	assetIDPrefix  = []byte("genesis/asset/")
+	lockupIDPrefix = []byte("genesis/lockup/")
+)
+
+type CustomAsset struct {
+	Metadata string `json:"metadata"`
+	Owner    string `json:"owner,omitempty"` // bech32 address, empty for no owner
+}
+
+// Lockup releases an allocation linearly over [Duration] seconds starting at
+// [Start], with nothing claimable until [Cliff] seconds have passed.
+type Lockup struct {
+	Start    int64 `json:"start"`    // unix seconds
+	Cliff    int64 `json:"cliff"`    // seconds after start
+	Duration int64 `json:"duration"` // seconds
+}
+
 type CustomAllocation struct {
 	Address string `json:"address"` // bech32 address
 	Balance uint64 `json:"balance"`
+
+	// Asset is the metadata of an entry in [CustomAssets]. If empty, the
+	// allocation is of the native asset.
+	Asset string `json:"asset,omitempty"`
+
+	// Lockup, if provided, escrows [Balance] in a vesting record that can be
+	// claimed with [actions.ClaimVested] using [LockupID].
+	Lockup *Lockup `json:"lockup,omitempty"`
+}
+
+// PairFee overrides the trading fees (in basis points) of a single pair.
+type PairFee struct {
+	Pair        string `json:"pair"` // [actions.PairID]
+	MakerFee    uint64 `json:"makerFee"`
+	TakerFee    uint64 `json:"takerFee"`
+	MakerRebate uint64 `json:"makerRebate"`
 }
 
 type Genesis struct {
@@ -54,6 +92,44 @@ type Genesis struct {
 	WarpBaseFee      uint64 `json:"warpBaseFee"`
 	WarpFeePerSigner uint64 `json:"warpFeePerSigner"`
 
+	// Treasury (all fees are burned if [Treasury] is empty). Fees are collected
+	// in a fee pool and sent to [Treasury] when it is settled.
+	Treasury         string `json:"treasury,omitempty"` // bech32 address
+	TreasuryFeeShare uint64 `json:"treasuryFeeShare"`   // basis points of fees
+	TreasuryTakerFee uint64 `json:"treasuryTakerFee"`   // basis points of fills
+
+	// Trading fees (in basis points), which can be overridden for each pair in
+	// [PairFees]. Fees are sent to [FeeRecipient] (or [Treasury] if empty).
+	FeeRecipient string     `json:"feeRecipient,omitempty"` // bech32 address
+	MakerFee     uint64     `json:"makerFee"`
+	TakerFee     uint64     `json:"takerFee"`
+	MakerRebate  uint64     `json:"makerRebate"`
+	PairFees     []*PairFee `json:"pairFees,omitempty"`
+
+	// Governance (disabled if [GovernanceVotingPeriod] is 0). Proposals lock
+	// [GovernanceDeposit] of the native asset until they are executed or
+	// expire.
+	GovernanceVotingPeriod    int64  `json:"governanceVotingPeriod"`    // seconds
+	GovernanceTimelock        int64  `json:"governanceTimelock"`        // seconds
+	GovernanceActivationDelay int64  `json:"governanceActivationDelay"` // seconds
+	GovernanceQuorum          uint64 `json:"governanceQuorum"`
+	GovernanceDeposit         uint64 `json:"governanceDeposit"`
+	GovernanceExpiry          int64  `json:"governanceExpiry"` // seconds
+
+	// Name service (disabled if [NamePeriod] is 0). Registrations and renewals
+	// cost [NameFee], which is sent to [Treasury] (or burned if empty).
+	NameFee    uint64 `json:"nameFee"`
+	NamePeriod int64  `json:"namePeriod"` // seconds
+
+	// Actions that are disabled until enabled by an upgrade
+	DisabledActions []string `json:"disabledActions,omitempty"`
+
+	// Upgrade schedule, populated from [upgradeBytes]
+	Upgrades []*Upgrade `json:"upgrades,omitempty"`
+
+	// Assets
+	CustomAssets []*CustomAsset `json:"customAssets,omitempty"`
+
 	// Allocations
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
 }
@@ -83,54 +159,156 @@ func Default() *Genesis {
 		// Warp pricing
 		WarpBaseFee:      1_024,
 		WarpFeePerSigner: 128,
+
+		// Governance
+		GovernanceTimelock:        86_400, // 1 day
+		GovernanceActivationDelay: 60,
+		GovernanceDeposit:         100_000_000_000,
+		GovernanceExpiry:          604_800, // 7 days
+
+		// Name service
+		NameFee:    1_000,
+		NamePeriod: 31_536_000, // 365 days
 	}
 }
 
-func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
+func New(b []byte, upgradeBytes []byte) (*Genesis, error) {
 	g := Default()
 	if len(b) > 0 {
 		if err := json.Unmarshal(b, g); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
 		}
 	}
-	if g.WindowTargetUnits == 0 {
-		return nil, ErrInvalidTarget
+	if len(upgradeBytes) > 0 {
+		upgrades, err := parseUpgrades(upgradeBytes)
+		if err != nil {
+			return nil, err
+		}
+		g.Upgrades = upgrades
 	}
-	if g.WindowTargetBlocks == 0 {
-		return nil, ErrInvalidTarget
+	if err := g.verify(); err != nil {
+		return nil, err
 	}
 	return g, nil
 }
 
+// TreasuryAddress returns the account that receives a share of fees, or
+// [crypto.EmptyPublicKey] if fees are burned.
+func (g *Genesis) TreasuryAddress() (crypto.PublicKey, error) {
+	if len(g.Treasury) == 0 {
+		return crypto.EmptyPublicKey, nil
+	}
+	return utils.ParseAddress(g.Treasury)
+}
+
+// FeeRecipientAddress returns the account that receives trading fees, or
+// [crypto.EmptyPublicKey] if no trading fees are charged.
+func (g *Genesis) FeeRecipientAddress() (crypto.PublicKey, error) {
+	if len(g.FeeRecipient) == 0 {
+		return g.TreasuryAddress()
+	}
+	return utils.ParseAddress(g.FeeRecipient)
+}
+
 func (g *Genesis) GetHRP() string {
 	return g.HRP
 }
 
+// AssetID returns the ID of the genesis asset created with [metadata].
+func AssetID(metadata string) ids.ID {
+	return hutils.ToID(append(append([]byte{}, assetIDPrefix...), metadata...))
+}
+
+// LockupID returns the ID of the vesting record created for the allocation at
+// [index] in [CustomAllocation].
+func LockupID(index int) ids.ID {
+	k := make([]byte, len(lockupIDPrefix)+hconsts.Uint64Len)
+	copy(k, lockupIDPrefix)
+	binary.BigEndian.PutUint64(k[len(lockupIDPrefix):], uint64(index))
+	return hutils.ToID(k)
+}
+
 func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, db chain.Database) error {
 	ctx, span := tracer.Start(ctx, "Genesis.Load")
 	defer span.End()
 
-	supply := uint64(0)
-	for _, alloc := range g.CustomAllocation {
+	assets := map[string]ids.ID{"": ids.Empty}
+	owners := map[ids.ID]crypto.PublicKey{ids.Empty: crypto.EmptyPublicKey}
+	for _, asset := range g.CustomAssets {
+		if len(asset.Metadata) == 0 || len(asset.Metadata) > actions.MaxMetadataSize {
+			return fmt.Errorf("%w: %q", ErrInvalidAsset, asset.Metadata)
+		}
+		if _, ok := assets[asset.Metadata]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateAsset, asset.Metadata)
+		}
+		owner := crypto.EmptyPublicKey
+		if len(asset.Owner) > 0 {
+			pk, err := utils.ParseAddress(asset.Owner)
+			if err != nil {
+				return err
+			}
+			owner = pk
+		}
+		assetID := AssetID(asset.Metadata)
+		assets[asset.Metadata] = assetID
+		owners[assetID] = owner
+	}
+
+	supplies := map[ids.ID]uint64{}
+	for i, alloc := range g.CustomAllocation {
 		pk, err := utils.ParseAddress(alloc.Address)
 		if err != nil {
 			return err
 		}
-		supply, err = smath.Add64(supply, alloc.Balance)
+		assetID, ok := assets[alloc.Asset]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrAssetMissing, alloc.Asset)
+		}
+		supplies[assetID], err = smath.Add64(supplies[assetID], alloc.Balance)
 		if err != nil {
 			return err
 		}
-		if err := storage.SetBalance(ctx, db, pk, ids.Empty, alloc.Balance); err != nil {
+		if alloc.Lockup != nil {
+			l := alloc.Lockup
+			if alloc.Balance == 0 || !actions.ValidVestingSchedule(l.Start, l.Cliff, l.Duration) {
+				return fmt.Errorf("%w: addr=%s", ErrInvalidLockup, alloc.Address)
+			}
+			if err := storage.SetVesting(
+				ctx,
+				db,
+				LockupID(i),
+				assetID,
+				pk,
+				alloc.Balance,
+				l.Start,
+				l.Cliff,
+				l.Duration,
+				0,
+			); err != nil {
+				return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, alloc.Balance)
+			}
+			continue
+		}
+		if err := storage.AddBalance(ctx, db, pk, assetID, alloc.Balance); err != nil {
 			return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, alloc.Balance)
 		}
 	}
-	return storage.SetAsset(
-		ctx,
-		db,
-		ids.Empty,
-		[]byte(consts.Symbol),
-		supply,
-		crypto.EmptyPublicKey,
-		false,
-	)
+	for metadata, assetID := range assets {
+		if assetID == ids.Empty {
+			metadata = consts.Symbol
+		}
+		if err := storage.SetAsset(
+			ctx,
+			db,
+			assetID,
+			[]byte(metadata),
+			supplies[assetID],
+			owners[assetID],
+			false,
+			0,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -6,17 +6,45 @@ package genesis
 import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
+
+	"tokenvm/actions"
+	"tokenvm/auth"
 )
 
 var _ chain.Rules = (*Rules)(nil)
 
 type Rules struct {
 	g *Genesis
+	t int64
+
+	// base is the genesis before any upgrades are applied
+	base *Genesis
+
+	chainID ids.ID
+}
+
+// At returns the parameters in effect at [t] after applying every upgrade
+// activated at or before [t].
+func (g *Genesis) At(t int64) *Genesis {
+	params := *g
+	for _, u := range g.Upgrades {
+		if u.Timestamp > t {
+			break
+		}
+		u.apply(&params)
+	}
+	return &params
+}
+
+// Rules returns the [Rules] in effect at [t].
+func (g *Genesis) Rules(t int64) *Rules {
+	return &Rules{g: g.At(t), t: t, base: g}
 }
 
-// TODO: use upgradeBytes
-func (g *Genesis) Rules(int64) *Rules {
-	return &Rules{g}
+// WithChainID sets the ID of the chain returned for [actions.ChainIDKey].
+func (r *Rules) WithChainID(chainID ids.ID) *Rules {
+	r.chainID = chainID
+	return r
 }
 
 func (*Rules) GetWarpConfig(ids.ID) (bool, uint64, uint64) {
@@ -75,6 +103,71 @@ func (r *Rules) GetWindowTargetBlocks() uint64 {
 	return r.g.WindowTargetBlocks
 }
 
-func (*Rules) FetchCustom(string) (any, bool) {
-	return nil, false
+func (r *Rules) FetchCustom(key string) (any, bool) {
+	if key == actions.ChainIDKey {
+		return r.chainID, true
+	}
+	if key == actions.GovernanceKey {
+		if r.g.GovernanceVotingPeriod == 0 {
+			return nil, false
+		}
+		return &actions.GovernanceConfig{
+			VotingPeriod:    r.g.GovernanceVotingPeriod,
+			Timelock:        r.g.GovernanceTimelock,
+			ActivationDelay: r.g.GovernanceActivationDelay,
+			Quorum:          r.g.GovernanceQuorum,
+			Deposit:         r.g.GovernanceDeposit,
+			Expiry:          r.g.GovernanceExpiry,
+		}, true
+	}
+	if key == actions.TreasuryKey {
+		treasury, err := r.g.TreasuryAddress()
+		if err != nil {
+			return nil, false
+		}
+		recipient, err := r.g.FeeRecipientAddress()
+		if err != nil {
+			return nil, false
+		}
+		return &actions.TreasuryConfig{
+			Treasury:     treasury,
+			TakerFee:     r.g.TreasuryTakerFee,
+			FeeRecipient: recipient,
+		}, true
+	}
+	if key == auth.FeeShareKey {
+		return r.g.TreasuryFeeShare, true
+	}
+	if key == actions.NameServiceKey {
+		if r.g.NamePeriod == 0 {
+			return nil, false
+		}
+		return &actions.NameServiceConfig{
+			Fee:    r.g.NameFee,
+			Period: r.g.NamePeriod,
+		}, true
+	}
+	if pair, ok := actions.TradingFeesPair(key); ok {
+		for _, fee := range r.g.PairFees {
+			if fee.Pair == pair {
+				return &actions.TradingFees{
+					MakerFee:    fee.MakerFee,
+					TakerFee:    fee.TakerFee,
+					MakerRebate: fee.MakerRebate,
+					Pair:        true,
+				}, true
+			}
+		}
+		return &actions.TradingFees{
+			MakerFee:    r.g.MakerFee,
+			TakerFee:    r.g.TakerFee,
+			MakerRebate: r.g.MakerRebate,
+		}, true
+	}
+	name, ok := actions.ActivationName(key)
+	if !ok {
+		return nil, false
+	}
+	start, end := r.base.activation(name, r.t)
+	return &actions.ActivationWindow{Start: start, End: end}, true
 }
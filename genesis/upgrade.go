@@ -0,0 +1,288 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/ava-labs/hypersdk/crypto"
+
+	"tokenvm/actions"
+	"tokenvm/utils"
+)
+
+// Upgrade changes the rules of the chain starting at [Timestamp]. Any
+// parameter left unset keeps the value it had before the upgrade.
+type Upgrade struct {
+	Timestamp int64 `json:"timestamp"` // unix seconds
+
+	// Block params
+	MaxBlockTxs   *int    `json:"maxBlockTxs,omitempty"`
+	MaxBlockUnits *uint64 `json:"maxBlockUnits,omitempty"`
+
+	// Tx params
+	BaseUnits      *uint64 `json:"baseUnits,omitempty"`
+	ValidityWindow *int64  `json:"validityWindow,omitempty"`
+
+	// Unit pricing
+	MinUnitPrice               *uint64 `json:"minUnitPrice,omitempty"`
+	UnitPriceChangeDenominator *uint64 `json:"unitPriceChangeDenominator,omitempty"`
+	WindowTargetUnits          *uint64 `json:"windowTargetUnits,omitempty"`
+
+	// Block pricing
+	MinBlockCost               *uint64 `json:"minBlockCost,omitempty"`
+	BlockCostChangeDenominator *uint64 `json:"blockCostChangeDenominator,omitempty"`
+	WindowTargetBlocks         *uint64 `json:"windowTargetBlocks,omitempty"`
+
+	// Warp pricing
+	WarpBaseFee      *uint64 `json:"warpBaseFee,omitempty"`
+	WarpFeePerSigner *uint64 `json:"warpFeePerSigner,omitempty"`
+
+	// Treasury
+	TreasuryFeeShare *uint64 `json:"treasuryFeeShare,omitempty"`
+	TreasuryTakerFee *uint64 `json:"treasuryTakerFee,omitempty"`
+
+	// Trading fees
+	MakerFee    *uint64 `json:"makerFee,omitempty"`
+	TakerFee    *uint64 `json:"takerFee,omitempty"`
+	MakerRebate *uint64 `json:"makerRebate,omitempty"`
+
+	// Governance
+	GovernanceVotingPeriod    *int64  `json:"governanceVotingPeriod,omitempty"`
+	GovernanceTimelock        *int64  `json:"governanceTimelock,omitempty"`
+	GovernanceActivationDelay *int64  `json:"governanceActivationDelay,omitempty"`
+	GovernanceQuorum          *uint64 `json:"governanceQuorum,omitempty"`
+	GovernanceDeposit         *uint64 `json:"governanceDeposit,omitempty"`
+	GovernanceExpiry          *int64  `json:"governanceExpiry,omitempty"`
+
+	// Name service
+	NameFee    *uint64 `json:"nameFee,omitempty"`
+	NamePeriod *int64  `json:"namePeriod,omitempty"`
+
+	// Actions
+	EnableActions  []string `json:"enableActions,omitempty"`
+	DisableActions []string `json:"disableActions,omitempty"`
+}
+
+type upgradeConfig struct {
+	Upgrades []*Upgrade `json:"upgrades"`
+}
+
+func parseUpgrades(b []byte) ([]*Upgrade, error) {
+	var c upgradeConfig
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal upgrades %s: %w", string(b), err)
+	}
+	return c.Upgrades, nil
+}
+
+func (u *Upgrade) apply(g *Genesis) {
+	if u.MaxBlockTxs != nil {
+		g.MaxBlockTxs = *u.MaxBlockTxs
+	}
+	if u.MaxBlockUnits != nil {
+		g.MaxBlockUnits = *u.MaxBlockUnits
+	}
+	if u.BaseUnits != nil {
+		g.BaseUnits = *u.BaseUnits
+	}
+	if u.ValidityWindow != nil {
+		g.ValidityWindow = *u.ValidityWindow
+	}
+	if u.MinUnitPrice != nil {
+		g.MinUnitPrice = *u.MinUnitPrice
+	}
+	if u.UnitPriceChangeDenominator != nil {
+		g.UnitPriceChangeDenominator = *u.UnitPriceChangeDenominator
+	}
+	if u.WindowTargetUnits != nil {
+		g.WindowTargetUnits = *u.WindowTargetUnits
+	}
+	if u.MinBlockCost != nil {
+		g.MinBlockCost = *u.MinBlockCost
+	}
+	if u.BlockCostChangeDenominator != nil {
+		g.BlockCostChangeDenominator = *u.BlockCostChangeDenominator
+	}
+	if u.WindowTargetBlocks != nil {
+		g.WindowTargetBlocks = *u.WindowTargetBlocks
+	}
+	if u.WarpBaseFee != nil {
+		g.WarpBaseFee = *u.WarpBaseFee
+	}
+	if u.WarpFeePerSigner != nil {
+		g.WarpFeePerSigner = *u.WarpFeePerSigner
+	}
+	if u.TreasuryFeeShare != nil {
+		g.TreasuryFeeShare = *u.TreasuryFeeShare
+	}
+	if u.TreasuryTakerFee != nil {
+		g.TreasuryTakerFee = *u.TreasuryTakerFee
+	}
+	if u.MakerFee != nil {
+		g.MakerFee = *u.MakerFee
+	}
+	if u.TakerFee != nil {
+		g.TakerFee = *u.TakerFee
+	}
+	if u.MakerRebate != nil {
+		g.MakerRebate = *u.MakerRebate
+	}
+	if u.GovernanceVotingPeriod != nil {
+		g.GovernanceVotingPeriod = *u.GovernanceVotingPeriod
+	}
+	if u.GovernanceTimelock != nil {
+		g.GovernanceTimelock = *u.GovernanceTimelock
+	}
+	if u.GovernanceActivationDelay != nil {
+		g.GovernanceActivationDelay = *u.GovernanceActivationDelay
+	}
+	if u.GovernanceQuorum != nil {
+		g.GovernanceQuorum = *u.GovernanceQuorum
+	}
+	if u.GovernanceDeposit != nil {
+		g.GovernanceDeposit = *u.GovernanceDeposit
+	}
+	if u.GovernanceExpiry != nil {
+		g.GovernanceExpiry = *u.GovernanceExpiry
+	}
+	if u.NameFee != nil {
+		g.NameFee = *u.NameFee
+	}
+	if u.NamePeriod != nil {
+		g.NamePeriod = *u.NamePeriod
+	}
+}
+
+func validTradingFees(maker uint64, taker uint64, rebate uint64) bool {
+	return maker <= utils.MaxBasisPoints && taker <= utils.MaxBasisPoints && rebate <= taker
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func verifyActions(names []string) error {
+	for _, name := range names {
+		if !contains(actions.Names, name) {
+			return fmt.Errorf("%w: %s", ErrUnknownAction, name)
+		}
+	}
+	return nil
+}
+
+// verify ensures the genesis params are valid at every point in the upgrade
+// schedule.
+func (g *Genesis) verify() error {
+	if err := verifyActions(g.DisabledActions); err != nil {
+		return err
+	}
+	treasury, err := g.TreasuryAddress()
+	if err != nil {
+		return err
+	}
+	if _, err := g.FeeRecipientAddress(); err != nil {
+		return err
+	}
+	pairs := map[string]struct{}{}
+	for _, fee := range g.PairFees {
+		if _, ok := pairs[fee.Pair]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicatePair, fee.Pair)
+		}
+		pairs[fee.Pair] = struct{}{}
+		if !validTradingFees(fee.MakerFee, fee.TakerFee, fee.MakerRebate) {
+			return fmt.Errorf("%w: %s", ErrInvalidTradingFees, fee.Pair)
+		}
+	}
+	params := *g
+	last := int64(math.MinInt64)
+	for _, u := range append([]*Upgrade{nil}, g.Upgrades...) {
+		if u != nil {
+			if u.Timestamp <= last || u.Timestamp < 0 {
+				return fmt.Errorf("%w: %d", ErrInvalidUpgradeTime, u.Timestamp)
+			}
+			last = u.Timestamp
+			if err := verifyActions(u.EnableActions); err != nil {
+				return err
+			}
+			if err := verifyActions(u.DisableActions); err != nil {
+				return err
+			}
+			for _, name := range u.EnableActions {
+				if contains(u.DisableActions, name) {
+					return fmt.Errorf("%w: %s", ErrConflictingActivation, name)
+				}
+			}
+			u.apply(&params)
+		}
+		if params.WindowTargetUnits == 0 {
+			return ErrInvalidTarget
+		}
+		if !validTradingFees(params.MakerFee, params.TakerFee, params.MakerRebate) {
+			return ErrInvalidTradingFees
+		}
+		if params.WindowTargetBlocks == 0 {
+			return ErrInvalidTarget
+		}
+		if params.TreasuryFeeShare > utils.MaxBasisPoints || params.TreasuryTakerFee > utils.MaxBasisPoints {
+			return ErrInvalidTreasury
+		}
+		if treasury == crypto.EmptyPublicKey && (params.TreasuryFeeShare > 0 || params.TreasuryTakerFee > 0) {
+			return ErrInvalidTreasury
+		}
+		if params.GovernanceVotingPeriod < 0 || params.GovernanceTimelock < 0 ||
+			params.GovernanceActivationDelay < 0 || params.GovernanceExpiry < 0 {
+			return ErrInvalidGovernance
+		}
+		if params.GovernanceVotingPeriod > 0 && params.GovernanceExpiry == 0 {
+			// Proposals would expire as soon as they could be executed
+			return ErrInvalidGovernance
+		}
+		if params.NamePeriod < 0 {
+			return ErrInvalidNameService
+		}
+	}
+	return nil
+}
+
+// activation returns the window during which the action named [name] is
+// enabled, starting from the segment of the schedule that contains [t].
+func (g *Genesis) activation(name string, t int64) (int64, int64) {
+	enabled := !contains(g.DisabledActions, name)
+	start := int64(-1)
+	for _, u := range g.Upgrades {
+		var on bool
+		switch {
+		case contains(u.EnableActions, name):
+			on = true
+		case contains(u.DisableActions, name):
+			on = false
+		default:
+			continue
+		}
+		if on == enabled {
+			continue
+		}
+		if u.Timestamp <= t {
+			enabled = on
+			start = u.Timestamp
+			continue
+		}
+		if enabled {
+			return start, u.Timestamp - 1
+		}
+		return u.Timestamp, -1
+	}
+	if enabled {
+		return start, -1
+	}
+	return math.MaxInt64, -1
+}
@@ -36,8 +36,61 @@ func init() {
 		consts.ActionRegistry.Register(&actions.ImportAsset{}, actions.UnmarshalImportAsset, true),
 		consts.ActionRegistry.Register(&actions.ExportAsset{}, actions.UnmarshalExportAsset, false),
 
+		consts.ActionRegistry.Register(&actions.SendMessage{}, actions.UnmarshalSendMessage, false),
+		consts.ActionRegistry.Register(&actions.ReceiveMessage{}, actions.UnmarshalReceiveMessage, true),
+		consts.ActionRegistry.Register(&actions.BatchExportAsset{}, actions.UnmarshalBatchExportAsset, false),
+		consts.ActionRegistry.Register(&actions.BatchImportAsset{}, actions.UnmarshalBatchImportAsset, true),
+		consts.ActionRegistry.Register(&actions.SetWarpDestinations{}, actions.UnmarshalSetWarpDestinations, false),
+		consts.ActionRegistry.Register(&actions.PauseAsset{}, actions.UnmarshalPauseAsset, false),
+		consts.ActionRegistry.Register(&actions.FreezeAccount{}, actions.UnmarshalFreezeAccount, false),
+		consts.ActionRegistry.Register(&actions.ClawbackAsset{}, actions.UnmarshalClawbackAsset, false),
+		consts.ActionRegistry.Register(&actions.SetAllowlistMode{}, actions.UnmarshalSetAllowlistMode, false),
+		consts.ActionRegistry.Register(&actions.SetAllowlist{}, actions.UnmarshalSetAllowlist, false),
+		consts.ActionRegistry.Register(&actions.CreateVesting{}, actions.UnmarshalCreateVesting, false),
+		consts.ActionRegistry.Register(&actions.ClaimVested{}, actions.UnmarshalClaimVested, false),
+		consts.ActionRegistry.Register(&actions.Propose{}, actions.UnmarshalPropose, false),
+		consts.ActionRegistry.Register(&actions.Vote{}, actions.UnmarshalVote, false),
+		consts.ActionRegistry.Register(&actions.ExecuteProposal{}, actions.UnmarshalExecuteProposal, false),
+		consts.ActionRegistry.Register(&actions.WithdrawVote{}, actions.UnmarshalWithdrawVote, false),
+		consts.ActionRegistry.Register(&actions.SwapOffer{}, actions.UnmarshalSwapOffer, false),
+		consts.ActionRegistry.Register(&actions.SetSponsorRate{}, actions.UnmarshalSetSponsorRate, false),
+		consts.ActionRegistry.Register(&actions.RegisterSession{}, actions.UnmarshalRegisterSession, false),
+		consts.ActionRegistry.Register(&actions.RevokeSession{}, actions.UnmarshalRevokeSession, false),
+		consts.ActionRegistry.Register(&actions.SetGuardians{}, actions.UnmarshalSetGuardians, false),
+		consts.ActionRegistry.Register(&actions.InitiateRecovery{}, actions.UnmarshalInitiateRecovery, false),
+		consts.ActionRegistry.Register(&actions.CancelRecovery{}, actions.UnmarshalCancelRecovery, false),
+		consts.ActionRegistry.Register(&actions.CompleteRecovery{}, actions.UnmarshalCompleteRecovery, false),
+		consts.ActionRegistry.Register(&actions.RegisterName{}, actions.UnmarshalRegisterName, false),
+		consts.ActionRegistry.Register(&actions.UpdateName{}, actions.UnmarshalUpdateName, false),
+		consts.ActionRegistry.Register(&actions.TransferNameOwnership{}, actions.UnmarshalTransferNameOwnership, false),
+		consts.ActionRegistry.Register(&actions.RenewName{}, actions.UnmarshalRenewName, false),
+		consts.ActionRegistry.Register(&actions.CreateCollection{}, actions.UnmarshalCreateCollection, false),
+		consts.ActionRegistry.Register(&actions.MintNFT{}, actions.UnmarshalMintNFT, false),
+		consts.ActionRegistry.Register(&actions.TransferNFT{}, actions.UnmarshalTransferNFT, false),
+		consts.ActionRegistry.Register(&actions.BurnNFT{}, actions.UnmarshalBurnNFT, false),
+		consts.ActionRegistry.Register(&actions.CreateStream{}, actions.UnmarshalCreateStream, false),
+		consts.ActionRegistry.Register(&actions.WithdrawStream{}, actions.UnmarshalWithdrawStream, false),
+		consts.ActionRegistry.Register(&actions.CancelStream{}, actions.UnmarshalCancelStream, false),
+		consts.ActionRegistry.Register(&actions.CreateAirdrop{}, actions.UnmarshalCreateAirdrop, false),
+		consts.ActionRegistry.Register(&actions.ClaimAirdrop{}, actions.UnmarshalClaimAirdrop, false),
+		consts.ActionRegistry.Register(&actions.ReclaimAirdrop{}, actions.UnmarshalReclaimAirdrop, false),
+		consts.ActionRegistry.Register(&actions.CreateEscrow{}, actions.UnmarshalCreateEscrow, false),
+		consts.ActionRegistry.Register(&actions.ResolveEscrow{}, actions.UnmarshalResolveEscrow, false),
+		consts.ActionRegistry.Register(&actions.CreateFeed{}, actions.UnmarshalCreateFeed, false),
+		consts.ActionRegistry.Register(&actions.UpdateFeed{}, actions.UnmarshalUpdateFeed, false),
+		consts.ActionRegistry.Register(&actions.CreateTriggerOrder{}, actions.UnmarshalCreateTriggerOrder, false),
+		consts.ActionRegistry.Register(&actions.TriggerOrder{}, actions.UnmarshalTriggerOrder, false),
+		consts.ActionRegistry.Register(&actions.CancelTriggerOrder{}, actions.UnmarshalCancelTriggerOrder, false),
+		consts.ActionRegistry.Register(&actions.SettleFees{}, actions.UnmarshalSettleFees, false),
+		consts.ActionRegistry.Register(&actions.ReclaimDeposit{}, actions.UnmarshalReclaimDeposit, false),
+
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register(&auth.ED25519{}, auth.UnmarshalED25519, false),
+		consts.AuthRegistry.Register(&auth.Sponsored{}, auth.UnmarshalSponsored, false),
+		consts.AuthRegistry.Register(&auth.Delegated{}, auth.UnmarshalDelegated, false),
+		consts.AuthRegistry.Register(&auth.SECP256K1{}, auth.UnmarshalSECP256K1, false),
+		consts.AuthRegistry.Register(&auth.Session{}, auth.UnmarshalSession, false),
+		consts.AuthRegistry.Register(&auth.Recovered{}, auth.UnmarshalRecovered, false),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -6,5 +6,6 @@ package rpc
 const (
 	JSONRPCEndpoint = "/tokenapi"
 
-	ordersToSend = 128
+	ordersToSend     = 128
+	feedPricesToSend = 1024
 )
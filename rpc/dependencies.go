@@ -18,8 +18,38 @@ type Controller interface {
 	Genesis() *genesis.Genesis
 	Tracer() trace.Tracer
 	GetTransaction(context.Context, ids.ID) (bool, int64, bool, uint64, error)
-	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint64, crypto.PublicKey, bool, error)
+	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint64, crypto.PublicKey, bool, uint8, error)
 	GetBalanceFromState(context.Context, crypto.PublicKey, ids.ID) (uint64, error)
 	Orders(pair string, limit int) []*orderbook.Order
 	GetLoanFromState(context.Context, ids.ID, ids.ID) (uint64, error)
+	GetLoansFromState(context.Context, ids.ID) ([]ids.ID, []uint64, bool, error)
+	GetFrozenFromState(context.Context, ids.ID, crypto.PublicKey) (bool, error)
+	GetAllowlistedFromState(context.Context, ids.ID, crypto.PublicKey) (bool, error)
+	GetVestingFromState(context.Context, ids.ID) (bool, ids.ID, crypto.PublicKey, uint64, int64, int64, int64, uint64, error)
+	GetProposals(context.Context) ([]ids.ID, error)
+	GetProposalFromState(context.Context, ids.ID) (bool, crypto.PublicKey, uint8, uint64, int64, uint64, uint64, uint64, bool, error)
+	GetProposalPairFromState(context.Context, ids.ID) (ids.ID, ids.ID, error)
+	GetParamFromState(context.Context, uint8, ids.ID, ids.ID) (bool, uint64, uint64, int64, error)
+	GetWarpDestinationsFromState(context.Context, ids.ID) ([]ids.ID, error)
+	GetMessageFromState(context.Context, ids.ID, ids.ID) (bool, crypto.PublicKey, crypto.PublicKey, []byte, error)
+	GetSponsorRateFromState(context.Context, crypto.PublicKey, ids.ID) (uint64, error)
+	GetSessionFromState(context.Context, crypto.PublicKey, crypto.PublicKey) (bool, int64, []uint8, []ids.ID, []uint64, error)
+	GetGuardiansFromState(context.Context, crypto.PublicKey) (uint8, int64, uint64, []crypto.PublicKey, error)
+	GetRecoveryFromState(context.Context, crypto.PublicKey) (bool, crypto.PublicKey, int64, error)
+	GetAccountKeyFromState(context.Context, crypto.PublicKey) (crypto.PublicKey, error)
+	GetNameFromState(context.Context, string) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, int64, error)
+	GetReverseNameFromState(context.Context, [32]byte) (string, error)
+	GetCollectionFromState(context.Context, ids.ID) (bool, crypto.PublicKey, uint64, []byte, error)
+	GetNFTFromState(context.Context, ids.ID) (bool, ids.ID, uint64, crypto.PublicKey, error)
+	GetNFTsByOwner(context.Context, crypto.PublicKey) ([]ids.ID, error)
+	GetStreamFromState(context.Context, ids.ID) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, uint64, int64, int64, uint64, error)
+	GetAirdropFromState(context.Context, ids.ID) (bool, ids.ID, crypto.PublicKey, ids.ID, int64, uint64, error)
+	GetAirdropClaimedFromState(context.Context, ids.ID, crypto.PublicKey) (bool, error)
+	GetEscrowFromState(context.Context, ids.ID) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, crypto.PublicKey, uint64, int64, bool, error)
+	GetFeedFromState(context.Context, ids.ID) (bool, crypto.PublicKey, uint8, uint64, int64, []byte, error)
+	GetFeedHistory(context.Context, ids.ID, int) ([]int64, []uint64, error)
+	GetTriggerOrders(context.Context) ([]ids.ID, error)
+	GetTriggerOrderFromState(context.Context, ids.ID) (bool, ids.ID, ids.ID, crypto.PublicKey, uint64, uint64, uint64, ids.ID, uint64, uint64, bool, error)
+	GetLastTradeFromState(context.Context, ids.ID, ids.ID) (bool, uint64, uint64, error)
+	GetFeePoolFromState(context.Context, ids.ID) (uint64, uint64, uint64, error)
 }
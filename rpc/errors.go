@@ -6,6 +6,23 @@ package rpc
 import "errors"
 
 var (
-	ErrTxNotFound    = errors.New("tx not found")
-	ErrAssetNotFound = errors.New("asset not found")
+	ErrTxNotFound      = errors.New("tx not found")
+	ErrAssetNotFound   = errors.New("asset not found")
+	ErrVestingNotFound = errors.New("vesting not found")
+	ErrMessageNotFound = errors.New("message not found")
+
+	ErrTreasuryNotFound = errors.New("treasury not found")
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrNameNotFound     = errors.New("name not found")
+
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrNFTNotFound        = errors.New("nft not found")
+
+	ErrStreamNotFound = errors.New("stream not found")
+
+	ErrAirdropNotFound = errors.New("airdrop not found")
+
+	ErrEscrowNotFound = errors.New("escrow not found")
+
+	ErrFeedNotFound = errors.New("feed not found")
 )
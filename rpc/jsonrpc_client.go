@@ -76,7 +76,7 @@ func (cli *JSONRPCClient) Tx(ctx context.Context, id ids.ID) (bool, bool, int64,
 func (cli *JSONRPCClient) Asset(
 	ctx context.Context,
 	asset ids.ID,
-) (bool, []byte, uint64, string, bool, error) {
+) (bool, []byte, uint64, string, bool, bool, error) {
 	resp := new(AssetReply)
 	err := cli.requester.SendRequest(
 		ctx,
@@ -90,11 +90,11 @@ func (cli *JSONRPCClient) Asset(
 	// We use string parsing here because the JSON-RPC library we use may not
 	// allows us to perform errors.Is.
 	case err != nil && strings.Contains(err.Error(), ErrAssetNotFound.Error()):
-		return false, nil, 0, "", false, nil
+		return false, nil, 0, "", false, false, nil
 	case err != nil:
-		return false, nil, 0, "", false, err
+		return false, nil, 0, "", false, false, err
 	}
-	return true, resp.Metadata, resp.Supply, resp.Owner, resp.Warp, nil
+	return true, resp.Metadata, resp.Supply, resp.Owner, resp.Warp, resp.Paused, nil
 }
 
 func (cli *JSONRPCClient) Balance(ctx context.Context, addr string, asset ids.ID) (uint64, error) {
@@ -142,6 +142,481 @@ func (cli *JSONRPCClient) Loan(
 	return resp.Amount, err
 }
 
+// BridgeState returns the outstanding loans of [asset] and whether they
+// include every destination it has been loaned to.
+func (cli *JSONRPCClient) BridgeState(ctx context.Context, asset ids.ID) ([]*Loan, bool, error) {
+	resp := new(BridgeStateReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"bridgeState",
+		&BridgeStateArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	return resp.Loans, resp.Complete, err
+}
+
+// Frozen returns true if [addr] may not move their balance of [asset].
+func (cli *JSONRPCClient) Frozen(ctx context.Context, asset ids.ID, addr string) (bool, error) {
+	resp := new(FrozenReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"frozen",
+		&FrozenArgs{
+			Asset:   asset,
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Frozen, err
+}
+
+// SponsorRate returns the amount of [asset] that [sponsor] charges for each
+// whole native token it pays in fees (or 0 if it does not accept [asset]).
+func (cli *JSONRPCClient) SponsorRate(ctx context.Context, sponsor string, asset ids.ID) (uint64, error) {
+	resp := new(SponsorRateReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"sponsorRate",
+		&SponsorRateArgs{
+			Sponsor: sponsor,
+			Asset:   asset,
+		},
+		resp,
+	)
+	return resp.Rate, err
+}
+
+// Session returns the session key [key] registered by [owner] (with the
+// remaining amount of each asset it can spend) or nil if it does not exist.
+func (cli *JSONRPCClient) Session(ctx context.Context, owner string, key string) (*SessionReply, error) {
+	resp := new(SessionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"session",
+		&SessionArgs{
+			Owner: owner,
+			Key:   key,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrSessionNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Recovery returns the key that controls [account], its guardians, and any
+// pending recovery.
+func (cli *JSONRPCClient) Recovery(ctx context.Context, account string) (*RecoveryReply, error) {
+	resp := new(RecoveryReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"recovery",
+		&RecoveryArgs{
+			Account: account,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Resolve returns the record of [name] or nil if it was never registered.
+// Callers should check [ResolveReply.Expiry] because expired records are
+// still returned.
+func (cli *JSONRPCClient) Resolve(ctx context.Context, name string) (*ResolveReply, error) {
+	resp := new(ResolveReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"resolve",
+		&ResolveArgs{
+			Name: name,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrNameNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReverseResolve returns the name of [target] (an address or asset ID) or nil
+// if it has none.
+func (cli *JSONRPCClient) ReverseResolve(ctx context.Context, target string) (*ReverseResolveReply, error) {
+	resp := new(ReverseResolveReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"reverseResolve",
+		&ReverseResolveArgs{
+			Target: target,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrNameNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Collection returns the owner, supply, and metadata of [collection] or nil
+// if it does not exist.
+func (cli *JSONRPCClient) Collection(ctx context.Context, collection ids.ID) (*CollectionReply, error) {
+	resp := new(CollectionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"collection",
+		&CollectionArgs{
+			Collection: collection,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrCollectionNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NFT returns token [id] of [collection] or nil if it does not exist.
+func (cli *JSONRPCClient) NFT(ctx context.Context, collection ids.ID, id uint64) (*NFT, error) {
+	resp := new(NFTReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nft",
+		&NFTArgs{
+			Collection: collection,
+			ID:         id,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrNFTNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp.NFT, nil
+}
+
+// NFTsByOwner returns every NFT owned by [owner].
+func (cli *JSONRPCClient) NFTsByOwner(ctx context.Context, owner string) ([]*NFT, error) {
+	resp := new(NFTsByOwnerReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nftsByOwner",
+		&NFTsByOwnerArgs{
+			Owner: owner,
+		},
+		resp,
+	)
+	return resp.NFTs, err
+}
+
+// Stream returns the stream created by [stream] or nil if it does not exist
+// (or has been fully withdrawn).
+func (cli *JSONRPCClient) Stream(ctx context.Context, stream ids.ID) (*StreamReply, error) {
+	resp := new(StreamReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"stream",
+		&StreamArgs{
+			Stream: stream,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrStreamNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Airdrop returns the airdrop created by [airdrop] (and whether [recipient]
+// has claimed from it, if provided) or nil if it does not exist.
+func (cli *JSONRPCClient) Airdrop(ctx context.Context, airdrop ids.ID, recipient string) (*AirdropReply, error) {
+	resp := new(AirdropReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"airdrop",
+		&AirdropArgs{
+			Airdrop:   airdrop,
+			Recipient: recipient,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrAirdropNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Escrow returns the escrow created by [escrow] or nil if it does not exist
+// (or has been resolved).
+func (cli *JSONRPCClient) Escrow(ctx context.Context, escrow ids.ID) (*EscrowReply, error) {
+	resp := new(EscrowReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"escrow",
+		&EscrowArgs{
+			Escrow: escrow,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrEscrowNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Feed returns the feed created by [feed] or nil if it does not exist.
+func (cli *JSONRPCClient) Feed(ctx context.Context, feed ids.ID) (*FeedReply, error) {
+	resp := new(FeedReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"feed",
+		&FeedArgs{
+			Feed: feed,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrFeedNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FeedHistory returns the last [limit] prices posted to [feed] (oldest
+// first). The server caps [limit] (and uses its cap if [limit] is 0).
+func (cli *JSONRPCClient) FeedHistory(ctx context.Context, feed ids.ID, limit int) ([]*FeedPrice, error) {
+	resp := new(FeedHistoryReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"feedHistory",
+		&FeedHistoryArgs{
+			Feed:  feed,
+			Limit: limit,
+		},
+		resp,
+	)
+	return resp.Prices, err
+}
+
+// TriggerOrders returns every trigger order that has not been triggered or
+// cancelled.
+func (cli *JSONRPCClient) TriggerOrders(ctx context.Context) ([]*TriggerOrder, error) {
+	resp := new(TriggerOrdersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"triggerOrders",
+		nil,
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp.TriggerOrders, nil
+}
+
+// LastTrade returns whether an order selling [out] for [in] has been filled
+// and, if so, the amounts of [in] and [out] traded by the last fill.
+func (cli *JSONRPCClient) LastTrade(ctx context.Context, in ids.ID, out ids.ID) (bool, uint64, uint64, error) {
+	resp := new(LastTradeReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"lastTrade",
+		&LastTradeArgs{
+			In:  in,
+			Out: out,
+		},
+		resp,
+	)
+	return resp.Exists, resp.In, resp.Out, err
+}
+
+// AllowlistStatus returns whether [asset] is in allowlist mode and whether
+// [addr] can receive it.
+func (cli *JSONRPCClient) AllowlistStatus(
+	ctx context.Context,
+	asset ids.ID,
+	addr string,
+) (bool, bool, error) {
+	resp := new(AllowlistStatusReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"allowlistStatus",
+		&AllowlistStatusArgs{
+			Asset:   asset,
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Enabled, resp.Allowed, err
+}
+
+// Vesting returns the schedule created by [vesting] or nil if it does not
+// exist (or was fully claimed).
+func (cli *JSONRPCClient) Vesting(ctx context.Context, vesting ids.ID) (*VestingReply, error) {
+	resp := new(VestingReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"vesting",
+		&VestingArgs{
+			Vesting: vesting,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrVestingNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Treasury returns the account that receives a share of fees and its native
+// balance. If fees are burned, nil is returned.
+func (cli *JSONRPCClient) Treasury(ctx context.Context) (*TreasuryReply, error) {
+	resp := new(TreasuryReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"treasury",
+		nil,
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrTreasuryNotFound.Error()):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Proposals returns every governance proposal and its current tally.
+func (cli *JSONRPCClient) Proposals(ctx context.Context) ([]*Proposal, error) {
+	resp := new(ProposalsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"proposals",
+		nil,
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Proposals, nil
+}
+
+// Param returns the value of [param] set by governance (for the [in]-[out]
+// pair if it is a pair param).
+func (cli *JSONRPCClient) Param(ctx context.Context, param string, in ids.ID, out ids.ID) (*ParamReply, error) {
+	resp := new(ParamReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"param",
+		&ParamArgs{
+			Param: param,
+			In:    in,
+			Out:   out,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WarpDestinations returns the chains [asset] may be exported to. If empty,
+// [asset] may be exported to any chain.
+func (cli *JSONRPCClient) WarpDestinations(ctx context.Context, asset ids.ID) ([]ids.ID, error) {
+	resp := new(WarpDestinationsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"warpDestinations",
+		&WarpDestinationsArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	return resp.Destinations, err
+}
+
+func (cli *JSONRPCClient) Message(
+	ctx context.Context,
+	source ids.ID,
+	txID ids.ID,
+) (bool, string, string, []byte, error) {
+	resp := new(MessageReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"message",
+		&MessageArgs{
+			Source: source,
+			TxID:   txID,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrMessageNotFound.Error()):
+		return false, "", "", nil, nil
+	case err != nil:
+		return false, "", "", nil, err
+	}
+	return true, resp.Sender, resp.Recipient, resp.Payload, nil
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
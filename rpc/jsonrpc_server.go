@@ -4,12 +4,17 @@
 package rpc
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/crypto"
 
+	"tokenvm/actions"
 	"tokenvm/genesis"
 	"tokenvm/orderbook"
+	"tokenvm/storage"
 	"tokenvm/utils"
 )
 
@@ -66,23 +71,31 @@ type AssetReply struct {
 	Supply   uint64 `json:"supply"`
 	Owner    string `json:"owner"`
 	Warp     bool   `json:"warp"`
+	Paused   bool   `json:"paused"`
 }
 
 func (j *JSONRPCServer) Asset(req *http.Request, args *AssetArgs, reply *AssetReply) error {
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Asset")
 	defer span.End()
 
-	exists, metadata, supply, owner, warp, err := j.c.GetAssetFromState(ctx, args.Asset)
+	exists, metadata, supply, owner, warp, flags, err := j.c.GetAssetFromState(ctx, args.Asset)
 	if err != nil {
 		return err
 	}
 	if !exists {
 		return ErrAssetNotFound
 	}
+	// Fees set aside to be burned are no longer in circulation, even if they
+	// have not been settled yet
+	burn, _, _, err := j.c.GetFeePoolFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
 	reply.Metadata = metadata
-	reply.Supply = supply
+	reply.Supply = supply - burn
 	reply.Owner = utils.Address(owner)
 	reply.Warp = warp
+	reply.Paused = flags&storage.AssetPaused != 0
 	return err
 }
 
@@ -147,3 +160,922 @@ func (j *JSONRPCServer) Loan(req *http.Request, args *LoanArgs, reply *LoanReply
 	reply.Amount = amount
 	return nil
 }
+
+type BridgeStateArgs struct {
+	Asset ids.ID `json:"asset"`
+}
+
+type Loan struct {
+	Destination ids.ID `json:"destination"`
+	Amount      uint64 `json:"amount"`
+}
+
+type BridgeStateReply struct {
+	Loans []*Loan `json:"loans"`
+
+	// Complete is false if [Asset] has been loaned to more than
+	// [storage.MaxLoanDestinations] destinations at once, in which case
+	// [Loans] may not include every outstanding loan.
+	Complete bool `json:"complete"`
+}
+
+func (j *JSONRPCServer) BridgeState(req *http.Request, args *BridgeStateArgs, reply *BridgeStateReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.BridgeState")
+	defer span.End()
+
+	destinations, amounts, complete, err := j.c.GetLoansFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Loans = make([]*Loan, len(destinations))
+	for i, destination := range destinations {
+		reply.Loans[i] = &Loan{Destination: destination, Amount: amounts[i]}
+	}
+	reply.Complete = complete
+	return nil
+}
+
+type FrozenArgs struct {
+	Asset   ids.ID `json:"asset"`
+	Address string `json:"address"`
+}
+
+type FrozenReply struct {
+	Frozen bool `json:"frozen"`
+}
+
+func (j *JSONRPCServer) Frozen(req *http.Request, args *FrozenArgs, reply *FrozenReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Frozen")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	frozen, err := j.c.GetFrozenFromState(ctx, args.Asset, addr)
+	if err != nil {
+		return err
+	}
+	reply.Frozen = frozen
+	return nil
+}
+
+type AllowlistStatusArgs struct {
+	Asset   ids.ID `json:"asset"`
+	Address string `json:"address"`
+}
+
+type AllowlistStatusReply struct {
+	// Enabled is true if the asset is in allowlist mode.
+	Enabled bool `json:"enabled"`
+
+	// Allowed is true if the address can receive the asset.
+	Allowed bool `json:"allowed"`
+}
+
+func (j *JSONRPCServer) AllowlistStatus(
+	req *http.Request,
+	args *AllowlistStatusArgs,
+	reply *AllowlistStatusReply,
+) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.AllowlistStatus")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	exists, _, _, owner, _, flags, err := j.c.GetAssetFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAssetNotFound
+	}
+	allowlisted, err := j.c.GetAllowlistedFromState(ctx, args.Asset, addr)
+	if err != nil {
+		return err
+	}
+	reply.Enabled = flags&storage.AssetAllowlist != 0
+	reply.Allowed = !reply.Enabled || owner == addr || allowlisted
+	return nil
+}
+
+type VestingArgs struct {
+	Vesting ids.ID `json:"vesting"`
+}
+
+type VestingReply struct {
+	Asset       ids.ID `json:"asset"`
+	Beneficiary string `json:"beneficiary"`
+	Amount      uint64 `json:"amount"`
+	Start       int64  `json:"start"`
+	Cliff       int64  `json:"cliff"`
+	Duration    int64  `json:"duration"`
+	Claimed     uint64 `json:"claimed"`
+}
+
+func (j *JSONRPCServer) Vesting(req *http.Request, args *VestingArgs, reply *VestingReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Vesting")
+	defer span.End()
+
+	exists, asset, beneficiary, amount, start, cliff, duration, claimed, err := j.c.GetVestingFromState(ctx, args.Vesting)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrVestingNotFound
+	}
+	reply.Asset = asset
+	reply.Beneficiary = utils.Address(beneficiary)
+	reply.Amount = amount
+	reply.Start = start
+	reply.Cliff = cliff
+	reply.Duration = duration
+	reply.Claimed = claimed
+	return nil
+}
+
+type TreasuryReply struct {
+	Address  string `json:"address"`
+	Balance  uint64 `json:"balance"`
+	FeeShare uint64 `json:"feeShare"`
+	TakerFee uint64 `json:"takerFee"`
+
+	// Unsettled is the amount of the native asset in the fee pool that will be
+	// sent to the treasury by the next SettleFees transaction.
+	Unsettled uint64 `json:"unsettled"`
+}
+
+func (j *JSONRPCServer) Treasury(req *http.Request, _ *struct{}, reply *TreasuryReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Treasury")
+	defer span.End()
+
+	// The fee share and taker fee can be changed by upgrades
+	g := j.c.Genesis().At(time.Now().Unix())
+	treasury, err := g.TreasuryAddress()
+	if err != nil {
+		return err
+	}
+	if treasury == crypto.EmptyPublicKey {
+		return ErrTreasuryNotFound
+	}
+	balance, err := j.c.GetBalanceFromState(ctx, treasury, ids.Empty)
+	if err != nil {
+		return err
+	}
+	_, unsettled, _, err := j.c.GetFeePoolFromState(ctx, ids.Empty)
+	if err != nil {
+		return err
+	}
+	reply.Address = utils.Address(treasury)
+	reply.Balance = balance
+	reply.Unsettled = unsettled
+	reply.FeeShare = g.TreasuryFeeShare
+	reply.TakerFee = g.TreasuryTakerFee
+	return nil
+}
+
+type Proposal struct {
+	ID       ids.ID `json:"id"`
+	Proposer string `json:"proposer"`
+	Param    string `json:"param"`
+	Value    uint64 `json:"value"`
+	Pair     string `json:"pair,omitempty"`
+	In       ids.ID `json:"in"`
+	Out      ids.ID `json:"out"`
+	Created  int64  `json:"created"`
+	Yes      uint64 `json:"yes"`
+	No       uint64 `json:"no"`
+	Executed bool   `json:"executed"`
+
+	// Deposit is the amount of the native asset the proposer can still reclaim
+	// with ReclaimDeposit.
+	Deposit uint64 `json:"deposit"`
+}
+
+type ProposalsReply struct {
+	Proposals []*Proposal `json:"proposals"`
+}
+
+func (j *JSONRPCServer) Proposals(req *http.Request, _ *struct{}, reply *ProposalsReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Proposals")
+	defer span.End()
+
+	proposals, err := j.c.GetProposals(ctx)
+	if err != nil {
+		return err
+	}
+	reply.Proposals = make([]*Proposal, 0, len(proposals))
+	for _, id := range proposals {
+		exists, proposer, param, value, created, yes, no, deposit, executed, err := j.c.GetProposalFromState(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		name := "unknown"
+		if int(param) < len(actions.ParamNames) {
+			name = actions.ParamNames[param]
+		}
+		var (
+			pair    string
+			in, out ids.ID
+		)
+		if actions.PairParam(param) {
+			in, out, err = j.c.GetProposalPairFromState(ctx, id)
+			if err != nil {
+				return err
+			}
+			pair = actions.PairID(in, out)
+		}
+		reply.Proposals = append(reply.Proposals, &Proposal{
+			ID:       id,
+			Proposer: utils.Address(proposer),
+			Param:    name,
+			Value:    value,
+			Pair:     pair,
+			In:       in,
+			Out:      out,
+			Created:  created,
+			Yes:      yes,
+			No:       no,
+			Executed: executed,
+			Deposit:  deposit,
+		})
+	}
+	return nil
+}
+
+type ParamArgs struct {
+	Param string `json:"param"`
+
+	// In and Out identify the pair of a pair param.
+	In  ids.ID `json:"in"`
+	Out ids.ID `json:"out"`
+}
+
+type ParamReply struct {
+	// Active is the value set by governance that is in effect (if
+	// [ActiveSet]). Otherwise, the value in the genesis (or upgrade) is used.
+	ActiveSet bool   `json:"activeSet"`
+	Active    uint64 `json:"active"`
+
+	// Pending replaces [Active] at [Activation] (if it is not 0).
+	Pending    uint64 `json:"pending"`
+	Activation int64  `json:"activation"`
+}
+
+func (j *JSONRPCServer) Param(req *http.Request, args *ParamArgs, reply *ParamReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Param")
+	defer span.End()
+
+	param, ok := actions.ParamFromName(args.Param)
+	if !ok {
+		return actions.ErrInvalidParam
+	}
+	activeSet, active, pending, activation, err := j.c.GetParamFromState(ctx, param, args.In, args.Out)
+	if err != nil {
+		return err
+	}
+	reply.ActiveSet = activeSet
+	reply.Active = active
+	reply.Pending = pending
+	reply.Activation = activation
+	return nil
+}
+
+type WarpDestinationsArgs struct {
+	Asset ids.ID `json:"asset"`
+}
+
+type WarpDestinationsReply struct {
+	Destinations []ids.ID `json:"destinations"`
+}
+
+func (j *JSONRPCServer) WarpDestinations(
+	req *http.Request,
+	args *WarpDestinationsArgs,
+	reply *WarpDestinationsReply,
+) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.WarpDestinations")
+	defer span.End()
+
+	destinations, err := j.c.GetWarpDestinationsFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Destinations = destinations
+	return nil
+}
+
+type MessageArgs struct {
+	Source ids.ID `json:"source"`
+	TxID   ids.ID `json:"txId"`
+}
+
+type MessageReply struct {
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Payload   []byte `json:"payload"`
+}
+
+func (j *JSONRPCServer) Message(req *http.Request, args *MessageArgs, reply *MessageReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Message")
+	defer span.End()
+
+	exists, sender, recipient, payload, err := j.c.GetMessageFromState(ctx, args.Source, args.TxID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrMessageNotFound
+	}
+	reply.Sender = utils.Address(sender)
+	reply.Recipient = utils.Address(recipient)
+	reply.Payload = payload
+	return nil
+}
+
+type SponsorRateArgs struct {
+	Sponsor string `json:"sponsor"`
+	Asset   ids.ID `json:"asset"`
+}
+
+type SponsorRateReply struct {
+	Rate uint64 `json:"rate"`
+}
+
+func (j *JSONRPCServer) SponsorRate(req *http.Request, args *SponsorRateArgs, reply *SponsorRateReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.SponsorRate")
+	defer span.End()
+
+	sponsor, err := utils.ParseAddress(args.Sponsor)
+	if err != nil {
+		return err
+	}
+	rate, err := j.c.GetSponsorRateFromState(ctx, sponsor, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Rate = rate
+	return nil
+}
+
+type SessionArgs struct {
+	Owner string `json:"owner"`
+	Key   string `json:"key"`
+}
+
+type SessionReply struct {
+	Expiry  int64                   `json:"expiry"`
+	Actions []uint8                 `json:"actions"`
+	Limits  []*actions.SessionLimit `json:"limits"`
+}
+
+func (j *JSONRPCServer) Session(req *http.Request, args *SessionArgs, reply *SessionReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Session")
+	defer span.End()
+
+	owner, err := utils.ParseAddress(args.Owner)
+	if err != nil {
+		return err
+	}
+	key, err := utils.ParseAddress(args.Key)
+	if err != nil {
+		return err
+	}
+	exists, expiry, sessionActions, assets, remaining, err := j.c.GetSessionFromState(ctx, owner, key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionNotFound
+	}
+	reply.Expiry = expiry
+	reply.Actions = sessionActions
+	reply.Limits = make([]*actions.SessionLimit, len(assets))
+	for i, asset := range assets {
+		reply.Limits[i] = &actions.SessionLimit{Asset: asset, Amount: remaining[i]}
+	}
+	return nil
+}
+
+type RecoveryArgs struct {
+	Account string `json:"account"`
+}
+
+type RecoveryReply struct {
+	Key       string   `json:"key"`
+	Guardians []string `json:"guardians"`
+	Threshold uint8    `json:"threshold"`
+	Delay     int64    `json:"delay"`
+	Nonce     uint64   `json:"nonce"`
+
+	// Pending is true if a recovery to [NewKey] has been initiated. It can be
+	// completed at [Ready].
+	Pending bool   `json:"pending"`
+	NewKey  string `json:"newKey"`
+	Ready   int64  `json:"ready"`
+}
+
+func (j *JSONRPCServer) Recovery(req *http.Request, args *RecoveryArgs, reply *RecoveryReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Recovery")
+	defer span.End()
+
+	account, err := utils.ParseAddress(args.Account)
+	if err != nil {
+		return err
+	}
+	key, err := j.c.GetAccountKeyFromState(ctx, account)
+	if err != nil {
+		return err
+	}
+	threshold, delay, nonce, guardians, err := j.c.GetGuardiansFromState(ctx, account)
+	if err != nil {
+		return err
+	}
+	pending, newKey, ready, err := j.c.GetRecoveryFromState(ctx, account)
+	if err != nil {
+		return err
+	}
+	reply.Key = utils.Address(key)
+	reply.Guardians = make([]string, len(guardians))
+	for i, guardian := range guardians {
+		reply.Guardians[i] = utils.Address(guardian)
+	}
+	reply.Threshold = threshold
+	reply.Delay = delay
+	reply.Nonce = nonce
+	reply.Pending = pending
+	if pending {
+		reply.NewKey = utils.Address(newKey)
+		reply.Ready = ready
+	}
+	return nil
+}
+
+type ResolveArgs struct {
+	Name string `json:"name"`
+}
+
+type ResolveReply struct {
+	Owner string `json:"owner"`
+
+	// Address is empty if [Name] does not resolve to an address and [Asset]
+	// is [ids.Empty] if it does not resolve to an asset.
+	Address string `json:"address"`
+	Asset   ids.ID `json:"asset"`
+
+	// Expiry is the unix timestamp (in seconds) after which [Name] no longer
+	// resolves and can be registered by anyone.
+	Expiry int64 `json:"expiry"`
+}
+
+func (j *JSONRPCServer) Resolve(req *http.Request, args *ResolveArgs, reply *ResolveReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Resolve")
+	defer span.End()
+
+	exists, owner, address, asset, expiry, err := j.c.GetNameFromState(ctx, args.Name)
+	if err != nil {
+		return err
+	}
+	if !exists || expiry <= time.Now().Unix() {
+		return ErrNameNotFound
+	}
+	reply.Owner = utils.Address(owner)
+	if address != crypto.EmptyPublicKey {
+		reply.Address = utils.Address(address)
+	}
+	reply.Asset = asset
+	reply.Expiry = expiry
+	return nil
+}
+
+type ReverseResolveArgs struct {
+	// Target is an address or an asset ID.
+	Target string `json:"target"`
+}
+
+type ReverseResolveReply struct {
+	Name   string `json:"name"`
+	Expiry int64  `json:"expiry"`
+}
+
+func (j *JSONRPCServer) ReverseResolve(
+	req *http.Request,
+	args *ReverseResolveArgs,
+	reply *ReverseResolveReply,
+) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ReverseResolve")
+	defer span.End()
+
+	var target [32]byte
+	if address, err := utils.ParseAddress(args.Target); err == nil {
+		target = address
+	} else {
+		asset, err := ids.FromString(args.Target)
+		if err != nil {
+			return err
+		}
+		target = asset
+	}
+	name, err := j.c.GetReverseNameFromState(ctx, target)
+	if err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		return ErrNameNotFound
+	}
+
+	// The reverse record is only valid if [name] still resolves to [target]
+	exists, _, address, asset, expiry, err := j.c.GetNameFromState(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists || expiry <= time.Now().Unix() || (address != target && asset != target) {
+		return ErrNameNotFound
+	}
+	reply.Name = name
+	reply.Expiry = expiry
+	return nil
+}
+
+type CollectionArgs struct {
+	Collection ids.ID `json:"collection"`
+}
+
+type CollectionReply struct {
+	Owner    string `json:"owner"`
+	Supply   uint64 `json:"supply"`
+	Metadata []byte `json:"metadata"`
+}
+
+func (j *JSONRPCServer) Collection(req *http.Request, args *CollectionArgs, reply *CollectionReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Collection")
+	defer span.End()
+
+	exists, owner, supply, metadata, err := j.c.GetCollectionFromState(ctx, args.Collection)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrCollectionNotFound
+	}
+	reply.Owner = utils.Address(owner)
+	reply.Supply = supply
+	reply.Metadata = metadata
+	return nil
+}
+
+type NFT struct {
+	// Asset is the ID of the NFT on the order book.
+	Asset      ids.ID `json:"asset"`
+	Collection ids.ID `json:"collection"`
+	ID         uint64 `json:"id"`
+	Owner      string `json:"owner"`
+	Metadata   []byte `json:"metadata"`
+}
+
+// nft returns the NFT with the asset ID [asset], or nil if it does not exist.
+func (j *JSONRPCServer) nft(ctx context.Context, asset ids.ID) (*NFT, error) {
+	exists, collection, id, owner, err := j.c.GetNFTFromState(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	_, metadata, _, _, _, _, err := j.c.GetAssetFromState(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	return &NFT{
+		Asset:      asset,
+		Collection: collection,
+		ID:         id,
+		Owner:      utils.Address(owner),
+		Metadata:   metadata,
+	}, nil
+}
+
+type NFTArgs struct {
+	Collection ids.ID `json:"collection"`
+	ID         uint64 `json:"id"`
+}
+
+type NFTReply struct {
+	NFT *NFT `json:"nft"`
+}
+
+// Nft is served as "nft". The JSON-RPC codec only capitalizes the first
+// letter of a method, so it can't be named NFT.
+func (j *JSONRPCServer) Nft(req *http.Request, args *NFTArgs, reply *NFTReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Nft")
+	defer span.End()
+
+	nft, err := j.nft(ctx, actions.NFTID(args.Collection, args.ID))
+	if err != nil {
+		return err
+	}
+	if nft == nil {
+		return ErrNFTNotFound
+	}
+	reply.NFT = nft
+	return nil
+}
+
+type NFTsByOwnerArgs struct {
+	Owner string `json:"owner"`
+}
+
+type NFTsByOwnerReply struct {
+	NFTs []*NFT `json:"nfts"`
+}
+
+// NftsByOwner is served as "nftsByOwner" (see [JSONRPCServer.Nft]).
+func (j *JSONRPCServer) NftsByOwner(req *http.Request, args *NFTsByOwnerArgs, reply *NFTsByOwnerReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.NftsByOwner")
+	defer span.End()
+
+	owner, err := utils.ParseAddress(args.Owner)
+	if err != nil {
+		return err
+	}
+	assets, err := j.c.GetNFTsByOwner(ctx, owner)
+	if err != nil {
+		return err
+	}
+	reply.NFTs = []*NFT{}
+	for _, asset := range assets {
+		nft, err := j.nft(ctx, asset)
+		if err != nil {
+			return err
+		}
+		// The index may be stale, so we only include NFTs still owned by
+		// [owner]. NFTs listed on the order book are still owned by the
+		// seller.
+		if nft == nil || nft.Owner != args.Owner {
+			continue
+		}
+		reply.NFTs = append(reply.NFTs, nft)
+	}
+	return nil
+}
+
+type StreamArgs struct {
+	Stream ids.ID `json:"stream"`
+}
+
+type StreamReply struct {
+	Asset     ids.ID `json:"asset"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Amount    uint64 `json:"amount"`
+	Start     int64  `json:"start"`
+	End       int64  `json:"end"`
+	Withdrawn uint64 `json:"withdrawn"`
+}
+
+func (j *JSONRPCServer) Stream(req *http.Request, args *StreamArgs, reply *StreamReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Stream")
+	defer span.End()
+
+	exists, asset, sender, recipient, amount, start, end, withdrawn, err := j.c.GetStreamFromState(ctx, args.Stream)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrStreamNotFound
+	}
+	reply.Asset = asset
+	reply.Sender = utils.Address(sender)
+	reply.Recipient = utils.Address(recipient)
+	reply.Amount = amount
+	reply.Start = start
+	reply.End = end
+	reply.Withdrawn = withdrawn
+	return nil
+}
+
+type AirdropArgs struct {
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Recipient is optional. If it is provided, [AirdropReply.Claimed] is set.
+	Recipient string `json:"recipient"`
+}
+
+type AirdropReply struct {
+	Asset     ids.ID `json:"asset"`
+	Creator   string `json:"creator"`
+	Root      ids.ID `json:"root"`
+	Expiry    int64  `json:"expiry"`
+	Remaining uint64 `json:"remaining"`
+	Claimed   bool   `json:"claimed"`
+}
+
+func (j *JSONRPCServer) Airdrop(req *http.Request, args *AirdropArgs, reply *AirdropReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Airdrop")
+	defer span.End()
+
+	exists, asset, creator, root, expiry, remaining, err := j.c.GetAirdropFromState(ctx, args.Airdrop)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAirdropNotFound
+	}
+	reply.Asset = asset
+	reply.Creator = utils.Address(creator)
+	reply.Root = root
+	reply.Expiry = expiry
+	reply.Remaining = remaining
+	if len(args.Recipient) == 0 {
+		return nil
+	}
+	recipient, err := utils.ParseAddress(args.Recipient)
+	if err != nil {
+		return err
+	}
+	reply.Claimed, err = j.c.GetAirdropClaimedFromState(ctx, args.Airdrop, recipient)
+	return err
+}
+
+type EscrowArgs struct {
+	Escrow ids.ID `json:"escrow"`
+}
+
+type EscrowReply struct {
+	Asset            ids.ID `json:"asset"`
+	Payer            string `json:"payer"`
+	Payee            string `json:"payee"`
+	Arbiter          string `json:"arbiter"`
+	Amount           uint64 `json:"amount"`
+	Deadline         int64  `json:"deadline"`
+	ReleaseOnTimeout bool   `json:"releaseOnTimeout"`
+}
+
+func (j *JSONRPCServer) Escrow(req *http.Request, args *EscrowArgs, reply *EscrowReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Escrow")
+	defer span.End()
+
+	exists, asset, payer, payee, arbiter, amount, deadline, releaseOnTimeout, err := j.c.GetEscrowFromState(ctx, args.Escrow)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrEscrowNotFound
+	}
+	reply.Asset = asset
+	reply.Payer = utils.Address(payer)
+	reply.Payee = utils.Address(payee)
+	reply.Arbiter = utils.Address(arbiter)
+	reply.Amount = amount
+	reply.Deadline = deadline
+	reply.ReleaseOnTimeout = releaseOnTimeout
+	return nil
+}
+
+type FeedArgs struct {
+	Feed ids.ID `json:"feed"`
+}
+
+type FeedReply struct {
+	Owner     string `json:"owner"`
+	Decimals  uint8  `json:"decimals"`
+	Price     uint64 `json:"price"`
+	Timestamp int64  `json:"timestamp"`
+	Metadata  []byte `json:"metadata"`
+}
+
+func (j *JSONRPCServer) Feed(req *http.Request, args *FeedArgs, reply *FeedReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Feed")
+	defer span.End()
+
+	exists, owner, decimals, price, timestamp, metadata, err := j.c.GetFeedFromState(ctx, args.Feed)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrFeedNotFound
+	}
+	reply.Owner = utils.Address(owner)
+	reply.Decimals = decimals
+	reply.Price = price
+	reply.Timestamp = timestamp
+	reply.Metadata = metadata
+	return nil
+}
+
+type FeedHistoryArgs struct {
+	Feed  ids.ID `json:"feed"`
+	Limit int    `json:"limit"`
+}
+
+type FeedPrice struct {
+	Timestamp int64  `json:"timestamp"`
+	Price     uint64 `json:"price"`
+}
+
+type FeedHistoryReply struct {
+	Prices []*FeedPrice `json:"prices"`
+}
+
+func (j *JSONRPCServer) FeedHistory(req *http.Request, args *FeedHistoryArgs, reply *FeedHistoryReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.FeedHistory")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > feedPricesToSend {
+		limit = feedPricesToSend
+	}
+	timestamps, prices, err := j.c.GetFeedHistory(ctx, args.Feed, limit)
+	if err != nil {
+		return err
+	}
+	reply.Prices = make([]*FeedPrice, len(timestamps))
+	for i := range timestamps {
+		reply.Prices[i] = &FeedPrice{Timestamp: timestamps[i], Price: prices[i]}
+	}
+	return nil
+}
+
+type TriggerOrder struct {
+	ID         ids.ID `json:"id"`
+	In         ids.ID `json:"in"`
+	Out        ids.ID `json:"out"`
+	Owner      string `json:"owner"`
+	Value      uint64 `json:"value"`
+	Reward     uint64 `json:"reward"`
+	MinOut     uint64 `json:"minOut"`
+	Feed       ids.ID `json:"feed"`
+	TriggerIn  uint64 `json:"triggerIn"`
+	TriggerOut uint64 `json:"triggerOut"`
+	Above      bool   `json:"above"`
+}
+
+type TriggerOrdersReply struct {
+	TriggerOrders []*TriggerOrder `json:"triggerOrders"`
+}
+
+func (j *JSONRPCServer) TriggerOrders(req *http.Request, _ *struct{}, reply *TriggerOrdersReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.TriggerOrders")
+	defer span.End()
+
+	triggers, err := j.c.GetTriggerOrders(ctx)
+	if err != nil {
+		return err
+	}
+	reply.TriggerOrders = make([]*TriggerOrder, 0, len(triggers))
+	for _, id := range triggers {
+		exists, in, out, owner, value, reward, minOut, feed, triggerIn, triggerOut, above, err := j.c.GetTriggerOrderFromState(ctx, id)
+		if err != nil {
+			return err
+		}
+		// The index may include trigger orders that were just triggered or
+		// cancelled.
+		if !exists {
+			continue
+		}
+		reply.TriggerOrders = append(reply.TriggerOrders, &TriggerOrder{
+			ID:         id,
+			In:         in,
+			Out:        out,
+			Owner:      utils.Address(owner),
+			Value:      value,
+			Reward:     reward,
+			MinOut:     minOut,
+			Feed:       feed,
+			TriggerIn:  triggerIn,
+			TriggerOut: triggerOut,
+			Above:      above,
+		})
+	}
+	return nil
+}
+
+type LastTradeArgs struct {
+	In  ids.ID `json:"in"`
+	Out ids.ID `json:"out"`
+}
+
+type LastTradeReply struct {
+	Exists bool   `json:"exists"`
+	In     uint64 `json:"in"`
+	Out    uint64 `json:"out"`
+}
+
+func (j *JSONRPCServer) LastTrade(req *http.Request, args *LastTradeArgs, reply *LastTradeReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.LastTrade")
+	defer span.End()
+
+	exists, in, out, err := j.c.GetLastTradeFromState(ctx, args.In, args.Out)
+	if err != nil {
+		return err
+	}
+	reply.Exists = exists
+	reply.In = in
+	reply.Out = out
+	return nil
+}
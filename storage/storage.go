@@ -25,29 +25,145 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // Metadata
 // 0x0/ (tx)
 //   -> [txID] => timestamp
+// 0x1/ (proposals)
+//   -> [txID] => nil
+// 0x2/ (nfts)
+//   -> [nft] => nil
+// 0x3/ (nft owners)
+//   -> [owner|nft] => nil
+// 0x4/ (feed history)
+//   -> [feed|^timestamp] => price
+// 0x5/ (trigger orders)
+//   -> [txID] => nil
 //
 // State
 // 0x0/ (balance)
 //   -> [owner|asset] => balance
 // 0x1/ (assets)
-//   -> [asset] => metadataLen|metadata|supply|owner|warp
+//   -> [asset] => metadataLen|metadata|supply|owner|warp|flags
 // 0x2/ (orders)
 //   -> [txID] => in|out|rate|remaining|owner
 // 0x3/ (loans)
 //   -> [assetID|destination] => amount
 // 0x4/ (hypersdk-incoming warp)
 // 0x5/ (hypersdk-outgoing warp)
+// 0x7/ (messages)
+//   -> [sourceChainID|txID] => sender|recipient|payloadLen|payload
+// 0x8/ (loan destinations)
+//   -> [assetID] => overflow|destination...
+// 0x9/ (warp destinations)
+//   -> [assetID] => destination...
+// 0xa/ (frozen accounts)
+//   -> [asset|owner] => frozen
+// 0xb/ (allowlisted accounts)
+//   -> [asset|owner] => allowed
+// 0xc/ (vesting)
+//   -> [txID] => asset|beneficiary|amount|start|cliff|duration|claimed
+// 0xd/ (proposals)
+//   -> [txID] => proposer|param|value|created|yes|no|deposit|executed
+// 0xe/ (votes)
+//   -> [proposal|voter] => weight|support
+// 0xf/ (params)
+//   -> [param] => activeSet|active|pending|activation
+//   -> [param|in|out] => activeSet|active|pending|activation
+// 0x10/ (swap nonces)
+//   -> [owner|nonce] => used
+// 0x11/ (sponsor rates)
+//   -> [sponsor|asset] => rate
+// 0x12/ (session keys)
+//   -> [owner|key] => expiry|actionsLen|actions|limitsLen|(asset|remaining)...
+// 0x13/ (guardians)
+//   -> [account] => threshold|delay|nonce|guardian...
+// 0x14/ (recoveries)
+//   -> [account] => key|ready
+// 0x15/ (account keys)
+//   -> [account] => key
+// 0x16/ (names)
+//   -> [name] => owner|address|asset|expiry
+// 0x17/ (reverse names)
+//   -> [address or asset] => name
+// 0x18/ (collections)
+//   -> [collection] => owner|supply|metadataLen|metadata
+// 0x19/ (nfts)
+//   -> [nft] => collection|id|owner
+// 0x1a/ (streams)
+//   -> [txID] => asset|sender|recipient|amount|start|end|withdrawn
+// 0x1b/ (airdrops)
+//   -> [txID] => asset|creator|root|expiry|remaining
+// 0x1c/ (airdrop claims)
+//   -> [airdrop|recipient] => claimed
+// 0x1d/ (escrows)
+//   -> [txID] => asset|payer|payee|arbiter|amount|deadline|releaseOnTimeout
+// 0x1e/ (feeds)
+//   -> [txID] => owner|decimals|price|timestamp|metadataLen|metadata
+// 0x1f/ (last trades)
+//   -> [in|out] => in|out
+// 0x20/ (trigger orders)
+//   -> [txID] => in|out|owner|value|reward|minOut|feed|triggerIn|triggerOut|above
+// 0x21/ (fee pools)
+//   -> [asset|shard] => burn|treasury|recipient
+// 0x22/ (proposal pairs)
+//   -> [txID] => in|out
 
 const (
-	txPrefix = 0x0
-
-	balancePrefix      = 0x0
-	assetPrefix        = 0x1
-	orderPrefix        = 0x2
-	loanPrefix         = 0x3
-	heightPrefix       = 0x4
-	incomingWarpPrefix = 0x5
-	outgoingWarpPrefix = 0x6
+	txPrefix            = 0x0
+	proposalIndexPrefix = 0x1
+	nftIndexPrefix      = 0x2
+	nftOwnerIndexPrefix = 0x3
+	feedHistoryPrefix   = 0x4
+	triggerIndexPrefix  = 0x5
+
+	balancePrefix          = 0x0
+	assetPrefix            = 0x1
+	orderPrefix            = 0x2
+	loanPrefix             = 0x3
+	heightPrefix           = 0x4
+	incomingWarpPrefix     = 0x5
+	outgoingWarpPrefix     = 0x6
+	messagePrefix          = 0x7
+	loanDestinationsPrefix = 0x8
+	warpDestinationsPrefix = 0x9
+	frozenPrefix           = 0xa
+	allowlistPrefix        = 0xb
+	vestingPrefix          = 0xc
+	proposalPrefix         = 0xd
+	votePrefix             = 0xe
+	paramPrefix            = 0xf
+	swapNoncePrefix        = 0x10
+	sponsorRatePrefix      = 0x11
+	sessionPrefix          = 0x12
+	guardiansPrefix        = 0x13
+	recoveryPrefix         = 0x14
+	accountKeyPrefix       = 0x15
+	namePrefix             = 0x16
+	reverseNamePrefix      = 0x17
+	collectionPrefix       = 0x18
+	nftPrefix              = 0x19
+	streamPrefix           = 0x1a
+	airdropPrefix          = 0x1b
+	airdropClaimPrefix     = 0x1c
+	escrowPrefix           = 0x1d
+	feedPrefix             = 0x1e
+	lastTradePrefix        = 0x1f
+	triggerOrderPrefix     = 0x20
+	feePoolPrefix          = 0x21
+	proposalPairPrefix     = 0x22
+)
+
+// Asset flags are stored as a bitmask at the end of each asset record.
+const (
+	// AssetPaused prevents all holders (other than the owner via clawback) from
+	// moving the asset.
+	AssetPaused uint8 = 1 << iota
+
+	// AssetAllowlist prevents accounts that have not been approved by the
+	// owner from receiving the asset.
+	AssetAllowlist
+
+	// AssetNonFungible marks an asset created for an NFT. It can only be moved
+	// with NFT actions and the order book so that its owner record stays
+	// accurate.
+	AssetNonFungible
 )
 
 var (
@@ -270,7 +386,7 @@ func GetAssetFromState(
 	ctx context.Context,
 	f ReadState,
 	asset ids.ID,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, []byte, uint64, crypto.PublicKey, bool, uint8, error) {
 	values, errs := f(ctx, [][]byte{PrefixAssetKey(asset)})
 	return innerGetAsset(values[0], errs[0])
 }
@@ -279,7 +395,7 @@ func GetAsset(
 	ctx context.Context,
 	db chain.Database,
 	asset ids.ID,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, []byte, uint64, crypto.PublicKey, bool, uint8, error) {
 	k := PrefixAssetKey(asset)
 	return innerGetAsset(db.GetValue(ctx, k))
 }
@@ -287,20 +403,26 @@ func GetAsset(
 func innerGetAsset(
 	v []byte,
 	err error,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, []byte, uint64, crypto.PublicKey, bool, uint8, error) {
 	if errors.Is(err, database.ErrNotFound) {
-		return false, nil, 0, crypto.EmptyPublicKey, false, nil
+		return false, nil, 0, crypto.EmptyPublicKey, false, 0, nil
 	}
 	if err != nil {
-		return false, nil, 0, crypto.EmptyPublicKey, false, err
+		return false, nil, 0, crypto.EmptyPublicKey, false, 0, err
 	}
 	metadataLen := binary.BigEndian.Uint16(v)
 	metadata := v[consts.Uint16Len : consts.Uint16Len+metadataLen]
 	supply := binary.BigEndian.Uint64(v[consts.Uint16Len+metadataLen:])
 	var pk crypto.PublicKey
 	copy(pk[:], v[consts.Uint16Len+metadataLen+consts.Uint64Len:])
-	warp := v[consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen] == 0x1
-	return true, metadata, supply, pk, warp, nil
+	warpOffset := consts.Uint16Len + int(metadataLen) + consts.Uint64Len + crypto.PublicKeyLen
+	warp := v[warpOffset] == 0x1
+	var flags uint8
+	if len(v) > warpOffset+1 {
+		// Assets written before flags were introduced do not include them
+		flags = v[warpOffset+1]
+	}
+	return true, metadata, supply, pk, warp, flags, nil
 }
 
 func SetAsset(
@@ -311,10 +433,11 @@ func SetAsset(
 	supply uint64,
 	owner crypto.PublicKey,
 	warp bool,
+	flags uint8,
 ) error {
 	k := PrefixAssetKey(asset)
 	metadataLen := len(metadata)
-	v := make([]byte, consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen+1)
+	v := make([]byte, consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen+2)
 	binary.BigEndian.PutUint16(v, uint16(metadataLen))
 	copy(v[consts.Uint16Len:], metadata)
 	binary.BigEndian.PutUint64(v[consts.Uint16Len+metadataLen:], supply)
@@ -324,6 +447,7 @@ func SetAsset(
 		b = 0x1
 	}
 	v[consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen] = b
+	v[consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen+1] = flags
 	return db.Insert(ctx, k, v)
 }
 
@@ -332,6 +456,106 @@ func DeleteAsset(ctx context.Context, db chain.Database, asset ids.ID) error {
 	return db.Remove(ctx, k)
 }
 
+// [frozenPrefix] + [asset] + [owner]
+func PrefixFrozenKey(asset ids.ID, owner crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+crypto.PublicKeyLen)
+	k[0] = frozenPrefix
+	copy(k[1:], asset[:])
+	copy(k[1+consts.IDLen:], owner[:])
+	return
+}
+
+// innerGetFlag returns true if a key used as a boolean flag exists.
+func innerGetFlag(_ []byte, err error) (bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetFrozen returns true if [owner] may not move their balance of [asset].
+func GetFrozen(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) (bool, error) {
+	k := PrefixFrozenKey(asset, owner)
+	return innerGetFlag(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetFrozenFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) (bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixFrozenKey(asset, owner)})
+	return innerGetFlag(values[0], errs[0])
+}
+
+func SetFrozen(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+	frozen bool,
+) error {
+	return setFlag(ctx, db, PrefixFrozenKey(asset, owner), frozen)
+}
+
+func setFlag(ctx context.Context, db chain.Database, k []byte, v bool) error {
+	if !v {
+		return db.Remove(ctx, k)
+	}
+	return db.Insert(ctx, k, []byte{successByte})
+}
+
+// [allowlistPrefix] + [asset] + [owner]
+func PrefixAllowlistKey(asset ids.ID, owner crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+crypto.PublicKeyLen)
+	k[0] = allowlistPrefix
+	copy(k[1:], asset[:])
+	copy(k[1+consts.IDLen:], owner[:])
+	return
+}
+
+// GetAllowlisted returns true if [owner] was approved to receive [asset].
+func GetAllowlisted(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) (bool, error) {
+	k := PrefixAllowlistKey(asset, owner)
+	return innerGetFlag(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetAllowlistedFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+	owner crypto.PublicKey,
+) (bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixAllowlistKey(asset, owner)})
+	return innerGetFlag(values[0], errs[0])
+}
+
+func SetAllowlisted(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	owner crypto.PublicKey,
+	allowed bool,
+) error {
+	return setFlag(ctx, db, PrefixAllowlistKey(asset, owner), allowed)
+}
+
 // [orderPrefix] + [txID]
 func PrefixOrderKey(txID ids.ID) (k []byte) {
 	k = make([]byte, 1+consts.IDLen)
@@ -474,6 +698,13 @@ func AddLoan(
 			amount,
 		)
 	}
+	if loan == 0 {
+		// We track all destinations with an outstanding loan so that they can be
+		// enumerated over RPC.
+		if err := addLoanDestination(ctx, db, asset, destination); err != nil {
+			return err
+		}
+	}
 	return SetLoan(ctx, db, asset, destination, nloan)
 }
 
@@ -501,26 +732,2173 @@ func SubLoan(
 	if nloan == 0 {
 		// If there is no balance left, we should delete the record instead of
 		// setting it to 0.
+		if err := removeLoanDestination(ctx, db, asset, destination); err != nil {
+			return err
+		}
 		return db.Remove(ctx, PrefixLoanKey(asset, destination))
 	}
 	return SetLoan(ctx, db, asset, destination, nloan)
 }
 
-func HeightKey() (k []byte) {
-	return heightKey
+// MaxLoanDestinations is the number of destinations with an outstanding loan
+// tracked for each asset (so that the list read by every new loan stays
+// small). Loans to any other destination are still recorded, but the asset is
+// marked as having untracked loans from then on.
+const MaxLoanDestinations = 64
+
+// [loanDestinationsPrefix] + [asset]
+func PrefixLoanDestinationsKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = loanDestinationsPrefix
+	copy(k[1:], asset[:])
+	return
 }
 
-func IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) (k []byte) {
-	k = make([]byte, 1+consts.IDLen*2)
-	k[0] = incomingWarpPrefix
-	copy(k[1:], sourceChainID[:])
-	copy(k[1+consts.IDLen:], msgID[:])
-	return k
+// innerGetLoanDestinations returns the tracked destinations and whether they
+// include every destination with an outstanding loan.
+func innerGetLoanDestinations(v []byte, err error) ([]ids.ID, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	destinations, err := innerGetDestinations(v[1:], nil)
+	if err != nil {
+		return nil, false, err
+	}
+	return destinations, v[0] == 0, nil
 }
 
-func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
+func GetLoanDestinations(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+) ([]ids.ID, bool, error) {
+	k := PrefixLoanDestinationsKey(asset)
+	return innerGetLoanDestinations(db.GetValue(ctx, k))
+}
+
+func setLoanDestinations(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destinations []ids.ID,
+	complete bool,
+) error {
+	k := PrefixLoanDestinationsKey(asset)
+	if len(destinations) == 0 && complete {
+		return db.Remove(ctx, k)
+	}
+	v := make([]byte, 1, 1+len(destinations)*consts.IDLen)
+	if !complete {
+		v[0] = 1
+	}
+	for _, destination := range destinations {
+		v = append(v, destination[:]...)
+	}
+	return db.Insert(ctx, k, v)
+}
+
+func addLoanDestination(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+) error {
+	destinations, complete, err := GetLoanDestinations(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	if len(destinations) == MaxLoanDestinations {
+		// We never clear this flag because we no longer know which
+		// destinations are missing.
+		return setLoanDestinations(ctx, db, asset, destinations, false)
+	}
+	return setLoanDestinations(ctx, db, asset, append(destinations, destination), complete)
+}
+
+func removeLoanDestination(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+) error {
+	destinations, complete, err := GetLoanDestinations(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	for i, d := range destinations {
+		if d != destination {
+			continue
+		}
+		destinations = append(destinations[:i], destinations[i+1:]...)
+		break
+	}
+	return setLoanDestinations(ctx, db, asset, destinations, complete)
+}
+
+// GetLoansFromState returns the outstanding loans of [asset] to each tracked
+// destination and whether every outstanding loan is included. Used to serve
+// RPC queries.
+func GetLoansFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+) ([]ids.ID, []uint64, bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixLoanDestinationsKey(asset)})
+	destinations, complete, err := innerGetLoanDestinations(values[0], errs[0])
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(destinations) == 0 {
+		return nil, nil, complete, nil
+	}
+	keys := make([][]byte, len(destinations))
+	for i, destination := range destinations {
+		keys[i] = PrefixLoanKey(asset, destination)
+	}
+	values, errs = f(ctx, keys)
+	amounts := make([]uint64, len(destinations))
+	for i := range destinations {
+		amounts[i], err = innerGetLoan(values[i], errs[i])
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+	return destinations, amounts, complete, nil
+}
+
+// [warpDestinationsPrefix] + [asset]
+func PrefixWarpDestinationsKey(asset ids.ID) (k []byte) {
 	k = make([]byte, 1+consts.IDLen)
-	k[0] = outgoingWarpPrefix
+	k[0] = warpDestinationsPrefix
+	copy(k[1:], asset[:])
+	return
+}
+
+// innerGetDestinations parses a list of chain IDs (used by both the loan
+// destination index and the warp destination allowlist).
+func innerGetDestinations(v []byte, err error) ([]ids.ID, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	destinations := make([]ids.ID, len(v)/consts.IDLen)
+	for i := range destinations {
+		copy(destinations[i][:], v[i*consts.IDLen:])
+	}
+	return destinations, nil
+}
+
+// GetWarpDestinations returns the chains [asset] may be exported to. If no
+// allowlist is set, [asset] may be exported to any chain.
+func GetWarpDestinations(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+) ([]ids.ID, error) {
+	k := PrefixWarpDestinationsKey(asset)
+	return innerGetDestinations(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetWarpDestinationsFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+) ([]ids.ID, error) {
+	values, errs := f(ctx, [][]byte{PrefixWarpDestinationsKey(asset)})
+	return innerGetDestinations(values[0], errs[0])
+}
+
+// SetWarpDestinations replaces the allowlist of chains [asset] may be exported
+// to. Providing no destinations removes the allowlist.
+func SetWarpDestinations(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destinations []ids.ID,
+) error {
+	k := PrefixWarpDestinationsKey(asset)
+	if len(destinations) == 0 {
+		return db.Remove(ctx, k)
+	}
+	v := make([]byte, 0, len(destinations)*consts.IDLen)
+	for _, destination := range destinations {
+		v = append(v, destination[:]...)
+	}
+	return db.Insert(ctx, k, v)
+}
+
+// AllowedWarpDestination returns true if [asset] may be exported to
+// [destination].
+func AllowedWarpDestination(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+) (bool, error) {
+	destinations, err := GetWarpDestinations(ctx, db, asset)
+	if err != nil {
+		return false, err
+	}
+	if len(destinations) == 0 {
+		return true, nil
+	}
+	for _, d := range destinations {
+		if d == destination {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// [vestingPrefix] + [txID]
+func PrefixVestingKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = vestingPrefix
 	copy(k[1:], txID[:])
-	return k
+	return
+}
+
+func SetVesting(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	asset ids.ID,
+	beneficiary crypto.PublicKey,
+	amount uint64,
+	start int64,
+	cliff int64,
+	duration int64,
+	claimed uint64,
+) error {
+	k := PrefixVestingKey(txID)
+	v := make([]byte, consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*5)
+	copy(v, asset[:])
+	copy(v[consts.IDLen:], beneficiary[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen:], amount)
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len:], uint64(start))
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*2:], uint64(cliff))
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*3:], uint64(duration))
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*4:], claimed)
+	return db.Insert(ctx, k, v)
+}
+
+func GetVesting(
+	ctx context.Context,
+	db chain.Database,
+	vesting ids.ID,
+) (
+	bool, // exists
+	ids.ID, // asset
+	crypto.PublicKey, // beneficiary
+	uint64, // amount
+	int64, // start
+	int64, // cliff
+	int64, // duration
+	uint64, // claimed
+	error,
+) {
+	k := PrefixVestingKey(vesting)
+	return innerGetVesting(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetVestingFromState(
+	ctx context.Context,
+	f ReadState,
+	vesting ids.ID,
+) (bool, ids.ID, crypto.PublicKey, uint64, int64, int64, int64, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixVestingKey(vesting)})
+	return innerGetVesting(values[0], errs[0])
+}
+
+func innerGetVesting(
+	v []byte,
+	err error,
+) (bool, ids.ID, crypto.PublicKey, uint64, int64, int64, int64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, crypto.EmptyPublicKey, 0, 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, ids.Empty, crypto.EmptyPublicKey, 0, 0, 0, 0, 0, err
+	}
+	var asset ids.ID
+	copy(asset[:], v[:consts.IDLen])
+	var beneficiary crypto.PublicKey
+	copy(beneficiary[:], v[consts.IDLen:])
+	amount := binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen:])
+	start := int64(binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len:]))
+	cliff := int64(binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*2:]))
+	duration := int64(binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*3:]))
+	claimed := binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen+consts.Uint64Len*4:])
+	return true, asset, beneficiary, amount, start, cliff, duration, claimed, nil
+}
+
+func DeleteVesting(ctx context.Context, db chain.Database, vesting ids.ID) error {
+	k := PrefixVestingKey(vesting)
+	return db.Remove(ctx, k)
+}
+
+// [proposalIndexPrefix] + [txID]
+func PrefixProposalIndexKey(id ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = proposalIndexPrefix
+	copy(k[1:], id[:])
+	return
+}
+
+// StoreProposal records [id] in the metadata index so that proposals can be
+// listed without iterating over state.
+func StoreProposal(
+	_ context.Context,
+	db database.KeyValueWriter,
+	id ids.ID,
+) error {
+	return db.Put(PrefixProposalIndexKey(id), nil)
+}
+
+func GetProposals(
+	_ context.Context,
+	db database.Iteratee,
+) ([]ids.ID, error) {
+	iter := db.NewIteratorWithPrefix([]byte{proposalIndexPrefix})
+	defer iter.Release()
+
+	proposals := []ids.ID{}
+	for iter.Next() {
+		id, err := ids.ToID(iter.Key()[1:])
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, id)
+	}
+	return proposals, iter.Error()
+}
+
+// [nftIndexPrefix] + [nft]
+func PrefixNFTIndexKey(nft ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = nftIndexPrefix
+	copy(k[1:], nft[:])
+	return
+}
+
+// StoreNFT records [nft] in the metadata index so that fills of it on the
+// order book can be detected without reading state.
+func StoreNFT(
+	_ context.Context,
+	db database.KeyValueWriter,
+	nft ids.ID,
+) error {
+	return db.Put(PrefixNFTIndexKey(nft), nil)
+}
+
+func IsNFT(
+	_ context.Context,
+	db database.KeyValueReader,
+	nft ids.ID,
+) (bool, error) {
+	return db.Has(PrefixNFTIndexKey(nft))
+}
+
+// [nftOwnerIndexPrefix] + [owner] + [nft]
+func PrefixNFTOwnerIndexKey(owner crypto.PublicKey, nft ids.ID) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen+consts.IDLen)
+	k[0] = nftOwnerIndexPrefix
+	copy(k[1:], owner[:])
+	copy(k[1+crypto.PublicKeyLen:], nft[:])
+	return
+}
+
+// StoreNFTOwner records that [owner] may own [nft] in the metadata index.
+//
+// The index is only a hint: callers must check the owner in state with
+// [GetNFTFromState].
+func StoreNFTOwner(
+	_ context.Context,
+	db database.KeyValueWriter,
+	owner crypto.PublicKey,
+	nft ids.ID,
+) error {
+	return db.Put(PrefixNFTOwnerIndexKey(owner, nft), nil)
+}
+
+func DeleteNFTOwner(
+	_ context.Context,
+	db database.KeyValueDeleter,
+	owner crypto.PublicKey,
+	nft ids.ID,
+) error {
+	return db.Delete(PrefixNFTOwnerIndexKey(owner, nft))
+}
+
+func GetNFTsByOwner(
+	_ context.Context,
+	db database.Iteratee,
+	owner crypto.PublicKey,
+) ([]ids.ID, error) {
+	prefix := make([]byte, 1+crypto.PublicKeyLen)
+	prefix[0] = nftOwnerIndexPrefix
+	copy(prefix[1:], owner[:])
+	iter := db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	nfts := []ids.ID{}
+	for iter.Next() {
+		nft, err := ids.ToID(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		nfts = append(nfts, nft)
+	}
+	return nfts, iter.Error()
+}
+
+// [proposalPrefix] + [txID]
+func PrefixProposalKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = proposalPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetProposal(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	proposer crypto.PublicKey,
+	param uint8,
+	value uint64,
+	created int64,
+	yes uint64,
+	no uint64,
+	deposit uint64,
+	executed bool,
+) error {
+	k := PrefixProposalKey(txID)
+	v := make([]byte, crypto.PublicKeyLen+1+consts.Uint64Len*5+1)
+	copy(v, proposer[:])
+	v[crypto.PublicKeyLen] = param
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1:], value)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1+consts.Uint64Len:], uint64(created))
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1+consts.Uint64Len*2:], yes)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1+consts.Uint64Len*3:], no)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1+consts.Uint64Len*4:], deposit)
+	b := failureByte
+	if executed {
+		b = successByte
+	}
+	v[crypto.PublicKeyLen+1+consts.Uint64Len*5] = b
+	return db.Insert(ctx, k, v)
+}
+
+func GetProposal(
+	ctx context.Context,
+	db chain.Database,
+	proposal ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // proposer
+	uint8, // param
+	uint64, // value
+	int64, // created
+	uint64, // yes
+	uint64, // no
+	uint64, // deposit
+	bool, // executed
+	error,
+) {
+	k := PrefixProposalKey(proposal)
+	return innerGetProposal(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetProposalFromState(
+	ctx context.Context,
+	f ReadState,
+	proposal ids.ID,
+) (bool, crypto.PublicKey, uint8, uint64, int64, uint64, uint64, uint64, bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixProposalKey(proposal)})
+	return innerGetProposal(values[0], errs[0])
+}
+
+func innerGetProposal(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, uint8, uint64, int64, uint64, uint64, uint64, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, 0, 0, 0, 0, 0, 0, false, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, 0, 0, 0, 0, 0, 0, false, err
+	}
+	var proposer crypto.PublicKey
+	copy(proposer[:], v[:crypto.PublicKeyLen])
+	param := v[crypto.PublicKeyLen]
+	value := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1:])
+	created := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1+consts.Uint64Len:]))
+	yes := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1+consts.Uint64Len*2:])
+	no := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1+consts.Uint64Len*3:])
+	deposit := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1+consts.Uint64Len*4:])
+	executed := v[crypto.PublicKeyLen+1+consts.Uint64Len*5] == successByte
+	return true, proposer, param, value, created, yes, no, deposit, executed, nil
+}
+
+// [proposalPairPrefix] + [txID]
+func PrefixProposalPairKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = proposalPairPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+// SetProposalPair records the [in]-[out] pair changed by a proposal of a pair
+// param.
+func SetProposalPair(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	in ids.ID,
+	out ids.ID,
+) error {
+	k := PrefixProposalPairKey(txID)
+	v := make([]byte, consts.IDLen*2)
+	copy(v, in[:])
+	copy(v[consts.IDLen:], out[:])
+	return db.Insert(ctx, k, v)
+}
+
+func GetProposalPair(
+	ctx context.Context,
+	db chain.Database,
+	proposal ids.ID,
+) (ids.ID, ids.ID, error) {
+	k := PrefixProposalPairKey(proposal)
+	return innerGetProposalPair(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetProposalPairFromState(
+	ctx context.Context,
+	f ReadState,
+	proposal ids.ID,
+) (ids.ID, ids.ID, error) {
+	values, errs := f(ctx, [][]byte{PrefixProposalPairKey(proposal)})
+	return innerGetProposalPair(values[0], errs[0])
+}
+
+func innerGetProposalPair(v []byte, err error) (ids.ID, ids.ID, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return ids.Empty, ids.Empty, nil
+	}
+	if err != nil {
+		return ids.Empty, ids.Empty, err
+	}
+	var in, out ids.ID
+	copy(in[:], v[:consts.IDLen])
+	copy(out[:], v[consts.IDLen:])
+	return in, out, nil
+}
+
+// [votePrefix] + [proposal] + [voter]
+func PrefixVoteKey(proposal ids.ID, voter crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+crypto.PublicKeyLen)
+	k[0] = votePrefix
+	copy(k[1:], proposal[:])
+	copy(k[1+consts.IDLen:], voter[:])
+	return
+}
+
+func SetVote(
+	ctx context.Context,
+	db chain.Database,
+	proposal ids.ID,
+	voter crypto.PublicKey,
+	weight uint64,
+	support bool,
+) error {
+	k := PrefixVoteKey(proposal, voter)
+	v := make([]byte, consts.Uint64Len+1)
+	binary.BigEndian.PutUint64(v, weight)
+	if support {
+		v[consts.Uint64Len] = successByte
+	} else {
+		v[consts.Uint64Len] = failureByte
+	}
+	return db.Insert(ctx, k, v)
+}
+
+func GetVote(
+	ctx context.Context,
+	db chain.Database,
+	proposal ids.ID,
+	voter crypto.PublicKey,
+) (bool, uint64, bool, error) {
+	k := PrefixVoteKey(proposal, voter)
+	v, err := db.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, false, nil
+	}
+	if err != nil {
+		return false, 0, false, err
+	}
+	return true, binary.BigEndian.Uint64(v), v[consts.Uint64Len] == successByte, nil
+}
+
+func DeleteVote(
+	ctx context.Context,
+	db chain.Database,
+	proposal ids.ID,
+	voter crypto.PublicKey,
+) error {
+	k := PrefixVoteKey(proposal, voter)
+	return db.Remove(ctx, k)
+}
+
+// [paramPrefix] + [param]
+func PrefixParamKey(param uint8) (k []byte) {
+	return []byte{paramPrefix, param}
+}
+
+// [paramPrefix] + [param] + [in] + [out]
+func PrefixPairParamKey(param uint8, in ids.ID, out ids.ID) (k []byte) {
+	k = make([]byte, 2+consts.IDLen*2)
+	k[0] = paramPrefix
+	k[1] = param
+	copy(k[2:], in[:])
+	copy(k[2+consts.IDLen:], out[:])
+	return
+}
+
+const paramLen = 1 + consts.Uint64Len*3
+
+// SetParam stores the value of a param set by governance under [k] (created
+// with [PrefixParamKey] or [PrefixPairParamKey]).
+//
+// [active] is only in effect if [activeSet] is true. [pending] replaces it for
+// all blocks with a timestamp at or after [activation] (if [activation] is not
+// 0).
+func SetParam(
+	ctx context.Context,
+	db chain.Database,
+	k []byte,
+	activeSet bool,
+	active uint64,
+	pending uint64,
+	activation int64,
+) error {
+	v := make([]byte, paramLen)
+	if activeSet {
+		v[0] = successByte
+	} else {
+		v[0] = failureByte
+	}
+	binary.BigEndian.PutUint64(v[1:], active)
+	binary.BigEndian.PutUint64(v[1+consts.Uint64Len:], pending)
+	binary.BigEndian.PutUint64(v[1+consts.Uint64Len*2:], uint64(activation))
+	return db.Insert(ctx, k, v)
+}
+
+func GetParam(
+	ctx context.Context,
+	db chain.Database,
+	k []byte,
+) (
+	bool, // activeSet
+	uint64, // active
+	uint64, // pending
+	int64, // activation
+	error,
+) {
+	return innerGetParam(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetParamFromState(
+	ctx context.Context,
+	f ReadState,
+	k []byte,
+) (bool, uint64, uint64, int64, error) {
+	values, errs := f(ctx, [][]byte{k})
+	return innerGetParam(values[0], errs[0])
+}
+
+func innerGetParam(v []byte, err error) (bool, uint64, uint64, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, 0, 0, 0, err
+	}
+	return v[0] == successByte,
+		binary.BigEndian.Uint64(v[1:]),
+		binary.BigEndian.Uint64(v[1+consts.Uint64Len:]),
+		int64(binary.BigEndian.Uint64(v[1+consts.Uint64Len*2:])),
+		nil
+}
+
+// ParamAt returns the value of a param set by governance that is in effect at
+// [t], if there is one.
+func ParamAt(activeSet bool, active uint64, pending uint64, activation int64, t int64) (uint64, bool) {
+	if activation != 0 && activation <= t {
+		return pending, true
+	}
+	return active, activeSet
+}
+
+// [swapNoncePrefix] + [owner] + [nonce]
+func PrefixSwapNonceKey(owner crypto.PublicKey, nonce uint64) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen+consts.Uint64Len)
+	k[0] = swapNoncePrefix
+	copy(k[1:], owner[:])
+	binary.BigEndian.PutUint64(k[1+crypto.PublicKeyLen:], nonce)
+	return
+}
+
+// GetSwapNonce returns true if [owner] already had a swap offer with [nonce]
+// settled.
+func GetSwapNonce(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	nonce uint64,
+) (bool, error) {
+	k := PrefixSwapNonceKey(owner, nonce)
+	return innerGetFlag(db.GetValue(ctx, k))
+}
+
+func SetSwapNonce(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	nonce uint64,
+) error {
+	return setFlag(ctx, db, PrefixSwapNonceKey(owner, nonce), true)
+}
+
+// [sponsorRatePrefix] + [sponsor] + [asset]
+func PrefixSponsorRateKey(sponsor crypto.PublicKey, asset ids.ID) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen+consts.IDLen)
+	k[0] = sponsorRatePrefix
+	copy(k[1:], sponsor[:])
+	copy(k[1+crypto.PublicKeyLen:], asset[:])
+	return
+}
+
+// GetSponsorRate returns the amount of [asset] that [sponsor] charges for
+// each whole native token it pays in fees. If [sponsor] does not accept
+// [asset], it returns 0.
+func GetSponsorRate(
+	ctx context.Context,
+	db chain.Database,
+	sponsor crypto.PublicKey,
+	asset ids.ID,
+) (uint64, error) {
+	k := PrefixSponsorRateKey(sponsor, asset)
+	return innerGetBalance(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetSponsorRateFromState(
+	ctx context.Context,
+	f ReadState,
+	sponsor crypto.PublicKey,
+	asset ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixSponsorRateKey(sponsor, asset)})
+	return innerGetBalance(values[0], errs[0])
+}
+
+// SetSponsorRate sets the rate [sponsor] charges in [asset]. A [rate] of 0
+// removes the rate.
+func SetSponsorRate(
+	ctx context.Context,
+	db chain.Database,
+	sponsor crypto.PublicKey,
+	asset ids.ID,
+	rate uint64,
+) error {
+	k := PrefixSponsorRateKey(sponsor, asset)
+	if rate == 0 {
+		return db.Remove(ctx, k)
+	}
+	return setBalance(ctx, db, k, rate)
+}
+
+// [sessionPrefix] + [owner] + [key]
+func PrefixSessionKey(owner crypto.PublicKey, key crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen*2)
+	k[0] = sessionPrefix
+	copy(k[1:], owner[:])
+	copy(k[1+crypto.PublicKeyLen:], key[:])
+	return
+}
+
+// SetSession registers [key] as a session key of [owner] until [expiry]. The
+// session may only perform [actions] (by action type ID) and spend up to
+// [remaining] of each of [assets].
+func SetSession(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	key crypto.PublicKey,
+	expiry int64,
+	actions []uint8,
+	assets []ids.ID,
+	remaining []uint64,
+) error {
+	k := PrefixSessionKey(owner, key)
+	v := make(
+		[]byte,
+		consts.Uint64Len+2+len(actions)+len(assets)*(consts.IDLen+consts.Uint64Len),
+	)
+	binary.BigEndian.PutUint64(v, uint64(expiry))
+	v[consts.Uint64Len] = uint8(len(actions))
+	copy(v[consts.Uint64Len+1:], actions)
+	offset := consts.Uint64Len + 1 + len(actions)
+	v[offset] = uint8(len(assets))
+	offset++
+	for i, asset := range assets {
+		copy(v[offset:], asset[:])
+		binary.BigEndian.PutUint64(v[offset+consts.IDLen:], remaining[i])
+		offset += consts.IDLen + consts.Uint64Len
+	}
+	return db.Insert(ctx, k, v)
+}
+
+func GetSession(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	key crypto.PublicKey,
+) (
+	bool, // exists
+	int64, // expiry
+	[]uint8, // actions
+	[]ids.ID, // assets
+	[]uint64, // remaining
+	error,
+) {
+	k := PrefixSessionKey(owner, key)
+	return innerGetSession(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetSessionFromState(
+	ctx context.Context,
+	f ReadState,
+	owner crypto.PublicKey,
+	key crypto.PublicKey,
+) (bool, int64, []uint8, []ids.ID, []uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixSessionKey(owner, key)})
+	return innerGetSession(values[0], errs[0])
+}
+
+func innerGetSession(
+	v []byte,
+	err error,
+) (bool, int64, []uint8, []ids.ID, []uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, nil, nil, err
+	}
+	expiry := int64(binary.BigEndian.Uint64(v))
+	actionsLen := int(v[consts.Uint64Len])
+	actions := make([]uint8, actionsLen)
+	copy(actions, v[consts.Uint64Len+1:])
+	offset := consts.Uint64Len + 1 + actionsLen
+	assets := make([]ids.ID, v[offset])
+	remaining := make([]uint64, len(assets))
+	offset++
+	for i := range assets {
+		copy(assets[i][:], v[offset:])
+		remaining[i] = binary.BigEndian.Uint64(v[offset+consts.IDLen:])
+		offset += consts.IDLen + consts.Uint64Len
+	}
+	return true, expiry, actions, assets, remaining, nil
+}
+
+func DeleteSession(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	key crypto.PublicKey,
+) error {
+	return db.Remove(ctx, PrefixSessionKey(owner, key))
+}
+
+// [guardiansPrefix] + [account]
+func PrefixGuardiansKey(account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = guardiansPrefix
+	copy(k[1:], account[:])
+	return
+}
+
+// SetGuardians allows any [threshold] of [guardians] to rotate the key of
+// [account] after [delay]. [nonce] is included in every approval signed by a
+// guardian so that approvals can't be replayed.
+func SetGuardians(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	threshold uint8,
+	delay int64,
+	nonce uint64,
+	guardians []crypto.PublicKey,
+) error {
+	k := PrefixGuardiansKey(account)
+	v := make([]byte, 1+consts.Uint64Len*2+len(guardians)*crypto.PublicKeyLen)
+	v[0] = threshold
+	binary.BigEndian.PutUint64(v[1:], uint64(delay))
+	binary.BigEndian.PutUint64(v[1+consts.Uint64Len:], nonce)
+	for i, guardian := range guardians {
+		copy(v[1+consts.Uint64Len*2+i*crypto.PublicKeyLen:], guardian[:])
+	}
+	return db.Insert(ctx, k, v)
+}
+
+func GetGuardians(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+) (
+	uint8, // threshold
+	int64, // delay
+	uint64, // nonce
+	[]crypto.PublicKey, // guardians
+	error,
+) {
+	k := PrefixGuardiansKey(account)
+	return innerGetGuardians(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetGuardiansFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+) (uint8, int64, uint64, []crypto.PublicKey, error) {
+	values, errs := f(ctx, [][]byte{PrefixGuardiansKey(account)})
+	return innerGetGuardians(values[0], errs[0])
+}
+
+func innerGetGuardians(
+	v []byte,
+	err error,
+) (uint8, int64, uint64, []crypto.PublicKey, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, 0, 0, nil, nil
+	}
+	if err != nil {
+		return 0, 0, 0, nil, err
+	}
+	threshold := v[0]
+	delay := int64(binary.BigEndian.Uint64(v[1:]))
+	nonce := binary.BigEndian.Uint64(v[1+consts.Uint64Len:])
+	guardians := make([]crypto.PublicKey, (len(v)-1-consts.Uint64Len*2)/crypto.PublicKeyLen)
+	for i := range guardians {
+		copy(guardians[i][:], v[1+consts.Uint64Len*2+i*crypto.PublicKeyLen:])
+	}
+	return threshold, delay, nonce, guardians, nil
+}
+
+// [recoveryPrefix] + [account]
+func PrefixRecoveryKey(account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = recoveryPrefix
+	copy(k[1:], account[:])
+	return
+}
+
+// SetRecovery records that control of [account] will move to [key] at
+// [ready].
+func SetRecovery(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	key crypto.PublicKey,
+	ready int64,
+) error {
+	k := PrefixRecoveryKey(account)
+	v := make([]byte, crypto.PublicKeyLen+consts.Uint64Len)
+	copy(v, key[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen:], uint64(ready))
+	return db.Insert(ctx, k, v)
+}
+
+func GetRecovery(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+) (
+	bool, // exists
+	crypto.PublicKey, // key
+	int64, // ready
+	error,
+) {
+	k := PrefixRecoveryKey(account)
+	return innerGetRecovery(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetRecoveryFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+) (bool, crypto.PublicKey, int64, error) {
+	values, errs := f(ctx, [][]byte{PrefixRecoveryKey(account)})
+	return innerGetRecovery(values[0], errs[0])
+}
+
+func innerGetRecovery(v []byte, err error) (bool, crypto.PublicKey, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, 0, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, 0, err
+	}
+	var key crypto.PublicKey
+	copy(key[:], v)
+	ready := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen:]))
+	return true, key, ready, nil
+}
+
+func DeleteRecovery(ctx context.Context, db chain.Database, account crypto.PublicKey) error {
+	return db.Remove(ctx, PrefixRecoveryKey(account))
+}
+
+// [accountKeyPrefix] + [account]
+func PrefixAccountKey(account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = accountKeyPrefix
+	copy(k[1:], account[:])
+	return
+}
+
+// GetAccountKey returns the key that controls [account]. Unless [account] was
+// recovered, this is [account] itself.
+func GetAccountKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+) (crypto.PublicKey, error) {
+	k := PrefixAccountKey(account)
+	v, err := db.GetValue(ctx, k)
+	return innerGetAccountKey(account, v, err)
+}
+
+// Used to serve RPC queries
+func GetAccountKeyFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+) (crypto.PublicKey, error) {
+	values, errs := f(ctx, [][]byte{PrefixAccountKey(account)})
+	return innerGetAccountKey(account, values[0], errs[0])
+}
+
+func innerGetAccountKey(account crypto.PublicKey, v []byte, err error) (crypto.PublicKey, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return account, nil
+	}
+	if err != nil {
+		return crypto.EmptyPublicKey, err
+	}
+	var key crypto.PublicKey
+	copy(key[:], v)
+	return key, nil
+}
+
+// SetAccountKey moves control of [account] to [key].
+func SetAccountKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	key crypto.PublicKey,
+) error {
+	k := PrefixAccountKey(account)
+	if key == account {
+		return db.Remove(ctx, k)
+	}
+	return db.Insert(ctx, k, key[:])
+}
+
+// [namePrefix] + [name]
+func PrefixNameKey(name string) (k []byte) {
+	k = make([]byte, 1+len(name))
+	k[0] = namePrefix
+	copy(k[1:], name)
+	return
+}
+
+// SetName records that [name] is owned by [owner] until [expiry] and points
+// to [address] and [asset].
+func SetName(
+	ctx context.Context,
+	db chain.Database,
+	name string,
+	owner crypto.PublicKey,
+	address crypto.PublicKey,
+	asset ids.ID,
+	expiry int64,
+) error {
+	k := PrefixNameKey(name)
+	v := make([]byte, crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len)
+	copy(v, owner[:])
+	copy(v[crypto.PublicKeyLen:], address[:])
+	copy(v[crypto.PublicKeyLen*2:], asset[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen:], uint64(expiry))
+	return db.Insert(ctx, k, v)
+}
+
+func GetName(
+	ctx context.Context,
+	db chain.Database,
+	name string,
+) (
+	bool, // exists
+	crypto.PublicKey, // owner
+	crypto.PublicKey, // address
+	ids.ID, // asset
+	int64, // expiry
+	error,
+) {
+	k := PrefixNameKey(name)
+	return innerGetName(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetNameFromState(
+	ctx context.Context,
+	f ReadState,
+	name string,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, int64, error) {
+	values, errs := f(ctx, [][]byte{PrefixNameKey(name)})
+	return innerGetName(values[0], errs[0])
+}
+
+func innerGetName(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, ids.Empty, 0, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, ids.Empty, 0, err
+	}
+	var owner crypto.PublicKey
+	copy(owner[:], v)
+	var address crypto.PublicKey
+	copy(address[:], v[crypto.PublicKeyLen:])
+	var asset ids.ID
+	copy(asset[:], v[crypto.PublicKeyLen*2:])
+	expiry := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen:]))
+	return true, owner, address, asset, expiry, nil
+}
+
+// [reverseNamePrefix] + [target]
+//
+// [target] is either an address or an asset ID.
+func PrefixReverseNameKey(target [32]byte) (k []byte) {
+	k = make([]byte, 1+len(target))
+	k[0] = reverseNamePrefix
+	copy(k[1:], target[:])
+	return
+}
+
+// SetReverseName records that [target] (an address or asset ID) is named
+// [name].
+func SetReverseName(
+	ctx context.Context,
+	db chain.Database,
+	target [32]byte,
+	name string,
+) error {
+	return db.Insert(ctx, PrefixReverseNameKey(target), []byte(name))
+}
+
+// Used to serve RPC queries
+//
+// The returned name may have expired or been pointed elsewhere since it was
+// recorded, so callers must check it against [GetNameFromState].
+func GetReverseNameFromState(
+	ctx context.Context,
+	f ReadState,
+	target [32]byte,
+) (string, error) {
+	values, errs := f(ctx, [][]byte{PrefixReverseNameKey(target)})
+	if errors.Is(errs[0], database.ErrNotFound) {
+		return "", nil
+	}
+	if errs[0] != nil {
+		return "", errs[0]
+	}
+	return string(values[0]), nil
+}
+
+// [collectionPrefix] + [collection]
+func PrefixCollectionKey(collection ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = collectionPrefix
+	copy(k[1:], collection[:])
+	return
+}
+
+// SetCollection records that [collection] is owned by [owner] and has
+// [supply] NFTs.
+func SetCollection(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	owner crypto.PublicKey,
+	supply uint64,
+	metadata []byte,
+) error {
+	k := PrefixCollectionKey(collection)
+	metadataLen := len(metadata)
+	v := make([]byte, crypto.PublicKeyLen+consts.Uint64Len+consts.Uint16Len+metadataLen)
+	copy(v, owner[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen:], supply)
+	binary.BigEndian.PutUint16(v[crypto.PublicKeyLen+consts.Uint64Len:], uint16(metadataLen))
+	copy(v[crypto.PublicKeyLen+consts.Uint64Len+consts.Uint16Len:], metadata)
+	return db.Insert(ctx, k, v)
+}
+
+func GetCollection(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // owner
+	uint64, // supply
+	[]byte, // metadata
+	error,
+) {
+	k := PrefixCollectionKey(collection)
+	return innerGetCollection(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetCollectionFromState(
+	ctx context.Context,
+	f ReadState,
+	collection ids.ID,
+) (bool, crypto.PublicKey, uint64, []byte, error) {
+	values, errs := f(ctx, [][]byte{PrefixCollectionKey(collection)})
+	return innerGetCollection(values[0], errs[0])
+}
+
+func innerGetCollection(v []byte, err error) (bool, crypto.PublicKey, uint64, []byte, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, 0, nil, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, 0, nil, err
+	}
+	var owner crypto.PublicKey
+	copy(owner[:], v)
+	supply := binary.BigEndian.Uint64(v[crypto.PublicKeyLen:])
+	metadataLen := binary.BigEndian.Uint16(v[crypto.PublicKeyLen+consts.Uint64Len:])
+	metadataStart := crypto.PublicKeyLen + consts.Uint64Len + consts.Uint16Len
+	metadata := v[metadataStart : metadataStart+int(metadataLen)]
+	return true, owner, supply, metadata, nil
+}
+
+// [nftPrefix] + [nft]
+func PrefixNFTKey(nft ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = nftPrefix
+	copy(k[1:], nft[:])
+	return
+}
+
+// SetNFT records that [nft] is token [id] of [collection] and is owned by
+// [owner]. The metadata of [nft] is stored in its asset record.
+func SetNFT(
+	ctx context.Context,
+	db chain.Database,
+	nft ids.ID,
+	collection ids.ID,
+	id uint64,
+	owner crypto.PublicKey,
+) error {
+	k := PrefixNFTKey(nft)
+	v := make([]byte, consts.IDLen+consts.Uint64Len+crypto.PublicKeyLen)
+	copy(v, collection[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen:], id)
+	copy(v[consts.IDLen+consts.Uint64Len:], owner[:])
+	return db.Insert(ctx, k, v)
+}
+
+func GetNFT(
+	ctx context.Context,
+	db chain.Database,
+	nft ids.ID,
+) (
+	bool, // exists
+	ids.ID, // collection
+	uint64, // id
+	crypto.PublicKey, // owner
+	error,
+) {
+	k := PrefixNFTKey(nft)
+	return innerGetNFT(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetNFTFromState(
+	ctx context.Context,
+	f ReadState,
+	nft ids.ID,
+) (bool, ids.ID, uint64, crypto.PublicKey, error) {
+	values, errs := f(ctx, [][]byte{PrefixNFTKey(nft)})
+	return innerGetNFT(values[0], errs[0])
+}
+
+func innerGetNFT(v []byte, err error) (bool, ids.ID, uint64, crypto.PublicKey, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, 0, crypto.EmptyPublicKey, nil
+	}
+	if err != nil {
+		return false, ids.Empty, 0, crypto.EmptyPublicKey, err
+	}
+	var collection ids.ID
+	copy(collection[:], v)
+	id := binary.BigEndian.Uint64(v[consts.IDLen:])
+	var owner crypto.PublicKey
+	copy(owner[:], v[consts.IDLen+consts.Uint64Len:])
+	return true, collection, id, owner, nil
+}
+
+func DeleteNFT(ctx context.Context, db chain.Database, nft ids.ID) error {
+	return db.Remove(ctx, PrefixNFTKey(nft))
+}
+
+// [streamPrefix] + [txID]
+func PrefixStreamKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = streamPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetStream(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	asset ids.ID,
+	sender crypto.PublicKey,
+	recipient crypto.PublicKey,
+	amount uint64,
+	start int64,
+	end int64,
+	withdrawn uint64,
+) error {
+	k := PrefixStreamKey(txID)
+	v := make([]byte, consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len*4)
+	copy(v, asset[:])
+	copy(v[consts.IDLen:], sender[:])
+	copy(v[consts.IDLen+crypto.PublicKeyLen:], recipient[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen*2:], amount)
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len:], uint64(start))
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len*2:], uint64(end))
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len*3:], withdrawn)
+	return db.Insert(ctx, k, v)
+}
+
+func GetStream(
+	ctx context.Context,
+	db chain.Database,
+	stream ids.ID,
+) (
+	bool, // exists
+	ids.ID, // asset
+	crypto.PublicKey, // sender
+	crypto.PublicKey, // recipient
+	uint64, // amount
+	int64, // start
+	int64, // end
+	uint64, // withdrawn
+	error,
+) {
+	k := PrefixStreamKey(stream)
+	return innerGetStream(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetStreamFromState(
+	ctx context.Context,
+	f ReadState,
+	stream ids.ID,
+) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, uint64, int64, int64, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixStreamKey(stream)})
+	return innerGetStream(values[0], errs[0])
+}
+
+func innerGetStream(
+	v []byte,
+	err error,
+) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, uint64, int64, int64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, crypto.EmptyPublicKey, crypto.EmptyPublicKey, 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, ids.Empty, crypto.EmptyPublicKey, crypto.EmptyPublicKey, 0, 0, 0, 0, err
+	}
+	var asset ids.ID
+	copy(asset[:], v[:consts.IDLen])
+	var sender crypto.PublicKey
+	copy(sender[:], v[consts.IDLen:])
+	var recipient crypto.PublicKey
+	copy(recipient[:], v[consts.IDLen+crypto.PublicKeyLen:])
+	amount := binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen*2:])
+	start := int64(binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len:]))
+	end := int64(binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len*2:]))
+	withdrawn := binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen*2+consts.Uint64Len*3:])
+	return true, asset, sender, recipient, amount, start, end, withdrawn, nil
+}
+
+func DeleteStream(ctx context.Context, db chain.Database, stream ids.ID) error {
+	k := PrefixStreamKey(stream)
+	return db.Remove(ctx, k)
+}
+
+// [airdropPrefix] + [txID]
+func PrefixAirdropKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = airdropPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetAirdrop(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	asset ids.ID,
+	creator crypto.PublicKey,
+	root ids.ID,
+	expiry int64,
+	remaining uint64,
+) error {
+	k := PrefixAirdropKey(txID)
+	v := make([]byte, consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len*2)
+	copy(v, asset[:])
+	copy(v[consts.IDLen:], creator[:])
+	copy(v[consts.IDLen+crypto.PublicKeyLen:], root[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+crypto.PublicKeyLen:], uint64(expiry))
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len:], remaining)
+	return db.Insert(ctx, k, v)
+}
+
+func GetAirdrop(
+	ctx context.Context,
+	db chain.Database,
+	airdrop ids.ID,
+) (
+	bool, // exists
+	ids.ID, // asset
+	crypto.PublicKey, // creator
+	ids.ID, // root
+	int64, // expiry
+	uint64, // remaining
+	error,
+) {
+	k := PrefixAirdropKey(airdrop)
+	return innerGetAirdrop(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetAirdropFromState(
+	ctx context.Context,
+	f ReadState,
+	airdrop ids.ID,
+) (bool, ids.ID, crypto.PublicKey, ids.ID, int64, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixAirdropKey(airdrop)})
+	return innerGetAirdrop(values[0], errs[0])
+}
+
+func innerGetAirdrop(
+	v []byte,
+	err error,
+) (bool, ids.ID, crypto.PublicKey, ids.ID, int64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, crypto.EmptyPublicKey, ids.Empty, 0, 0, nil
+	}
+	if err != nil {
+		return false, ids.Empty, crypto.EmptyPublicKey, ids.Empty, 0, 0, err
+	}
+	var asset ids.ID
+	copy(asset[:], v[:consts.IDLen])
+	var creator crypto.PublicKey
+	copy(creator[:], v[consts.IDLen:])
+	var root ids.ID
+	copy(root[:], v[consts.IDLen+crypto.PublicKeyLen:])
+	expiry := int64(binary.BigEndian.Uint64(v[consts.IDLen*2+crypto.PublicKeyLen:]))
+	remaining := binary.BigEndian.Uint64(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len:])
+	return true, asset, creator, root, expiry, remaining, nil
+}
+
+func DeleteAirdrop(ctx context.Context, db chain.Database, airdrop ids.ID) error {
+	k := PrefixAirdropKey(airdrop)
+	return db.Remove(ctx, k)
+}
+
+// [airdropClaimPrefix] + [airdrop] + [recipient]
+func PrefixAirdropClaimKey(airdrop ids.ID, recipient crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+crypto.PublicKeyLen)
+	k[0] = airdropClaimPrefix
+	copy(k[1:], airdrop[:])
+	copy(k[1+consts.IDLen:], recipient[:])
+	return
+}
+
+// GetAirdropClaimed returns true if [recipient] already claimed from
+// [airdrop].
+func GetAirdropClaimed(
+	ctx context.Context,
+	db chain.Database,
+	airdrop ids.ID,
+	recipient crypto.PublicKey,
+) (bool, error) {
+	k := PrefixAirdropClaimKey(airdrop, recipient)
+	return innerGetFlag(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetAirdropClaimedFromState(
+	ctx context.Context,
+	f ReadState,
+	airdrop ids.ID,
+	recipient crypto.PublicKey,
+) (bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixAirdropClaimKey(airdrop, recipient)})
+	return innerGetFlag(values[0], errs[0])
+}
+
+func SetAirdropClaimed(
+	ctx context.Context,
+	db chain.Database,
+	airdrop ids.ID,
+	recipient crypto.PublicKey,
+) error {
+	return setFlag(ctx, db, PrefixAirdropClaimKey(airdrop, recipient), true)
+}
+
+// [escrowPrefix] + [txID]
+func PrefixEscrowKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = escrowPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetEscrow(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	asset ids.ID,
+	payer crypto.PublicKey,
+	payee crypto.PublicKey,
+	arbiter crypto.PublicKey,
+	amount uint64,
+	deadline int64,
+	releaseOnTimeout bool,
+) error {
+	k := PrefixEscrowKey(txID)
+	v := make([]byte, consts.IDLen+crypto.PublicKeyLen*3+consts.Uint64Len*2+1)
+	copy(v, asset[:])
+	copy(v[consts.IDLen:], payer[:])
+	copy(v[consts.IDLen+crypto.PublicKeyLen:], payee[:])
+	copy(v[consts.IDLen+crypto.PublicKeyLen*2:], arbiter[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen*3:], amount)
+	binary.BigEndian.PutUint64(v[consts.IDLen+crypto.PublicKeyLen*3+consts.Uint64Len:], uint64(deadline))
+	b := failureByte
+	if releaseOnTimeout {
+		b = successByte
+	}
+	v[consts.IDLen+crypto.PublicKeyLen*3+consts.Uint64Len*2] = b
+	return db.Insert(ctx, k, v)
+}
+
+func GetEscrow(
+	ctx context.Context,
+	db chain.Database,
+	escrow ids.ID,
+) (
+	bool, // exists
+	ids.ID, // asset
+	crypto.PublicKey, // payer
+	crypto.PublicKey, // payee
+	crypto.PublicKey, // arbiter
+	uint64, // amount
+	int64, // deadline
+	bool, // releaseOnTimeout
+	error,
+) {
+	k := PrefixEscrowKey(escrow)
+	return innerGetEscrow(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetEscrowFromState(
+	ctx context.Context,
+	f ReadState,
+	escrow ids.ID,
+) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, crypto.PublicKey, uint64, int64, bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixEscrowKey(escrow)})
+	return innerGetEscrow(values[0], errs[0])
+}
+
+func innerGetEscrow(
+	v []byte,
+	err error,
+) (bool, ids.ID, crypto.PublicKey, crypto.PublicKey, crypto.PublicKey, uint64, int64, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, crypto.EmptyPublicKey, crypto.EmptyPublicKey, crypto.EmptyPublicKey, 0, 0, false, nil
+	}
+	if err != nil {
+		return false, ids.Empty, crypto.EmptyPublicKey, crypto.EmptyPublicKey, crypto.EmptyPublicKey, 0, 0, false, err
+	}
+	var asset ids.ID
+	copy(asset[:], v[:consts.IDLen])
+	var payer crypto.PublicKey
+	copy(payer[:], v[consts.IDLen:])
+	var payee crypto.PublicKey
+	copy(payee[:], v[consts.IDLen+crypto.PublicKeyLen:])
+	var arbiter crypto.PublicKey
+	copy(arbiter[:], v[consts.IDLen+crypto.PublicKeyLen*2:])
+	amount := binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen*3:])
+	deadline := int64(binary.BigEndian.Uint64(v[consts.IDLen+crypto.PublicKeyLen*3+consts.Uint64Len:]))
+	releaseOnTimeout := v[consts.IDLen+crypto.PublicKeyLen*3+consts.Uint64Len*2] == successByte
+	return true, asset, payer, payee, arbiter, amount, deadline, releaseOnTimeout, nil
+}
+
+func DeleteEscrow(ctx context.Context, db chain.Database, escrow ids.ID) error {
+	k := PrefixEscrowKey(escrow)
+	return db.Remove(ctx, k)
+}
+
+// [feedPrefix] + [txID]
+func PrefixFeedKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = feedPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetFeed(
+	ctx context.Context,
+	db chain.Database,
+	feed ids.ID,
+	owner crypto.PublicKey,
+	decimals uint8,
+	price uint64,
+	timestamp int64,
+	metadata []byte,
+) error {
+	k := PrefixFeedKey(feed)
+	metadataLen := len(metadata)
+	v := make([]byte, crypto.PublicKeyLen+1+consts.Uint64Len*2+consts.Uint16Len+metadataLen)
+	copy(v, owner[:])
+	v[crypto.PublicKeyLen] = decimals
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1:], price)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+1+consts.Uint64Len:], uint64(timestamp))
+	binary.BigEndian.PutUint16(v[crypto.PublicKeyLen+1+consts.Uint64Len*2:], uint16(metadataLen))
+	copy(v[crypto.PublicKeyLen+1+consts.Uint64Len*2+consts.Uint16Len:], metadata)
+	return db.Insert(ctx, k, v)
+}
+
+func GetFeed(
+	ctx context.Context,
+	db chain.Database,
+	feed ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // owner
+	uint8, // decimals
+	uint64, // price
+	int64, // timestamp
+	[]byte, // metadata
+	error,
+) {
+	k := PrefixFeedKey(feed)
+	return innerGetFeed(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetFeedFromState(
+	ctx context.Context,
+	f ReadState,
+	feed ids.ID,
+) (bool, crypto.PublicKey, uint8, uint64, int64, []byte, error) {
+	values, errs := f(ctx, [][]byte{PrefixFeedKey(feed)})
+	return innerGetFeed(values[0], errs[0])
+}
+
+func innerGetFeed(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, uint8, uint64, int64, []byte, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, 0, 0, 0, nil, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, 0, 0, 0, nil, err
+	}
+	var owner crypto.PublicKey
+	copy(owner[:], v[:crypto.PublicKeyLen])
+	decimals := v[crypto.PublicKeyLen]
+	price := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1:])
+	timestamp := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen+1+consts.Uint64Len:]))
+	metadataLen := binary.BigEndian.Uint16(v[crypto.PublicKeyLen+1+consts.Uint64Len*2:])
+	metadata := v[crypto.PublicKeyLen+1+consts.Uint64Len*2+consts.Uint16Len:]
+	return true, owner, decimals, price, timestamp, metadata[:metadataLen], nil
+}
+
+// [feedHistoryPrefix] + [feed] + [^timestamp]
+//
+// The timestamp is inverted so that iterating over the history of a feed
+// yields the newest price first.
+func PrefixFeedHistoryKey(feed ids.ID, timestamp int64) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len)
+	k[0] = feedHistoryPrefix
+	copy(k[1:], feed[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], ^uint64(timestamp))
+	return
+}
+
+// StoreFeedPrice records the [price] of [feed] at [timestamp] in the
+// metadata index. If [feed] is updated more than once at the same
+// [timestamp], only the last price is kept.
+func StoreFeedPrice(
+	_ context.Context,
+	db database.KeyValueWriter,
+	feed ids.ID,
+	timestamp int64,
+	price uint64,
+) error {
+	v := make([]byte, consts.Uint64Len)
+	binary.BigEndian.PutUint64(v, price)
+	return db.Put(PrefixFeedHistoryKey(feed, timestamp), v)
+}
+
+// GetFeedHistory returns the timestamps and prices of the last [limit]
+// updates of [feed] (oldest first).
+func GetFeedHistory(
+	_ context.Context,
+	db database.Iteratee,
+	feed ids.ID,
+	limit int,
+) ([]int64, []uint64, error) {
+	prefix := make([]byte, 1+consts.IDLen)
+	prefix[0] = feedHistoryPrefix
+	copy(prefix[1:], feed[:])
+	iter := db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	timestamps := []int64{}
+	prices := []uint64{}
+	for len(timestamps) < limit && iter.Next() {
+		timestamps = append(timestamps, int64(^binary.BigEndian.Uint64(iter.Key()[len(prefix):])))
+		prices = append(prices, binary.BigEndian.Uint64(iter.Value()))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, nil, err
+	}
+	for i, j := 0, len(timestamps)-1; i < j; i, j = i+1, j-1 {
+		timestamps[i], timestamps[j] = timestamps[j], timestamps[i]
+		prices[i], prices[j] = prices[j], prices[i]
+	}
+	return timestamps, prices, nil
+}
+
+// [lastTradePrefix] + [in] + [out]
+func PrefixLastTradeKey(in ids.ID, out ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2)
+	k[0] = lastTradePrefix
+	copy(k[1:], in[:])
+	copy(k[1+consts.IDLen:], out[:])
+	return
+}
+
+// SetLastTrade records that the last fill of an order selling [out] for [in]
+// paid [inAmount] of [in] for [outAmount] of [out].
+func SetLastTrade(
+	ctx context.Context,
+	db chain.Database,
+	in ids.ID,
+	out ids.ID,
+	inAmount uint64,
+	outAmount uint64,
+) error {
+	k := PrefixLastTradeKey(in, out)
+	v := make([]byte, consts.Uint64Len*2)
+	binary.BigEndian.PutUint64(v, inAmount)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], outAmount)
+	return db.Insert(ctx, k, v)
+}
+
+func GetLastTrade(
+	ctx context.Context,
+	db chain.Database,
+	in ids.ID,
+	out ids.ID,
+) (
+	bool, // exists
+	uint64, // inAmount
+	uint64, // outAmount
+	error,
+) {
+	k := PrefixLastTradeKey(in, out)
+	return innerGetLastTrade(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetLastTradeFromState(
+	ctx context.Context,
+	f ReadState,
+	in ids.ID,
+	out ids.ID,
+) (bool, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixLastTradeKey(in, out)})
+	return innerGetLastTrade(values[0], errs[0])
+}
+
+func innerGetLastTrade(v []byte, err error) (bool, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, 0, nil
+	}
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return true, binary.BigEndian.Uint64(v), binary.BigEndian.Uint64(v[consts.Uint64Len:]), nil
+}
+
+// [triggerOrderPrefix] + [txID]
+func PrefixTriggerOrderKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = triggerOrderPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetTriggerOrder(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	in ids.ID,
+	out ids.ID,
+	owner crypto.PublicKey,
+	value uint64,
+	reward uint64,
+	minOut uint64,
+	feed ids.ID,
+	triggerIn uint64,
+	triggerOut uint64,
+	above bool,
+) error {
+	k := PrefixTriggerOrderKey(txID)
+	v := make([]byte, consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*5+1)
+	copy(v, in[:])
+	copy(v[consts.IDLen:], out[:])
+	copy(v[consts.IDLen*2:], owner[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+crypto.PublicKeyLen:], value)
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len:], reward)
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len*2:], minOut)
+	copy(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len*3:], feed[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*3:], triggerIn)
+	binary.BigEndian.PutUint64(v[consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*4:], triggerOut)
+	b := failureByte
+	if above {
+		b = successByte
+	}
+	v[consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*5] = b
+	return db.Insert(ctx, k, v)
+}
+
+func GetTriggerOrder(
+	ctx context.Context,
+	db chain.Database,
+	trigger ids.ID,
+) (
+	bool, // exists
+	ids.ID, // in
+	ids.ID, // out
+	crypto.PublicKey, // owner
+	uint64, // value
+	uint64, // reward
+	uint64, // minOut
+	ids.ID, // feed
+	uint64, // triggerIn
+	uint64, // triggerOut
+	bool, // above
+	error,
+) {
+	k := PrefixTriggerOrderKey(trigger)
+	return innerGetTriggerOrder(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetTriggerOrderFromState(
+	ctx context.Context,
+	f ReadState,
+	trigger ids.ID,
+) (bool, ids.ID, ids.ID, crypto.PublicKey, uint64, uint64, uint64, ids.ID, uint64, uint64, bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixTriggerOrderKey(trigger)})
+	return innerGetTriggerOrder(values[0], errs[0])
+}
+
+func innerGetTriggerOrder(
+	v []byte,
+	err error,
+) (bool, ids.ID, ids.ID, crypto.PublicKey, uint64, uint64, uint64, ids.ID, uint64, uint64, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, ids.Empty, crypto.EmptyPublicKey, 0, 0, 0, ids.Empty, 0, 0, false, nil
+	}
+	if err != nil {
+		return false, ids.Empty, ids.Empty, crypto.EmptyPublicKey, 0, 0, 0, ids.Empty, 0, 0, false, err
+	}
+	var in ids.ID
+	copy(in[:], v[:consts.IDLen])
+	var out ids.ID
+	copy(out[:], v[consts.IDLen:consts.IDLen*2])
+	var owner crypto.PublicKey
+	copy(owner[:], v[consts.IDLen*2:consts.IDLen*2+crypto.PublicKeyLen])
+	value := binary.BigEndian.Uint64(v[consts.IDLen*2+crypto.PublicKeyLen:])
+	reward := binary.BigEndian.Uint64(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len:])
+	minOut := binary.BigEndian.Uint64(v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len*2:])
+	var feed ids.ID
+	copy(feed[:], v[consts.IDLen*2+crypto.PublicKeyLen+consts.Uint64Len*3:consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*3])
+	triggerIn := binary.BigEndian.Uint64(v[consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*3:])
+	triggerOut := binary.BigEndian.Uint64(v[consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*4:])
+	above := v[consts.IDLen*3+crypto.PublicKeyLen+consts.Uint64Len*5] == successByte
+	return true, in, out, owner, value, reward, minOut, feed, triggerIn, triggerOut, above, nil
+}
+
+func DeleteTriggerOrder(ctx context.Context, db chain.Database, trigger ids.ID) error {
+	k := PrefixTriggerOrderKey(trigger)
+	return db.Remove(ctx, k)
+}
+
+// [triggerIndexPrefix] + [trigger]
+func PrefixTriggerIndexKey(trigger ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = triggerIndexPrefix
+	copy(k[1:], trigger[:])
+	return
+}
+
+// StoreTriggerOrder records [trigger] in the metadata index so that open
+// trigger orders can be listed without iterating over state.
+func StoreTriggerOrder(
+	_ context.Context,
+	db database.KeyValueWriter,
+	trigger ids.ID,
+) error {
+	return db.Put(PrefixTriggerIndexKey(trigger), nil)
+}
+
+func RemoveTriggerOrder(
+	_ context.Context,
+	db database.KeyValueDeleter,
+	trigger ids.ID,
+) error {
+	return db.Delete(PrefixTriggerIndexKey(trigger))
+}
+
+func GetTriggerOrders(
+	_ context.Context,
+	db database.Iteratee,
+) ([]ids.ID, error) {
+	iter := db.NewIteratorWithPrefix([]byte{triggerIndexPrefix})
+	defer iter.Release()
+
+	triggers := []ids.ID{}
+	for iter.Next() {
+		id, err := ids.ToID(iter.Key()[1:])
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, id)
+	}
+	return triggers, iter.Error()
+}
+
+func HeightKey() (k []byte) {
+	return heightKey
+}
+
+func IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2)
+	k[0] = incomingWarpPrefix
+	copy(k[1:], sourceChainID[:])
+	copy(k[1+consts.IDLen:], msgID[:])
+	return k
+}
+
+func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = outgoingWarpPrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+// [messagePrefix] + [sourceChainID] + [txID]
+func PrefixMessageKey(sourceChainID ids.ID, txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2)
+	k[0] = messagePrefix
+	copy(k[1:], sourceChainID[:])
+	copy(k[1+consts.IDLen:], txID[:])
+	return
+}
+
+func SetMessage(
+	ctx context.Context,
+	db chain.Database,
+	sourceChainID ids.ID,
+	txID ids.ID,
+	sender crypto.PublicKey,
+	recipient crypto.PublicKey,
+	payload []byte,
+) error {
+	k := PrefixMessageKey(sourceChainID, txID)
+	payloadLen := len(payload)
+	v := make([]byte, crypto.PublicKeyLen*2+consts.Uint16Len+payloadLen)
+	copy(v, sender[:])
+	copy(v[crypto.PublicKeyLen:], recipient[:])
+	binary.BigEndian.PutUint16(v[crypto.PublicKeyLen*2:], uint16(payloadLen))
+	copy(v[crypto.PublicKeyLen*2+consts.Uint16Len:], payload)
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetMessageFromState(
+	ctx context.Context,
+	f ReadState,
+	sourceChainID ids.ID,
+	txID ids.ID,
+) (bool, crypto.PublicKey, crypto.PublicKey, []byte, error) {
+	values, errs := f(ctx, [][]byte{PrefixMessageKey(sourceChainID, txID)})
+	v, err := values[0], errs[0]
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, nil, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, nil, err
+	}
+	var sender crypto.PublicKey
+	copy(sender[:], v[:crypto.PublicKeyLen])
+	var recipient crypto.PublicKey
+	copy(recipient[:], v[crypto.PublicKeyLen:crypto.PublicKeyLen*2])
+	payloadLen := binary.BigEndian.Uint16(v[crypto.PublicKeyLen*2:])
+	payload := v[crypto.PublicKeyLen*2+consts.Uint16Len : crypto.PublicKeyLen*2+consts.Uint16Len+int(payloadLen)]
+	return true, sender, recipient, payload, nil
+}
+
+// FeePoolShards is the number of shards fees are accumulated in before they
+// are settled. Transactions only conflict over the fee pool if their payers
+// fall in the same shard.
+const FeePoolShards = 16
+
+// FeePoolShard returns the shard of the fee pool that [payer] accumulates
+// fees in.
+func FeePoolShard(payer crypto.PublicKey) uint8 {
+	return payer[0] % FeePoolShards
+}
+
+// [feePoolPrefix] + [asset] + [shard]
+func PrefixFeePoolKey(asset ids.ID, shard uint8) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+1)
+	k[0] = feePoolPrefix
+	copy(k[1:], asset[:])
+	k[1+consts.IDLen] = shard
+	return
+}
+
+// SetFeePool stores the unsettled amounts of [asset] accumulated in [shard]
+// that will be burned ([burn]) or sent to the treasury ([treasury]) and the fee
+// recipient ([recipient]).
+//
+// Fees are split when they are charged, so settling a shard only moves funds.
+func SetFeePool(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	shard uint8,
+	burn uint64,
+	treasury uint64,
+	recipient uint64,
+) error {
+	k := PrefixFeePoolKey(asset, shard)
+	if burn == 0 && treasury == 0 && recipient == 0 {
+		return db.Remove(ctx, k)
+	}
+	v := make([]byte, consts.Uint64Len*3)
+	binary.BigEndian.PutUint64(v, burn)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], treasury)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len*2:], recipient)
+	return db.Insert(ctx, k, v)
+}
+
+func GetFeePool(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	shard uint8,
+) (
+	uint64, // burn
+	uint64, // treasury
+	uint64, // recipient
+	error,
+) {
+	k := PrefixFeePoolKey(asset, shard)
+	return innerGetFeePool(db.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetFeePoolFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+) (uint64, uint64, uint64, error) {
+	keys := make([][]byte, FeePoolShards)
+	for i := range keys {
+		keys[i] = PrefixFeePoolKey(asset, uint8(i))
+	}
+	values, errs := f(ctx, keys)
+	var burn, treasury, recipient uint64
+	for i := range keys {
+		b, t, r, err := innerGetFeePool(values[i], errs[i])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		burn += b
+		treasury += t
+		recipient += r
+	}
+	return burn, treasury, recipient, nil
+}
+
+func innerGetFeePool(v []byte, err error) (uint64, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return binary.BigEndian.Uint64(v),
+		binary.BigEndian.Uint64(v[consts.Uint64Len:]),
+		binary.BigEndian.Uint64(v[consts.Uint64Len*2:]),
+		nil
 }
@@ -712,7 +712,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 			)
 			gomega.Ω(err).Should(gomega.BeNil())
 			gomega.Ω(aNewSenderBalance).Should(gomega.Equal(uint64(0)))
-			exists, metadata, supply, owner, warp, err := instancesB[0].tcli.Asset(
+			exists, metadata, supply, owner, warp, _, err := instancesB[0].tcli.Asset(
 				context.Background(),
 				newAsset,
 			)
@@ -806,7 +806,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 			otherBalance, err := instancesB[0].tcli.Balance(context.Background(), aother, newAsset)
 			gomega.Ω(err).Should(gomega.BeNil())
 			gomega.Ω(otherBalance).Should(gomega.Equal(uint64(2900)))
-			exists, metadata, supply, owner, warp, err := instancesB[0].tcli.Asset(
+			exists, metadata, supply, owner, warp, _, err := instancesB[0].tcli.Asset(
 				context.Background(),
 				newAsset,
 			)
@@ -975,7 +975,7 @@ var _ = ginkgo.Describe("[Test]", func() {
 			otherBalance, err := instancesB[0].tcli.Balance(context.Background(), aother, newAsset)
 			gomega.Ω(err).Should(gomega.BeNil())
 			gomega.Ω(otherBalance).Should(gomega.Equal(uint64(0)))
-			exists, _, _, _, _, err := instancesB[0].tcli.Asset(context.Background(), newAsset)
+			exists, _, _, _, _, _, err := instancesB[0].tcli.Asset(context.Background(), newAsset)
 			gomega.Ω(err).Should(gomega.BeNil())
 			gomega.Ω(exists).Should(gomega.BeFalse())
 		})
@@ -40,6 +40,7 @@ import (
 	"github.com/ava-labs/hypersdk/rpc"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"tokenvm/actions"
 	"tokenvm/auth"
@@ -47,6 +48,7 @@ import (
 	"tokenvm/controller"
 	"tokenvm/genesis"
 	trpc "tokenvm/rpc"
+	"tokenvm/storage"
 	"tokenvm/utils"
 )
 
@@ -111,6 +113,20 @@ var (
 	rsender2 crypto.PublicKey
 	sender2  string
 
+	treasury  string
+	rtreasury crypto.PublicKey
+
+	// settler pays for [settleFees]. Its fee pool shard is never settled by
+	// its own transactions, so it must not share a shard with [rsender] or
+	// [rsender2].
+	settler  *auth.ED25519Factory
+	rsettler crypto.PublicKey
+
+	// Warp messages from [sourceChainID] are verified against [sourceSigner],
+	// the only validator of its subnet.
+	sourceChainID ids.ID
+	sourceSigner  *bls.SecretKey
+
 	asset1   []byte
 	asset1ID ids.ID
 	asset2   []byte
@@ -163,6 +179,23 @@ var _ = ginkgo.BeforeSuite(func() {
 		zap.String("pk", hex.EncodeToString(priv2[:])),
 	)
 
+	treasuryPriv, err := crypto.GeneratePrivateKey()
+	gomega.Ω(err).Should(gomega.BeNil())
+	rtreasury = treasuryPriv.PublicKey()
+	treasury = utils.Address(rtreasury)
+
+	for {
+		settlerPriv, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		shard := storage.FeePoolShard(settlerPriv.PublicKey())
+		if shard == storage.FeePoolShard(rsender) || shard == storage.FeePoolShard(rsender2) {
+			continue
+		}
+		settler = auth.NewED25519Factory(settlerPriv)
+		rsettler = settlerPriv.PublicKey()
+		break
+	}
+
 	asset1 = []byte("1")
 	asset2 = []byte("2")
 	asset3 = []byte("3")
@@ -175,11 +208,52 @@ var _ = ginkgo.BeforeSuite(func() {
 		gen.MinUnitPrice = uint64(minPrice)
 	}
 	gen.WindowTargetBlocks = 1_000_000 // deactivate block fee
+	gen.Treasury = treasury
+	gen.TreasuryFeeShare = 5_000
+	gen.GovernanceVotingPeriod = 2
+	gen.GovernanceTimelock = 0
+	gen.GovernanceActivationDelay = 3
+	gen.GovernanceQuorum = 1_000
+	gen.GovernanceDeposit = 10_000
+	gen.GovernanceExpiry = 10
+	gen.PairFees = []*genesis.PairFee{
+		{
+			Pair:        actions.PairID(ids.Empty, genesis.AssetID("GEN")),
+			MakerFee:    100,
+			TakerFee:    200,
+			MakerRebate: 50,
+		},
+	}
+	gen.CustomAssets = []*genesis.CustomAsset{
+		{
+			Metadata: "GEN",
+			Owner:    sender,
+		},
+	}
 	gen.CustomAllocation = []*genesis.CustomAllocation{
 		{
 			Address: sender,
 			Balance: 10_000_000,
 		},
+		{
+			Address: sender,
+			Balance: 500,
+			Asset:   "GEN",
+		},
+		{
+			Address: utils.Address(rsettler),
+			Balance: 1_000_000,
+		},
+		{
+			Address: sender2,
+			Balance: 1_000,
+			Asset:   "GEN",
+			Lockup: &genesis.Lockup{
+				Start:    0,
+				Cliff:    0,
+				Duration: 1,
+			},
+		},
 	}
 	genesisBytes, err = json.Marshal(gen)
 	gomega.Ω(err).Should(gomega.BeNil())
@@ -188,6 +262,36 @@ var _ = ginkgo.BeforeSuite(func() {
 	subnetID := ids.GenerateTestID()
 	chainID := ids.GenerateTestID()
 
+	sourceChainID = ids.GenerateTestID()
+	sourceSubnetID := ids.GenerateTestID()
+	sourceSigner, err = bls.NewSecretKey()
+	gomega.Ω(err).Should(gomega.BeNil())
+	vdrState := &validators.TestState{
+		GetSubnetIDF: func(_ context.Context, chainID ids.ID) (ids.ID, error) {
+			if chainID != sourceChainID {
+				return ids.Empty, fmt.Errorf("unknown chain %s", chainID)
+			}
+			return sourceSubnetID, nil
+		},
+		GetValidatorSetF: func(
+			_ context.Context,
+			_ uint64,
+			subnetID ids.ID,
+		) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if subnetID != sourceSubnetID {
+				return nil, fmt.Errorf("unknown subnet %s", subnetID)
+			}
+			nodeID := ids.GenerateTestNodeID()
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(sourceSigner),
+					Weight:    1,
+				},
+			}, nil
+		},
+	}
+
 	app := &appSender{}
 	for i := range instances {
 		nodeID := ids.GenerateTestNodeID()
@@ -207,7 +311,7 @@ var _ = ginkgo.BeforeSuite(func() {
 			Metrics:        metrics.NewOptionalGatherer(),
 			PublicKey:      bls.PublicFromSecretKey(sk),
 			WarpSigner:     warp.NewSigner(sk, chainID),
-			ValidatorState: &validators.TestState{},
+			ValidatorState: vdrState,
 		}
 
 		toEngine := make(chan common.Message, 1)
@@ -261,18 +365,43 @@ var _ = ginkgo.BeforeSuite(func() {
 
 		csupply := uint64(0)
 		for _, alloc := range g.CustomAllocation {
+			if len(alloc.Asset) > 0 || alloc.Lockup != nil {
+				continue
+			}
 			balance, err := cli.Balance(context.Background(), alloc.Address, ids.Empty)
 			gomega.Ω(err).Should(gomega.BeNil())
 			gomega.Ω(balance).Should(gomega.Equal(alloc.Balance))
 			csupply += alloc.Balance
 		}
-		exists, metadata, supply, owner, warp, err := cli.Asset(context.Background(), ids.Empty)
+		exists, metadata, supply, owner, warp, _, err := cli.Asset(context.Background(), ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
 		gomega.Ω(string(metadata)).Should(gomega.Equal(tconsts.Symbol))
 		gomega.Ω(supply).Should(gomega.Equal(csupply))
 		gomega.Ω(owner).Should(gomega.Equal(utils.Address(crypto.EmptyPublicKey)))
 		gomega.Ω(warp).Should(gomega.BeFalse())
+
+		// Verify genesis assets and lockups
+		genesisAsset := genesis.AssetID("GEN")
+		exists, metadata, supply, owner, warp, _, err = cli.Asset(context.Background(), genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(string(metadata)).Should(gomega.Equal("GEN"))
+		gomega.Ω(supply).Should(gomega.Equal(uint64(1_500)))
+		gomega.Ω(owner).Should(gomega.Equal(sender))
+		gomega.Ω(warp).Should(gomega.BeFalse())
+		balance, err := cli.Balance(context.Background(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(500)))
+		balance, err = cli.Balance(context.Background(), sender2, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeZero())
+		vesting, err := cli.Vesting(context.Background(), genesis.LockupID(2))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(vesting).ShouldNot(gomega.BeNil())
+		gomega.Ω(vesting.Asset).Should(gomega.Equal(genesisAsset))
+		gomega.Ω(vesting.Beneficiary).Should(gomega.Equal(sender2))
+		gomega.Ω(vesting.Amount).Should(gomega.Equal(uint64(1_000)))
 	}
 
 	app.instances = instances
@@ -724,7 +853,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(string(result.Output)).
 			Should(gomega.ContainSubstring("asset missing"))
 
-		exists, _, _, _, _, err := instances[0].tcli.Asset(context.TODO(), assetID)
+		exists, _, _, _, _, _, err := instances[0].tcli.Asset(context.TODO(), assetID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeFalse())
 	})
@@ -752,7 +881,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		balance, err := instances[0].tcli.Balance(context.TODO(), sender, assetID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			assetID,
 		)
@@ -818,7 +947,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -858,7 +987,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -896,7 +1025,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(string(result.Output)).
 			Should(gomega.ContainSubstring("wrong owner"))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -935,7 +1064,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -970,7 +1099,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(string(result.Output)).
 			Should(gomega.ContainSubstring("invalid balance"))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -1046,7 +1175,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -1086,7 +1215,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].tcli.Asset(
+		exists, metadata, supply, owner, warp, _, err := instances[0].tcli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
@@ -1123,7 +1252,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(string(result.Output)).
 			Should(gomega.ContainSubstring("asset missing"))
 
-		exists, _, _, _, _, err := instances[0].tcli.Asset(context.TODO(), assetID)
+		exists, _, _, _, _, _, err := instances[0].tcli.Asset(context.TODO(), assetID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeFalse())
 	})
@@ -1868,6 +1997,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		loan, err = instances[0].tcli.Loan(context.TODO(), ids.Empty, dest)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(loan).Should(gomega.Equal(uint64(110)))
+
+		loans, complete, err := instances[0].tcli.BridgeState(context.TODO(), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(complete).Should(gomega.BeTrue())
+		gomega.Ω(loans).Should(gomega.HaveLen(1))
+		gomega.Ω(loans[0].Destination).Should(gomega.Equal(dest))
+		gomega.Ω(loans[0].Amount).Should(gomega.Equal(uint64(110)))
 	})
 
 	ginkgo.It("export native asset (invalid return)", func() {
@@ -1896,67 +2032,3209 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(result.Success).Should(gomega.BeFalse())
 		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("not warp asset"))
 	})
-})
 
-func expectBlk(i instance) func() []*chain.Result {
-	ctx := context.TODO()
+	ginkgo.It("send message", func() {
+		dest := ids.GenerateTestID()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SendMessage{
+				Recipient:   rsender2,
+				Payload:     []byte("hello"),
+				Destination: dest,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		msg := &actions.WarpMessage{
+			Sender:    rsender,
+			Recipient: rsender2,
+			Payload:   []byte("hello"),
+			TxID:      tx.ID(),
+		}
+		msgb, err := msg.Marshal()
+		gomega.Ω(err).Should(gomega.BeNil())
+		wm, err := warp.NewUnsignedMessage(instances[0].chainID, dest, msgb)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(result.WarpMessage).Should(gomega.Equal(wm))
+	})
 
-	// manually signal ready
-	i.vm.Builder().TriggerBuild()
-	// manually ack ready sig as in engine
-	<-i.toEngine
+	ginkgo.It("receive message with wrong destination", func() {
+		msg := &actions.WarpMessage{
+			Sender:    rsender,
+			Recipient: rsender2,
+			Payload:   []byte("hello"),
+			TxID:      ids.GenerateTestID(),
+		}
+		msgb, err := msg.Marshal()
+		gomega.Ω(err).Should(gomega.BeNil())
+		uwm, err := warp.NewUnsignedMessage(ids.Empty, ids.Empty, msgb)
+		gomega.Ω(err).Should(gomega.BeNil())
+		wm, err := warp.NewMessage(uwm, &warp.BitSetSignature{})
+		gomega.Ω(err).Should(gomega.BeNil())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			wm,
+			&actions.ReceiveMessage{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
 
-	blk, err := i.vm.BuildBlock(ctx)
-	gomega.Ω(err).To(gomega.BeNil())
-	gomega.Ω(blk).To(gomega.Not(gomega.BeNil()))
+		// Build block with context
+		accept := expectBlkWithContext(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("warp verification failed"))
 
-	gomega.Ω(blk.Verify(ctx)).To(gomega.BeNil())
-	gomega.Ω(blk.Status()).To(gomega.Equal(choices.Processing))
+		exists, _, _, _, err := instances[0].tcli.Message(context.TODO(), ids.Empty, msg.TxID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+	})
 
-	err = i.vm.SetPreference(ctx, blk.ID())
-	gomega.Ω(err).To(gomega.BeNil())
+	ginkgo.It("receive message", func() {
+		// Send message
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SendMessage{
+				Recipient:   rsender2,
+				Payload:     []byte("ping"),
+				Destination: instances[0].chainID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-	return func() []*chain.Result {
-		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
-		gomega.Ω(blk.Status()).To(gomega.Equal(choices.Accepted))
+		// Deliver message as if it were sent from [sourceChainID]
+		uwm, err := warp.NewUnsignedMessage(
+			sourceChainID,
+			instances[0].chainID,
+			results[0].WarpMessage.Payload,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		wm := signWarpMessage(uwm)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			wm,
+			&actions.ReceiveMessage{},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlkWithContext(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-		lastAccepted, err := i.vm.LastAccepted(ctx)
-		gomega.Ω(err).To(gomega.BeNil())
-		gomega.Ω(lastAccepted).To(gomega.Equal(blk.ID()))
-		return blk.(*chain.StatelessBlock).Results()
-	}
-}
+		exists, from, to, payload, err := instances[0].tcli.Message(context.TODO(), sourceChainID, tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(from).Should(gomega.Equal(sender))
+		gomega.Ω(to).Should(gomega.Equal(sender2))
+		gomega.Ω(payload).Should(gomega.Equal([]byte("ping")))
 
-// TODO: unify with expectBlk
-func expectBlkWithContext(i instance) func() []*chain.Result {
-	ctx := context.TODO()
+		// Message can't be received again
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			wm,
+			&actions.ReceiveMessage{},
+			factory2,
+			uniqueTx{},
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlkWithContext(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).Should(gomega.ContainSubstring("warp verification failed"))
+	})
 
-	// manually signal ready
-	i.vm.Builder().TriggerBuild()
-	// manually ack ready sig as in engine
-	<-i.toEngine
+	ginkgo.It("import legacy warp transfer", func() {
+		// Transfers exported before payloads had a type byte start with [To]
+		asset := ids.GenerateTestID()
+		p := codec.NewWriter(actions.WarpTransferSize)
+		p.PackPublicKey(rsender2)
+		p.PackID(asset)
+		p.PackUint64(100)
+		p.PackBool(false)
+		op := codec.NewOptionalWriter()
+		op.PackUint64(10)
+		p.PackOptional(op)
+		p.PackID(ids.GenerateTestID())
+		gomega.Ω(p.Err()).Should(gomega.BeNil())
+		wt, err := actions.UnmarshalWarpTransfer(p.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(wt.To).Should(gomega.Equal(rsender2))
+		gomega.Ω(wt.Value).Should(gomega.Equal(uint64(100)))
+		gomega.Ω(wt.Reward).Should(gomega.Equal(uint64(10)))
+
+		uwm, err := warp.NewUnsignedMessage(sourceChainID, instances[0].chainID, p.Bytes())
+		gomega.Ω(err).Should(gomega.BeNil())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			signWarpMessage(uwm),
+			&actions.ImportAsset{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlkWithContext(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-	bctx := &block.Context{PChainHeight: 1}
-	blk, err := i.vm.BuildBlockWithContext(ctx, bctx)
-	gomega.Ω(err).To(gomega.BeNil())
-	gomega.Ω(blk).To(gomega.Not(gomega.BeNil()))
-	cblk := blk.(block.WithVerifyContext)
+		imported := actions.ImportedAssetID(asset, sourceChainID)
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, imported)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(100)))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, imported)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+	})
 
-	gomega.Ω(cblk.VerifyWithContext(ctx, bctx)).To(gomega.BeNil())
-	gomega.Ω(blk.Status()).To(gomega.Equal(choices.Processing))
+	ginkgo.It("batch export native asset", func() {
+		dest := ids.GenerateTestID()
+		loan, err := instances[0].tcli.Loan(context.TODO(), ids.Empty, dest)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(0)))
 
-	err = i.vm.SetPreference(ctx, blk.ID())
-	gomega.Ω(err).To(gomega.BeNil())
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.BatchExportAsset{
+				Transfers: []*actions.BatchTransfer{
+					{To: rsender2, Asset: ids.Empty, Value: 100},
+					{To: rsender, Asset: ids.Empty, Value: 50},
+				},
+				Destination: dest,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		wbt := &actions.WarpBatchTransfer{
+			Transfers: []*actions.WarpBatchTransferEntry{
+				{To: rsender2, Asset: ids.Empty, Value: 100},
+				{To: rsender, Asset: ids.Empty, Value: 50},
+			},
+			TxID: tx.ID(),
+		}
+		wbtb, err := wbt.Marshal()
+		gomega.Ω(err).Should(gomega.BeNil())
+		wm, err := warp.NewUnsignedMessage(instances[0].chainID, dest, wbtb)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(result.WarpMessage).Should(gomega.Equal(wm))
 
-	return func() []*chain.Result {
-		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
-		gomega.Ω(blk.Status()).To(gomega.Equal(choices.Accepted))
+		loan, err = instances[0].tcli.Loan(context.TODO(), ids.Empty, dest)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(150)))
+	})
 
-		lastAccepted, err := i.vm.LastAccepted(ctx)
-		gomega.Ω(err).To(gomega.BeNil())
-		gomega.Ω(lastAccepted).To(gomega.Equal(blk.ID()))
-		return blk.(*chain.StatelessBlock).Results()
-	}
+	ginkgo.It("batch import asset", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		importBatch := func(transfers []*actions.WarpBatchTransferEntry) *chain.Result {
+			wbt := &actions.WarpBatchTransfer{
+				Transfers: transfers,
+				TxID:      ids.GenerateTestID(),
+			}
+			wbtb, err := wbt.Marshal()
+			gomega.Ω(err).Should(gomega.BeNil())
+			uwm, err := warp.NewUnsignedMessage(sourceChainID, instances[0].chainID, wbtb)
+			gomega.Ω(err).Should(gomega.BeNil())
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				signWarpMessage(uwm),
+				&actions.BatchImportAsset{},
+				factory,
+				uniqueTx{},
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlkWithContext(instances[0])
+			results := accept()
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			return results[0]
+		}
+		checkBalance := func(addr string, asset ids.ID, expected uint64) {
+			balance, err := instances[0].tcli.Balance(context.TODO(), addr, asset)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(balance).Should(gomega.Equal(expected))
+		}
+
+		// Export native asset to source so that it can be returned
+		result, _, _ := submitAction(&actions.ExportAsset{
+			To:          rsender2,
+			Asset:       ids.Empty,
+			Value:       100,
+			Destination: sourceChainID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		// Mint assets of source
+		sourceAsset := ids.GenerateTestID()
+		imported := actions.ImportedAssetID(sourceAsset, sourceChainID)
+		result = importBatch([]*actions.WarpBatchTransferEntry{
+			{To: rsender2, Asset: sourceAsset, Value: 50},
+			{To: rsender, Asset: sourceAsset, Value: 25},
+		})
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		checkBalance(sender2, imported, 50)
+		checkBalance(sender, imported, 25)
+		exists, _, supply, _, isWarp, _, err := instances[0].tcli.Asset(context.TODO(), imported)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(supply).Should(gomega.Equal(uint64(75)))
+		gomega.Ω(isWarp).Should(gomega.BeTrue())
+
+		// Return exported native asset
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result = importBatch([]*actions.WarpBatchTransferEntry{
+			{To: rsender2, Asset: ids.Empty, Value: 60, Return: true},
+		})
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		checkBalance(sender2, ids.Empty, balance+60)
+		loan, err := instances[0].tcli.Loan(context.TODO(), ids.Empty, sourceChainID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(40)))
+
+		// Nothing is credited if any transfer fails
+		result = importBatch([]*actions.WarpBatchTransferEntry{
+			{To: rsender2, Asset: sourceAsset, Value: 10},
+			{To: rsender2, Asset: ids.Empty, Value: 41, Return: true},
+		})
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("could not subtract loan"))
+		checkBalance(sender2, imported, 50)
+		checkBalance(sender2, ids.Empty, balance+60)
+		loan, err = instances[0].tcli.Loan(context.TODO(), ids.Empty, sourceChainID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(40)))
+	})
+
+	ginkgo.It("rejects oversized batch export", func() {
+		transfers := make([]*actions.BatchTransfer, actions.MaxBatchSize+1)
+		for i := range transfers {
+			transfers[i] = &actions.BatchTransfer{To: rsender2, Asset: ids.Empty, Value: 1}
+		}
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: time.Now().Unix(),
+				UnitPrice: 1000,
+			},
+			nil,
+			&actions.BatchExportAsset{
+				Transfers:   transfers,
+				Destination: ids.GenerateTestID(),
+			},
+		)
+		// Must do manual construction to avoid `tx.Sign` error (would fail with
+		// oversized batch)
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).To(gomega.BeNil())
+		auth, err := factory.Sign(msg, tx.Action)
+		gomega.Ω(err).To(gomega.BeNil())
+		tx.Auth = auth
+		p := codec.NewWriter(consts.MaxInt)
+		gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err.Error()).Should(gomega.ContainSubstring("batch is too large"))
+	})
+
+	ginkgo.It("export to destination not in allowlist", func() {
+		allowed := ids.GenerateTestID()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetWarpDestinations{
+				Asset:        asset2ID,
+				Destinations: []ids.ID{allowed},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		destinations, err := instances[0].tcli.WarpDestinations(context.TODO(), asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(destinations).Should(gomega.Equal([]ids.ID{allowed}))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ExportAsset{
+				To:          rsender2,
+				Asset:       asset2ID,
+				Value:       1,
+				Destination: ids.GenerateTestID(),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("destination not allowed for asset"))
+	})
+
+	ginkgo.It("set warp destinations from wrong owner", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetWarpDestinations{
+				Asset: asset3ID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("wrong owner"))
+	})
+
+	ginkgo.It("pause asset", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.PauseAsset{
+				Asset:  asset2ID,
+				Paused: true,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, _, _, _, _, paused, err := instances[0].tcli.Asset(context.TODO(), asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(paused).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: asset2ID,
+				Value: 1,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("asset is paused"))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.PauseAsset{
+				Asset:  asset2ID,
+				Paused: false,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, _, _, _, _, paused, err = instances[0].tcli.Asset(context.TODO(), asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(paused).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("freeze account and claw back", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: asset2ID,
+				Value: 2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.FreezeAccount{
+				Asset:   asset2ID,
+				Account: rsender2,
+				Frozen:  true,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		frozen, err := instances[0].tcli.Frozen(context.TODO(), asset2ID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(frozen).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Asset: asset2ID,
+				Value: 1,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("account is frozen"))
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ClawbackAsset{
+				Asset: asset2ID,
+				From:  rsender2,
+				Value: 2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		newBalance2, err := instances[0].tcli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance2).Should(gomega.Equal(balance2 - 2))
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance + 2))
+	})
+
+	ginkgo.It("transfer in allowlist mode", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetAllowlistMode{
+				Asset:   asset2ID,
+				Enabled: true,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		enabled, allowed, err := instances[0].tcli.AllowlistStatus(context.TODO(), asset2ID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(enabled).Should(gomega.BeTrue())
+		gomega.Ω(allowed).Should(gomega.BeFalse())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: asset2ID,
+				Value: 1,
+			},
+			factory,
+			uniqueTx{},
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).Should(gomega.ContainSubstring("account is not allowlisted"))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetAllowlist{
+				Asset:   asset2ID,
+				Account: rsender2,
+				Allowed: true,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		_, allowed, err = instances[0].tcli.AllowlistStatus(context.TODO(), asset2ID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(allowed).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: asset2ID,
+				Value: 2,
+			},
+			factory,
+			uniqueTx{},
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetAllowlistMode{
+				Asset:   asset2ID,
+				Enabled: false,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("create vesting and claim", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Fund both schedules
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: asset2ID,
+				Value: 3,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Schedule that has not started
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateVesting{
+				Asset:       asset2ID,
+				Beneficiary: rsender2,
+				Amount:      2,
+				Start:       time.Now().Add(24 * time.Hour).Unix(),
+				Duration:    100,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		pendingID := tx.ID()
+
+		vesting, err := instances[0].tcli.Vesting(context.TODO(), pendingID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(vesting.Asset).Should(gomega.Equal(asset2ID))
+		gomega.Ω(vesting.Beneficiary).Should(gomega.Equal(sender2))
+		gomega.Ω(vesting.Amount).Should(gomega.Equal(uint64(2)))
+		gomega.Ω(vesting.Claimed).Should(gomega.Equal(uint64(0)))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ClaimVested{
+				Vesting: pendingID,
+				Asset:   asset2ID,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).Should(gomega.ContainSubstring("nothing to claim"))
+
+		// Schedule that has fully vested
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateVesting{
+				Asset:       asset2ID,
+				Beneficiary: rsender2,
+				Amount:      1,
+				Start:       0,
+				Duration:    1,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		vestedID := tx.ID()
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - 3))
+
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ClaimVested{
+				Vesting: vestedID,
+				Asset:   asset2ID,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		newBalance2, err := instances[0].tcli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance2).Should(gomega.Equal(balance2 + 1))
+		vesting, err = instances[0].tcli.Vesting(context.TODO(), vestedID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(vesting).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("claim genesis lockup", func() {
+		genesisAsset := genesis.AssetID("GEN")
+		lockupID := genesis.LockupID(2)
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ClaimVested{
+				Vesting: lockupID,
+				Asset:   genesisAsset,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender2, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1_000)))
+		vesting, err := instances[0].tcli.Vesting(context.TODO(), lockupID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(vesting).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("applies upgrade schedule", func() {
+		g, err := genesis.New(
+			[]byte(`{"disabledActions":["SendMessage"]}`),
+			[]byte(`{"upgrades":[
+				{"timestamp":100,"maxBlockUnits":1000,"warpBaseFee":10,"enableActions":["SendMessage"]},
+				{
+					"timestamp":200,"warpFeePerSigner":5,"disableActions":["Transfer"],
+					"governanceVotingPeriod":60,"governanceQuorum":10,"nameFee":5
+				}
+			]}`),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		r := g.Rules(99)
+		gomega.Ω(r.GetMaxBlockUnits()).Should(gomega.Equal(gen.MaxBlockUnits))
+		gomega.Ω(r.GetWarpBaseFee()).Should(gomega.Equal(gen.WarpBaseFee))
+		start, end := (&actions.SendMessage{}).ValidRange(r)
+		gomega.Ω(start).Should(gomega.Equal(int64(100)))
+		gomega.Ω(end).Should(gomega.Equal(int64(-1)))
+		start, end = (&actions.Transfer{}).ValidRange(r)
+		gomega.Ω(start).Should(gomega.Equal(int64(-1)))
+		gomega.Ω(end).Should(gomega.Equal(int64(199)))
+
+		r = g.Rules(150)
+		gomega.Ω(r.GetMaxBlockUnits()).Should(gomega.Equal(uint64(1000)))
+		gomega.Ω(r.GetWarpBaseFee()).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(r.GetWarpFeePerSigner()).Should(gomega.Equal(gen.WarpFeePerSigner))
+		start, end = (&actions.SendMessage{}).ValidRange(r)
+		gomega.Ω(start).Should(gomega.Equal(int64(100)))
+		gomega.Ω(end).Should(gomega.Equal(int64(-1)))
+
+		r = g.Rules(200)
+		gomega.Ω(r.GetMaxBlockUnits()).Should(gomega.Equal(uint64(1000)))
+		gomega.Ω(r.GetWarpFeePerSigner()).Should(gomega.Equal(uint64(5)))
+		start, _ = (&actions.Transfer{}).ValidRange(r)
+		gomega.Ω(start > 200).Should(gomega.BeTrue())
+
+		// Governance and the name service are configured by upgrades too
+		_, ok := g.Rules(199).FetchCustom(actions.GovernanceKey)
+		gomega.Ω(ok).Should(gomega.BeFalse())
+		v, ok := r.FetchCustom(actions.GovernanceKey)
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		config := v.(*actions.GovernanceConfig)
+		gomega.Ω(config.VotingPeriod).Should(gomega.Equal(int64(60)))
+		gomega.Ω(config.Quorum).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(config.Timelock).Should(gomega.Equal(gen.GovernanceTimelock))
+		v, ok = r.FetchCustom(actions.NameServiceKey)
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		gomega.Ω(v.(*actions.NameServiceConfig).Fee).Should(gomega.Equal(uint64(5)))
+		gomega.Ω(g.At(200).NameFee).Should(gomega.Equal(uint64(5)))
+
+		// Invalid schedules are rejected
+		_, err = genesis.New(nil, []byte(`{"upgrades":[{"timestamp":100},{"timestamp":100}]}`))
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring("invalid upgrade timestamp")))
+		_, err = genesis.New(nil, []byte(`{"upgrades":[{"timestamp":100,"enableActions":["Unknown"]}]}`))
+		gomega.Ω(err).Should(gomega.MatchError(gomega.ContainSubstring("unknown action")))
+		_, err = genesis.New(nil, []byte(`{"upgrades":[{"timestamp":100,"windowTargetUnits":0}]}`))
+		gomega.Ω(err).Should(gomega.MatchError(genesis.ErrInvalidTarget))
+		_, err = genesis.New(nil, []byte(`{"upgrades":[{"timestamp":100,"treasuryFeeShare":5000}]}`))
+		gomega.Ω(err).Should(gomega.MatchError(genesis.ErrInvalidTreasury))
+		_, err = genesis.New(nil, []byte(`{"upgrades":[{"timestamp":100,"governanceTimelock":-1}]}`))
+		gomega.Ω(err).Should(gomega.MatchError(genesis.ErrInvalidGovernance))
+		_, err = genesis.New(nil, []byte(`{"upgrades":[{"timestamp":100,"namePeriod":-1}]}`))
+		gomega.Ω(err).Should(gomega.MatchError(genesis.ErrInvalidNameService))
+	})
+
+	ginkgo.It("changes params with governance", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Propose{
+				Param: actions.ParamMakerRebate,
+				Value: 50,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		proposalID := tx.ID()
+
+		// The deposit is locked until the proposal is executed or expires
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - fee - gen.GovernanceDeposit))
+
+		balance = newBalance
+		submit, _, fee, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Vote{
+				Proposal: proposalID,
+				Support:  true,
+				Weight:   1_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		newBalance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - fee - 1_000))
+
+		// Cannot execute while voting is open
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ExecuteProposal{
+				Proposal: proposalID,
+				Param:    actions.ParamMakerRebate,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).Should(gomega.ContainSubstring("voting is still open"))
+
+		// Wait for voting to close
+		proposals, err := instances[0].tcli.Proposals(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(proposals).Should(gomega.HaveLen(1))
+		waitForBlockTime(proposals[0].Created + gen.GovernanceVotingPeriod)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Vote{
+				Proposal: proposalID,
+				Support:  false,
+				Weight:   1,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).Should(gomega.ContainSubstring("voting is closed"))
+		result, _, _ := submitAction(&actions.ReclaimDeposit{Proposal: proposalID}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("deposit is locked"))
+
+		param, err := instances[0].tcli.Param(context.TODO(), "makerRebate", ids.Empty, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(param.ActiveSet).Should(gomega.BeFalse())
+		gomega.Ω(param.Activation).Should(gomega.BeZero())
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ExecuteProposal{
+				Proposal: proposalID,
+				Param:    actions.ParamMakerRebate,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		// The new value is read from state once it activates
+		param, err = instances[0].tcli.Param(context.TODO(), "makerRebate", ids.Empty, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(param.ActiveSet).Should(gomega.BeFalse())
+		gomega.Ω(param.Pending).Should(gomega.Equal(uint64(50)))
+		gomega.Ω(param.Activation).Should(gomega.Equal(
+			instances[0].vm.LastAcceptedBlock().Tmstmp + gen.GovernanceActivationDelay,
+		))
+
+		proposals, err = instances[0].tcli.Proposals(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(proposals).Should(gomega.HaveLen(1))
+		gomega.Ω(proposals[0].ID).Should(gomega.Equal(proposalID))
+		gomega.Ω(proposals[0].Param).Should(gomega.Equal("makerRebate"))
+		gomega.Ω(proposals[0].Yes).Should(gomega.Equal(uint64(1_000)))
+		gomega.Ω(proposals[0].No).Should(gomega.BeZero())
+		gomega.Ω(proposals[0].Deposit).Should(gomega.Equal(gen.GovernanceDeposit))
+		gomega.Ω(proposals[0].Executed).Should(gomega.BeTrue())
+
+		// Recover locked vote
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, fee, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.WithdrawVote{
+				Proposal: proposalID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		newBalance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - fee + 1_000))
+
+		// Only the proposer can reclaim the deposit, and only once
+		result, _, _ = submitAction(&actions.ReclaimDeposit{Proposal: proposalID}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("not the proposer"))
+		result, _, fee = submitAction(&actions.ReclaimDeposit{Proposal: proposalID}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance = newBalance
+		newBalance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - fee + gen.GovernanceDeposit))
+		result, _, _ = submitAction(&actions.ReclaimDeposit{Proposal: proposalID}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("deposit already reclaimed"))
+	})
+
+	ginkgo.It("expires proposals that are not executed", func() {
+		result, proposalID, _ := submitAction(&actions.Propose{
+			Param: actions.ParamTakerFee,
+			Value: 100,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.Vote{
+			Proposal: proposalID,
+			Support:  true,
+			Weight:   1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		var created int64
+		proposals, err := instances[0].tcli.Proposals(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		for _, proposal := range proposals {
+			if proposal.ID == proposalID {
+				created = proposal.Created
+			}
+		}
+		gomega.Ω(created).Should(gomega.BeNumerically(">", 0))
+		waitForBlockTime(created + gen.GovernanceVotingPeriod + gen.GovernanceTimelock + gen.GovernanceExpiry)
+		result, _, _ = submitAction(&actions.ExecuteProposal{
+			Proposal: proposalID,
+			Param:    actions.ParamTakerFee,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("proposal expired"))
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result, _, fee := submitAction(&actions.ReclaimDeposit{Proposal: proposalID}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - fee + gen.GovernanceDeposit))
+
+		result, _, _ = submitAction(&actions.WithdrawVote{Proposal: proposalID}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("credits fees to treasury", func() {
+		t, err := instances[0].tcli.Treasury(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(t).ShouldNot(gomega.BeNil())
+		gomega.Ω(t.Address).Should(gomega.Equal(treasury))
+		gomega.Ω(t.FeeShare).Should(gomega.Equal(uint64(5_000)))
+
+		// Settle the fees of previous tests so we only measure what is paid
+		// here
+		settleFees(ids.Empty)
+		t, err = instances[0].tcli.Treasury(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		_, _, supply, _, _, _, err := instances[0].tcli.Asset(context.TODO(), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 3,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		paid := balance - newBalance - 3
+
+		// Fees are split when they are charged: the burned part is removed
+		// from the supply right away and the rest waits to be settled
+		_, _, newSupply, _, _, _, err := instances[0].tcli.Asset(context.TODO(), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		burned := supply - newSupply
+		newT, err := instances[0].tcli.Treasury(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newT.Balance).Should(gomega.Equal(t.Balance))
+		unsettled := newT.Unsettled - t.Unsettled
+		gomega.Ω(burned).Should(gomega.BeNumerically(">", 0))
+		gomega.Ω(unsettled).Should(gomega.BeNumerically(">", 0))
+		gomega.Ω(burned + unsettled).Should(gomega.Equal(paid))
+
+		// Settling only moves the treasury's share
+		settleFees(ids.Empty)
+		newT, err = instances[0].tcli.Treasury(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newT.Balance - t.Balance).Should(gomega.Equal(unsettled))
+	})
+
+	ginkgo.It("charges pair trading fees", func() {
+		genesisAsset := genesis.AssetID("GEN")
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateOrder{
+				In:      ids.Empty,
+				InTick:  1_000,
+				Out:     genesisAsset,
+				OutTick: 400,
+				Supply:  400,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		settleFees(genesisAsset)
+
+		ownerIn, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		ownerOut, err := instances[0].tcli.Balance(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		fillerOut, err := instances[0].tcli.Balance(context.TODO(), sender2, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		recipientOut, err := instances[0].tcli.Balance(context.TODO(), treasury, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.FillOrder{
+				Order: tx.ID(),
+				Owner: rsender,
+				In:    ids.Empty,
+				Out:   genesisAsset,
+				Value: 1_000,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		or, err := actions.UnmarshalOrderResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(or.In).Should(gomega.Equal(uint64(1_000)))
+		gomega.Ω(or.Out).Should(gomega.Equal(uint64(400)))
+		gomega.Ω(or.Remaining).Should(gomega.Equal(uint64(0)))
+		gomega.Ω(or.MakerFee).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(or.TakerFee).Should(gomega.Equal(uint64(8)))
+		gomega.Ω(or.MakerRebate).Should(gomega.Equal(uint64(2)))
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(ownerIn + 990))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(ownerOut + 2))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender2, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(fillerOut + 392))
+
+		// Trading fees are sent to the fee recipient when they are settled
+		balance, err = instances[0].tcli.Balance(context.TODO(), treasury, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(recipientOut))
+		settleFees(genesisAsset)
+		balance, err = instances[0].tcli.Balance(context.TODO(), treasury, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(recipientOut + 6))
+	})
+
+	ginkgo.It("changes pair fees with governance", func() {
+		genesisAsset := genesis.AssetID("GEN")
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Propose{
+				Param: actions.ParamPairTakerFee,
+				Value: 300,
+				In:    ids.Empty,
+				Out:   genesisAsset,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		proposalID := tx.ID()
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Vote{
+				Proposal: proposalID,
+				Support:  true,
+				Weight:   1_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		var created int64
+		proposals, err := instances[0].tcli.Proposals(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		for _, proposal := range proposals {
+			if proposal.ID == proposalID {
+				gomega.Ω(proposal.Param).Should(gomega.Equal("pairTakerFee"))
+				gomega.Ω(proposal.Pair).Should(gomega.Equal(actions.PairID(ids.Empty, genesisAsset)))
+				created = proposal.Created
+			}
+		}
+		gomega.Ω(created).Should(gomega.BeNumerically(">", 0))
+		waitForBlockTime(created + gen.GovernanceVotingPeriod)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.ExecuteProposal{
+				Proposal: proposalID,
+				Param:    actions.ParamPairTakerFee,
+				In:       ids.Empty,
+				Out:      genesisAsset,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		param, err := instances[0].tcli.Param(context.TODO(), "pairTakerFee", ids.Empty, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(param.ActiveSet).Should(gomega.BeFalse())
+		gomega.Ω(param.Pending).Should(gomega.Equal(uint64(300)))
+		activation := param.Activation
+		gomega.Ω(activation).Should(gomega.Equal(
+			instances[0].vm.LastAcceptedBlock().Tmstmp + gen.GovernanceActivationDelay,
+		))
+
+		// Only the taker fee of the pair changes once it activates
+		result, order, _ := submitAction(&actions.CreateOrder{
+			In:      ids.Empty,
+			InTick:  1_000,
+			Out:     genesisAsset,
+			OutTick: 100,
+			Supply:  200,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		waitForBlockTime(activation)
+		fill := &actions.FillOrder{
+			Order: order,
+			Owner: rsender2,
+			In:    ids.Empty,
+			Out:   genesisAsset,
+			Value: 1_000,
+		}
+		result, _, _ = submitAction(fill, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		or, err := actions.UnmarshalOrderResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(or.MakerFee).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(or.TakerFee).Should(gomega.Equal(uint64(3)))
+		gomega.Ω(or.MakerRebate).Should(gomega.Equal(uint64(0)))
+
+		// A second change keeps the first in effect until it activates
+		result, proposalID, _ = submitAction(&actions.Propose{
+			Param: actions.ParamPairTakerFee,
+			Value: 400,
+			In:    ids.Empty,
+			Out:   genesisAsset,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.Vote{
+			Proposal: proposalID,
+			Support:  true,
+			Weight:   1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		waitForBlockTime(instances[0].vm.LastAcceptedBlock().Tmstmp + gen.GovernanceVotingPeriod)
+		result, _, _ = submitAction(&actions.ExecuteProposal{
+			Proposal: proposalID,
+			Param:    actions.ParamPairTakerFee,
+			In:       ids.Empty,
+			Out:      genesisAsset,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		param, err = instances[0].tcli.Param(context.TODO(), "pairTakerFee", ids.Empty, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(param.ActiveSet).Should(gomega.BeTrue())
+		gomega.Ω(param.Active).Should(gomega.Equal(uint64(300)))
+		gomega.Ω(param.Pending).Should(gomega.Equal(uint64(400)))
+		gomega.Ω(param.Activation).Should(gomega.Equal(
+			instances[0].vm.LastAcceptedBlock().Tmstmp + gen.GovernanceActivationDelay,
+		))
+
+		waitForBlockTime(param.Activation)
+		result, _, _ = submitAction(fill, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		or, err = actions.UnmarshalOrderResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(or.TakerFee).Should(gomega.Equal(uint64(4)))
+
+		// Other pairs are not affected
+		param, err = instances[0].tcli.Param(context.TODO(), "pairTakerFee", genesisAsset, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(param.ActiveSet).Should(gomega.BeFalse())
+		gomega.Ω(param.Activation).Should(gomega.BeZero())
+	})
+
+	ginkgo.It("settles signed swap offer", func() {
+		genesisAsset := genesis.AssetID("GEN")
+		makerGive, err := instances[0].tcli.Balance(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		makerWant, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		takerGive, err := instances[0].tcli.Balance(context.TODO(), sender2, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		takerWant, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		offer := &actions.SwapOffer{
+			Give:       genesisAsset,
+			GiveAmount: 10,
+			Want:       ids.Empty,
+			WantAmount: 1_000,
+			Expiry:     time.Now().Unix() + 60,
+			Nonce:      1,
+		}
+		offer.Sign(instances[0].chainID, priv)
+
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			offer,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(makerGive - 10))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(makerWant + 1_000))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender2, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(takerGive + 10))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(takerWant - 1_000 - fee))
+
+		// Offer cannot be replayed
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			offer,
+			factory2,
+			uniqueTx{},
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).
+			Should(gomega.ContainSubstring("nonce already used"))
+
+		// Offer cannot be modified
+		offer.Nonce = 2
+		offer.GiveAmount = 20
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			offer,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).
+			Should(gomega.ContainSubstring("invalid signature"))
+
+		// Offer signed for another chain cannot be settled
+		offer.GiveAmount = 10
+		offer.Sign(ids.GenerateTestID(), priv)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			offer,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).
+			Should(gomega.ContainSubstring("invalid signature"))
+
+		// Offer can only be settled by its taker
+		offer.Nonce = 3
+		offer.Taker = rtreasury
+		offer.Sign(instances[0].chainID, priv)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			offer,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).
+			Should(gomega.ContainSubstring("actor is not the taker"))
+		offer.Nonce = 4
+		offer.Taker = rsender2
+		offer.Sign(instances[0].chainID, priv)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			offer,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("pays fees with sponsor", func() {
+		genesisAsset := genesis.AssetID("GEN")
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rsender3 := priv3.PublicKey()
+		sender3 := utils.Address(rsender3)
+
+		// Publish rate and fund account that only holds [genesisAsset]
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		rate := uint64(1_000_000) // 1 GEN per 1000 native units
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetSponsorRate{
+				Asset: genesisAsset,
+				Rate:  rate,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender3,
+				Asset: genesisAsset,
+				Value: 50,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(2))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(results[1].Success).Should(gomega.BeTrue())
+		published, err := instances[0].tcli.SponsorRate(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(published).Should(gomega.Equal(rate))
+
+		sponsorNative, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		sponsorAsset, err := instances[0].tcli.Balance(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Transact without any native balance
+		submit, _, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: genesisAsset,
+				Value: 1,
+			},
+			auth.NewSponsoredFactory(priv3, rsender, genesisAsset, rate),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		charge, ok := utils.MulDiv(fee, rate, 1_000_000_000, true)
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		gomega.Ω(charge).Should(gomega.BeNumerically(">", 0))
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender3, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender3, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(49 - charge))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, genesisAsset)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(sponsorAsset + charge))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(sponsorNative - fee))
+	})
+
+	ginkgo.It("pays fees with fee payer", func() {
+		priv4, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rsender4 := priv4.PublicKey()
+		sender4 := utils.Address(rsender4)
+
+		// Fund account with only enough to transfer (and not pay fees)
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender4,
+				Value: 10,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		payerBalance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		recipientBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Value: 10,
+			},
+			auth.NewDelegatedFactory(priv4, priv2),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(rsender4))
+		gomega.Ω(auth.GetSigner(tx.Auth)).Should(gomega.Equal(rsender2))
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender4, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(recipientBalance + 10))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(payerBalance - fee))
+	})
+
+	ginkgo.It("rejects rewrapped signatures", func() {
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submitAuth := func(tx *chain.Transaction, txAuth chain.Auth) error {
+			tx.Auth = txAuth
+			p := codec.NewWriter(consts.MaxInt)
+			gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+			gomega.Ω(p.Err()).To(gomega.BeNil())
+			_, err := instances[0].cli.SubmitTx(context.Background(), p.Bytes())
+			return err
+		}
+
+		// Wrap an ED25519 signature as the actor of [Delegated]
+		_, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 11,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		ed25519Auth := tx.Auth.(*auth.ED25519)
+		delegatedAuth, err := auth.NewDelegatedFactory(priv, priv2).Sign(msg, tx.Action)
+		gomega.Ω(err).Should(gomega.BeNil())
+		delegated := delegatedAuth.(*auth.Delegated)
+		gomega.Ω(delegated.AsyncVerify(msg)).Should(gomega.BeNil())
+		delegated.ActorSignature = ed25519Auth.Signature
+		gomega.Ω(delegated.AsyncVerify(msg)).Should(gomega.MatchError(auth.ErrInvalidSignature))
+		gomega.Ω(submitAuth(tx, delegated)).ShouldNot(gomega.BeNil())
+
+		// Unwrap the actor signature of [Delegated] as an ED25519 signature
+		_, tx, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 12,
+			},
+			auth.NewDelegatedFactory(priv, priv2),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		msg, err = tx.Digest(actionRegistry)
+		gomega.Ω(err).Should(gomega.BeNil())
+		unwrapped := &auth.ED25519{
+			Signer:    rsender,
+			Signature: tx.Auth.(*auth.Delegated).ActorSignature,
+		}
+		gomega.Ω(unwrapped.AsyncVerify(msg)).Should(gomega.MatchError(auth.ErrInvalidSignature))
+		gomega.Ω(submitAuth(tx, unwrapped)).ShouldNot(gomega.BeNil())
+	})
+
+	ginkgo.It("signs with secp256k1 key", func() {
+		secpPriv, err := ethcrypto.GenerateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rsender5 := auth.SECP256K1Actor(ethcrypto.PubkeyToAddress(secpPriv.PublicKey))
+		sender5 := utils.Address(rsender5)
+
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender5,
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		recipientBalance, err := instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 10,
+			},
+			auth.NewSECP256K1Factory(secpPriv),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(rsender5))
+		p := codec.NewWriter(consts.MaxInt)
+		tx.Auth.Marshal(p)
+		gomega.Ω(p.Err()).Should(gomega.BeNil())
+		unmarshaled, err := auth.UnmarshalSECP256K1(codec.NewReader(p.Bytes(), consts.MaxInt), nil)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(unmarshaled).Should(gomega.Equal(tx.Auth))
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender5, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(100_000 - 10 - fee))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(recipientBalance + 10))
+	})
+
+	ginkgo.It("acts with session key", func() {
+		sessionPriv, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rsession := sessionPriv.PublicKey()
+		session := utils.Address(rsession)
+		transferType, _, _, ok := tconsts.ActionRegistry.LookupType(&actions.Transfer{})
+		gomega.Ω(ok).Should(gomega.BeTrue())
+
+		// Register session key that can only transfer
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		expiry := time.Now().Unix() + 600
+		limit := uint64(100_000)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RegisterSession{
+				Key:     rsession,
+				Expiry:  expiry,
+				Actions: []uint8{transferType},
+				Limits:  []*actions.SessionLimit{{Asset: ids.Empty, Amount: limit}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Transfer as owner
+		sessionFactory := auth.NewSessionFactory(rsender, sessionPriv, expiry)
+		ownerBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 10,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(rsender))
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(ownerBalance - 10 - fee))
+		reply, err := instances[0].tcli.Session(context.TODO(), sender, session)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reply.Expiry).Should(gomega.Equal(expiry))
+		gomega.Ω(reply.Limits).Should(gomega.HaveLen(1))
+		remaining := limit - 10 - fee
+		gomega.Ω(reply.Limits[0].Amount).Should(gomega.Equal(remaining))
+
+		// Cannot perform other actions
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateOrder{
+				In:      ids.Empty,
+				InTick:  1,
+				Out:     ids.Empty,
+				OutTick: 1,
+				Supply:  1,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring("action not allowed for session key"))
+
+		// Cannot spend more than the remaining limit
+		submit, _, fee, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: remaining,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(string(results[0].Output)).
+			Should(gomega.ContainSubstring("session key spending limit exceeded"))
+		reply, err = instances[0].tcli.Session(context.TODO(), sender, session)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reply.Limits[0].Amount).Should(gomega.Equal(remaining - fee))
+
+		// Revoke session key
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RevokeSession{
+				Key: rsession,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		reply, err = instances[0].tcli.Session(context.TODO(), sender, session)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reply).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("recovers account with guardians", func() {
+		priv6, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rsender6 := priv6.PublicKey()
+		sender6 := utils.Address(rsender6)
+		factory6 := auth.NewED25519Factory(priv6)
+		newPriv, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		newKey := newPriv.PublicKey()
+
+		// Fund account
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender6,
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Set guardians
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.SetGuardians{
+				Guardians: []crypto.PublicKey{rsender, rsender2},
+				Threshold: 2,
+			},
+			factory6,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Register session key
+		sessionPriv, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		transferType, _, _, ok := tconsts.ActionRegistry.LookupType(&actions.Transfer{})
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		expiry := time.Now().Unix() + 600
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.RegisterSession{
+				Key:     sessionPriv.PublicKey(),
+				Expiry:  expiry,
+				Actions: []uint8{transferType},
+				Limits:  []*actions.SessionLimit{{Asset: ids.Empty, Amount: 10_000}},
+			},
+			factory6,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		approve := func() []*actions.RecoveryApproval {
+			recovery, err := instances[0].tcli.Recovery(context.TODO(), sender6)
+			gomega.Ω(err).Should(gomega.BeNil())
+			digest := actions.RecoveryDigest(instances[0].chainID, rsender6, newKey, recovery.Nonce)
+			return []*actions.RecoveryApproval{
+				{Guardian: rsender, Signature: crypto.Sign(digest, priv)},
+				{Guardian: rsender2, Signature: crypto.Sign(digest, priv2)},
+			}
+		}
+		initiate := func(approvals []*actions.RecoveryApproval) *chain.Result {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				nil,
+				&actions.InitiateRecovery{
+					Account:   rsender6,
+					Key:       newKey,
+					Approvals: approvals,
+				},
+				factory,
+				uniqueTx{},
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept()
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			return results[0]
+		}
+
+		// Initiate and cancel recovery
+		approvals := approve()
+		gomega.Ω(initiate(approvals).Success).Should(gomega.BeTrue())
+		recovery, err := instances[0].tcli.Recovery(context.TODO(), sender6)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(recovery.Pending).Should(gomega.BeTrue())
+		gomega.Ω(recovery.NewKey).Should(gomega.Equal(utils.Address(newKey)))
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CancelRecovery{},
+			factory6,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Approvals can't be replayed
+		result := initiate(approvals)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("invalid signature"))
+
+		// Recover account
+		gomega.Ω(initiate(approve()).Success).Should(gomega.BeTrue())
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CompleteRecovery{
+				Account: rsender6,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		recovery, err = instances[0].tcli.Recovery(context.TODO(), sender6)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(recovery.Pending).Should(gomega.BeFalse())
+		gomega.Ω(recovery.Key).Should(gomega.Equal(utils.Address(newKey)))
+
+		// Old key can no longer sign for account
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			factory6,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring("key does not control account"))
+
+		// Session keys registered by old key can no longer sign for account
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewSessionFactory(rsender6, sessionPriv, expiry),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring("key does not control account"))
+
+		// New key can spend balance of account
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender6, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, _, fee, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewRecoveredFactory(rsender6, newPriv),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender6, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - 1 - fee))
+
+		// Swap offers signed by old key can no longer be settled
+		offer := &actions.SwapOffer{
+			Give:       ids.Empty,
+			GiveAmount: 10,
+			Want:       genesis.AssetID("GEN"),
+			WantAmount: 1,
+			Expiry:     time.Now().Unix() + 60,
+			Nonce:      1,
+		}
+		offer.Sign(instances[0].chainID, priv6)
+		result, _, _ = submitAction(offer, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("invalid signature"))
+
+		// Swap offers signed by new key can be settled
+		offer.SignFor(instances[0].chainID, rsender6, newPriv)
+		result, _, _ = submitAction(offer, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err = instances[0].tcli.Balance(context.TODO(), sender6, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - 1 - fee - 10))
+	})
+
+	ginkgo.It("registers and resolves names", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Create asset to name
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateAsset{
+				Metadata: []byte("named"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		assetID := tx.ID()
+
+		// Register name
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		result, _, fee := submitAction(&actions.RegisterName{
+			Name:    "alice",
+			Address: rsender,
+			Asset:   assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		newBalance, err := instances[0].tcli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance - fee - gen.NameFee))
+		record, err := instances[0].tcli.Resolve(context.TODO(), "alice")
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(record.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(record.Address).Should(gomega.Equal(sender))
+		gomega.Ω(record.Asset).Should(gomega.Equal(assetID))
+		expiry := record.Expiry
+		reverse, err := instances[0].tcli.ReverseResolve(context.TODO(), sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reverse.Name).Should(gomega.Equal("alice"))
+		reverse, err = instances[0].tcli.ReverseResolve(context.TODO(), assetID.String())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reverse.Name).Should(gomega.Equal("alice"))
+		record, err = instances[0].tcli.Resolve(context.TODO(), "bob")
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(record).Should(gomega.BeNil())
+
+		// Can't register a name that is taken
+		result, _, _ = submitAction(&actions.RegisterName{
+			Name:    "alice",
+			Address: rsender2,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("name already registered"))
+
+		// Can't label an account you don't control
+		result, _, _ = submitAction(&actions.RegisterName{
+			Name:    "not-bob",
+			Address: rsender2,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		reverse, err = instances[0].tcli.ReverseResolve(context.TODO(), sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reverse).Should(gomega.BeNil())
+
+		// Transfer name
+		result, _, _ = submitAction(&actions.TransferNameOwnership{
+			Name: "alice",
+			To:   rsender2,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.UpdateName{
+			Name:    "alice",
+			Address: rsender,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("wrong owner"))
+
+		// Update name
+		result, _, _ = submitAction(&actions.UpdateName{
+			Name:    "alice",
+			Address: rsender2,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		record, err = instances[0].tcli.Resolve(context.TODO(), "alice")
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(record.Owner).Should(gomega.Equal(sender2))
+		gomega.Ω(record.Address).Should(gomega.Equal(sender2))
+		gomega.Ω(record.Asset).Should(gomega.Equal(ids.Empty))
+		reverse, err = instances[0].tcli.ReverseResolve(context.TODO(), sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reverse).Should(gomega.BeNil())
+		reverse, err = instances[0].tcli.ReverseResolve(context.TODO(), sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(reverse.Name).Should(gomega.Equal("alice"))
+
+		// Anyone can renew a name
+		result, _, _ = submitAction(&actions.RenewName{
+			Name: "alice",
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		record, err = instances[0].tcli.Resolve(context.TODO(), "alice")
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(record.Expiry).Should(gomega.Equal(expiry + gen.NamePeriod))
+	})
+
+	ginkgo.It("mints and trades nfts", func() {
+		// Create collection
+		result, collection, _ := submitAction(&actions.CreateCollection{
+			Metadata: []byte("punks"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		// Nothing has been minted yet (the client must reach the NFT methods
+		// of the server to tell)
+		nft, err := instances[0].tcli.NFT(context.TODO(), collection, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nft).Should(gomega.BeNil())
+		nfts, err := instances[0].tcli.NFTsByOwner(context.TODO(), sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.BeEmpty())
+
+		// Only the owner can mint
+		result, _, _ = submitAction(&actions.MintNFT{
+			Collection: collection,
+			ID:         1,
+			To:         rsender2,
+			Metadata:   []byte("punk #1"),
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("wrong owner"))
+		result, _, _ = submitAction(&actions.MintNFT{
+			Collection: collection,
+			ID:         1,
+			To:         rsender,
+			Metadata:   []byte("punk #1"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		nftID := actions.NFTID(collection, 1)
+		nft, err = instances[0].tcli.NFT(context.TODO(), collection, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nft.Asset).Should(gomega.Equal(nftID))
+		gomega.Ω(nft.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(nft.Metadata).Should(gomega.Equal([]byte("punk #1")))
+		info, err := instances[0].tcli.Collection(context.TODO(), collection)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(info.Supply).Should(gomega.Equal(uint64(1)))
+		nfts, err = instances[0].tcli.NFTsByOwner(context.TODO(), sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.HaveLen(1))
+		gomega.Ω(nfts[0].Asset).Should(gomega.Equal(nftID))
+
+		// Can't mint the same NFT twice
+		result, _, _ = submitAction(&actions.MintNFT{
+			Collection: collection,
+			ID:         1,
+			To:         rsender,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("nft already exists"))
+
+		// NFTs can't be moved with Transfer
+		result, _, _ = submitAction(&actions.Transfer{
+			To:    rsender2,
+			Asset: nftID,
+			Value: 1,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+
+		// Transfer NFT
+		result, _, _ = submitAction(&actions.TransferNFT{
+			Collection: collection,
+			ID:         1,
+			To:         rsender2,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		nft, err = instances[0].tcli.NFT(context.TODO(), collection, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nft.Owner).Should(gomega.Equal(sender2))
+		nfts, err = instances[0].tcli.NFTsByOwner(context.TODO(), sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.HaveLen(0))
+		nfts, err = instances[0].tcli.NFTsByOwner(context.TODO(), sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.HaveLen(1))
+
+		// List NFT on the order book
+		result, order, _ := submitAction(&actions.CreateOrder{
+			In:      ids.Empty,
+			InTick:  100,
+			Out:     nftID,
+			OutTick: 1,
+			Supply:  1,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		// Buy NFT
+		result, _, _ = submitAction(&actions.FillOrder{
+			Order: order,
+			Owner: rsender2,
+			In:    ids.Empty,
+			Out:   nftID,
+			Value: 100,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		nft, err = instances[0].tcli.NFT(context.TODO(), collection, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nft.Owner).Should(gomega.Equal(sender))
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, nftID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+		nfts, err = instances[0].tcli.NFTsByOwner(context.TODO(), sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.HaveLen(0))
+		nfts, err = instances[0].tcli.NFTsByOwner(context.TODO(), sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.HaveLen(1))
+
+		// Burn NFT
+		result, _, _ = submitAction(&actions.BurnNFT{
+			Collection: collection,
+			ID:         1,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		nft, err = instances[0].tcli.NFT(context.TODO(), collection, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nft).Should(gomega.BeNil())
+		info, err = instances[0].tcli.Collection(context.TODO(), collection)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(info.Supply).Should(gomega.Equal(uint64(0)))
+
+		// Burned NFTs can't be minted again
+		result, _, _ = submitAction(&actions.MintNFT{
+			Collection: collection,
+			ID:         1,
+			To:         rsender,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("nft already exists"))
+	})
+
+	ginkgo.It("streams payments", func() {
+		// Create asset to stream
+		result, assetID, _ := submitAction(&actions.CreateAsset{
+			Metadata: []byte("payroll"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.MintAsset{
+			To:    rsender,
+			Asset: assetID,
+			Value: 2_000_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		// Stream that is in progress
+		now := time.Now().Unix()
+		result, streamID, _ := submitAction(&actions.CreateStream{
+			Asset:     assetID,
+			Recipient: rsender2,
+			Amount:    1_000_000,
+			Start:     now - 100,
+			End:       now + 100_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1_000_000)))
+		stream, err := instances[0].tcli.Stream(context.TODO(), streamID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stream.Asset).Should(gomega.Equal(assetID))
+		gomega.Ω(stream.Sender).Should(gomega.Equal(sender))
+		gomega.Ω(stream.Recipient).Should(gomega.Equal(sender2))
+		gomega.Ω(stream.Withdrawn).Should(gomega.Equal(uint64(0)))
+
+		// Only the recipient can withdraw
+		result, _, _ = submitAction(&actions.WithdrawStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(&actions.WithdrawStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		stream, err = instances[0].tcli.Stream(context.TODO(), streamID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		withdrawn := stream.Withdrawn
+		gomega.Ω(withdrawn).Should(gomega.BeNumerically(">", 0))
+		gomega.Ω(withdrawn).Should(gomega.BeNumerically("<", 1_000_000))
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(withdrawn))
+
+		// Only the sender can cancel
+		result, _, _ = submitAction(&actions.CancelStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(&actions.CancelStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		// Whatever was streamed before the cancel can still be withdrawn
+		stream, err = instances[0].tcli.Stream(context.TODO(), streamID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		streamed := stream.Amount
+		gomega.Ω(streamed).Should(gomega.BeNumerically(">=", withdrawn))
+		gomega.Ω(stream.End).Should(gomega.BeNumerically("<=", time.Now().Unix()))
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(2_000_000 - streamed))
+		if streamed > withdrawn {
+			result, _, _ = submitAction(&actions.WithdrawStream{
+				Stream: streamID,
+				Asset:  assetID,
+			}, factory2)
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+		}
+		balance2, err = instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(streamed))
+		stream, err = instances[0].tcli.Stream(context.TODO(), streamID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stream).Should(gomega.BeNil())
+
+		// Stream that has ended can't be canceled
+		result, streamID, _ = submitAction(&actions.CreateStream{
+			Asset:     assetID,
+			Recipient: rsender2,
+			Amount:    10,
+			Start:     0,
+			End:       1,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.CancelStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("stream has ended"))
+		result, _, _ = submitAction(&actions.WithdrawStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance2, err = instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(streamed + 10))
+
+		// Nothing can be withdrawn before a stream starts
+		start := time.Now().Unix() + 3_600
+		result, streamID, _ = submitAction(&actions.CreateStream{
+			Asset:     assetID,
+			Recipient: rsender2,
+			Amount:    10,
+			Start:     start,
+			End:       start + 1,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.WithdrawStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("nothing streamed to withdraw"))
+		result, _, _ = submitAction(&actions.CancelStream{
+			Stream: streamID,
+			Asset:  assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		stream, err = instances[0].tcli.Stream(context.TODO(), streamID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stream).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("claims airdrops", func() {
+		// Create asset to airdrop
+		result, assetID, _ := submitAction(&actions.CreateAsset{
+			Metadata: []byte("drop"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.MintAsset{
+			To:    rsender,
+			Asset: assetID,
+			Value: 1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		// Build tree
+		other, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		root, proofs, err := actions.BuildAirdropTree([]ids.ID{
+			actions.AirdropLeaf(rsender2, 100),
+			actions.AirdropLeaf(other.PublicKey(), 200),
+			actions.AirdropLeaf(rsender, 50),
+		})
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(proofs).Should(gomega.HaveLen(3))
+		expiry := time.Now().Add(3 * time.Second).Unix()
+		result, airdropID, _ := submitAction(&actions.CreateAirdrop{
+			Asset:  assetID,
+			Amount: 350,
+			Root:   root,
+			Expiry: expiry,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(650)))
+
+		// Claim with the wrong amount
+		result, _, _ = submitAction(&actions.ClaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+			Amount:  200,
+			Proof:   proofs[0],
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("invalid proof"))
+
+		// Claim
+		result, _, _ = submitAction(&actions.ClaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+			Amount:  100,
+			Proof:   proofs[0],
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(uint64(100)))
+		airdrop, err := instances[0].tcli.Airdrop(context.TODO(), airdropID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(airdrop.Root).Should(gomega.Equal(root))
+		gomega.Ω(airdrop.Creator).Should(gomega.Equal(sender))
+		gomega.Ω(airdrop.Remaining).Should(gomega.Equal(uint64(250)))
+		gomega.Ω(airdrop.Claimed).Should(gomega.BeTrue())
+
+		// Can only claim once
+		result, _, _ = submitAction(&actions.ClaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+			Amount:  100,
+			Proof:   proofs[0],
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("airdrop already claimed"))
+
+		// Can't reclaim before expiry
+		result, _, _ = submitAction(&actions.ReclaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("airdrop has not expired"))
+
+		// Wait for airdrop to expire
+		waitForBlockTime(expiry)
+		result, _, _ = submitAction(&actions.ClaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+			Amount:  50,
+			Proof:   proofs[2],
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("airdrop expired"))
+		result, _, _ = submitAction(&actions.ReclaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(&actions.ReclaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   assetID,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance, err = instances[0].tcli.Balance(context.TODO(), sender, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(900)))
+		airdrop, err = instances[0].tcli.Airdrop(context.TODO(), airdropID, "")
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(airdrop).Should(gomega.BeNil())
+	})
+
+	ginkgo.It("resolves escrows", func() {
+		// Fund arbiter and create asset to escrow
+		arbiter, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rarbiter := arbiter.PublicKey()
+		arbiterFactory := auth.NewED25519Factory(arbiter)
+		result, _, _ := submitAction(&actions.Transfer{
+			To:    rarbiter,
+			Value: 1_000_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, assetID, _ := submitAction(&actions.CreateAsset{
+			Metadata: []byte("escrow"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.MintAsset{
+			To:    rsender,
+			Asset: assetID,
+			Value: 1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		createEscrow := func(deadline int64, releaseOnTimeout bool) ids.ID {
+			result, escrowID, _ := submitAction(&actions.CreateEscrow{
+				Asset:            assetID,
+				Payee:            rsender2,
+				Arbiter:          rarbiter,
+				Amount:           100,
+				Deadline:         deadline,
+				ReleaseOnTimeout: releaseOnTimeout,
+			}, factory)
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+			return escrowID
+		}
+		deadline := time.Now().Add(time.Hour).Unix()
+
+		// Parties must be different
+		result, _, _ = submitAction(&actions.CreateEscrow{
+			Asset:    assetID,
+			Payee:    rsender2,
+			Arbiter:  rsender2,
+			Amount:   100,
+			Deadline: deadline,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("must be different"))
+
+		// Payer releases to payee
+		escrowID := createEscrow(deadline, false)
+		escrow, err := instances[0].tcli.Escrow(context.TODO(), escrowID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(escrow.Payer).Should(gomega.Equal(sender))
+		gomega.Ω(escrow.Payee).Should(gomega.Equal(sender2))
+		gomega.Ω(escrow.Arbiter).Should(gomega.Equal(utils.Address(rarbiter)))
+		gomega.Ω(escrow.Amount).Should(gomega.Equal(uint64(100)))
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender2,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender2,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance2, err := instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(uint64(100)))
+		escrow, err = instances[0].tcli.Escrow(context.TODO(), escrowID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(escrow).Should(gomega.BeNil())
+
+		// Payee refunds payer
+		escrowID = createEscrow(deadline, true)
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance, err := instances[0].tcli.Balance(context.TODO(), sender, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(900)))
+
+		// Arbiter decides
+		escrowID = createEscrow(deadline, false)
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rarbiter,
+		}, arbiterFactory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("recipient is not the payer or payee"))
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender2,
+		}, arbiterFactory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance2, err = instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(uint64(200)))
+
+		// Default outcome after deadline
+		timeout := time.Now().Add(3 * time.Second).Unix()
+		escrowID = createEscrow(timeout, true)
+		waitForBlockTime(timeout)
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender,
+		}, arbiterFactory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(&actions.ResolveEscrow{
+			Escrow:    escrowID,
+			Asset:     assetID,
+			Recipient: rsender2,
+		}, arbiterFactory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		balance2, err = instances[0].tcli.Balance(context.TODO(), sender2, assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance2).Should(gomega.Equal(uint64(300)))
+	})
+
+	ginkgo.It("updates price feeds", func() {
+		// Decimals are capped
+		result, _, _ := submitAction(&actions.CreateFeed{
+			Decimals: actions.MaxFeedDecimals + 1,
+			Metadata: []byte("TKN/USD"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("invalid decimals"))
+
+		// Create feed
+		result, feedID, _ := submitAction(&actions.CreateFeed{
+			Decimals: 8,
+			Metadata: []byte("TKN/USD"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		feed, err := instances[0].tcli.Feed(context.TODO(), feedID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(feed.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(feed.Decimals).Should(gomega.Equal(uint8(8)))
+		gomega.Ω(feed.Price).Should(gomega.Equal(uint64(0)))
+		gomega.Ω(feed.Metadata).Should(gomega.Equal([]byte("TKN/USD")))
+		feed, err = instances[0].tcli.Feed(context.TODO(), ids.GenerateTestID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(feed).Should(gomega.BeNil())
+
+		// Only the owner can update the feed
+		result, _, _ = submitAction(&actions.UpdateFeed{
+			Feed:  feedID,
+			Price: 100_000_000,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("wrong owner"))
+		result, _, _ = submitAction(&actions.UpdateFeed{
+			Feed:  ids.GenerateTestID(),
+			Price: 100_000_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("feed is missing"))
+
+		// Update feed (in blocks with different timestamps)
+		prices := []uint64{100_000_000, 105_000_000, 98_000_000}
+		for i, price := range prices {
+			if i > 0 {
+				waitForBlockTime(instances[0].vm.LastAcceptedBlock().Tmstmp + 1)
+			}
+			result, _, _ = submitAction(&actions.UpdateFeed{
+				Feed:  feedID,
+				Price: price,
+			}, factory)
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+		}
+		feed, err = instances[0].tcli.Feed(context.TODO(), feedID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(feed.Price).Should(gomega.Equal(uint64(98_000_000)))
+		gomega.Ω(feed.Timestamp).Should(gomega.BeNumerically(">", 0))
+
+		// Query history
+		history, err := instances[0].tcli.FeedHistory(context.TODO(), feedID, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(history).Should(gomega.HaveLen(3))
+		for i, price := range prices {
+			gomega.Ω(history[i].Price).Should(gomega.Equal(price))
+		}
+		gomega.Ω(history[2].Timestamp).Should(gomega.Equal(feed.Timestamp))
+		history, err = instances[0].tcli.FeedHistory(context.TODO(), feedID, 2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(history).Should(gomega.HaveLen(2))
+		gomega.Ω(history[0].Price).Should(gomega.Equal(uint64(105_000_000)))
+	})
+
+	ginkgo.It("triggers orders", func() {
+		parser, err := instances[0].tcli.Parser(context.Background())
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		// Fund keeper and create assets to trade
+		keeper, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rkeeper := keeper.PublicKey()
+		keeperFactory := auth.NewED25519Factory(keeper)
+		result, _, _ := submitAction(&actions.Transfer{
+			To:    rkeeper,
+			Value: 1_000_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, inAssetID, _ := submitAction(&actions.CreateAsset{
+			Metadata: []byte("trigger in"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, outAssetID, _ := submitAction(&actions.CreateAsset{
+			Metadata: []byte("trigger out"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.MintAsset{
+			To:    rsender2,
+			Asset: inAssetID,
+			Value: 1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.MintAsset{
+			To:    rsender,
+			Asset: outAssetID,
+			Value: 1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, orderID, _ := submitAction(&actions.CreateOrder{
+			In:      inAssetID,
+			InTick:  10,
+			Out:     outAssetID,
+			OutTick: 1,
+			Supply:  100,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		checkBalance := func(addr string, asset ids.ID, expected uint64) {
+			balance, err := instances[0].tcli.Balance(context.TODO(), addr, asset)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(balance).Should(gomega.Equal(expected))
+		}
+
+		// Reject trigger orders without a limit
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			nil,
+			&actions.CreateTriggerOrder{
+				In:         inAssetID,
+				Out:        outAssetID,
+				Value:      55,
+				TriggerIn:  10,
+				TriggerOut: 1,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).ShouldNot(gomega.BeNil())
+
+		// Trigger on last trade
+		result, triggerID, _ := submitAction(&actions.CreateTriggerOrder{
+			In:         inAssetID,
+			Out:        outAssetID,
+			Value:      55,
+			Reward:     5,
+			MinOut:     5,
+			TriggerIn:  10,
+			TriggerOut: 1,
+			Above:      true,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		checkBalance(sender2, inAssetID, 940)
+		triggers, err := instances[0].tcli.TriggerOrders(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(triggers).Should(gomega.HaveLen(1))
+		gomega.Ω(triggers[0].ID).Should(gomega.Equal(triggerID))
+		gomega.Ω(triggers[0].Owner).Should(gomega.Equal(sender2))
+		trigger := &actions.TriggerOrder{
+			Trigger:    triggerID,
+			Owner:      rsender2,
+			In:         inAssetID,
+			Out:        outAssetID,
+			Order:      orderID,
+			OrderOwner: rsender,
+		}
+		result, _, _ = submitAction(trigger, keeperFactory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("trigger condition not met"))
+		result, _, _ = submitAction(&actions.FillOrder{
+			Order: orderID,
+			Owner: rsender,
+			In:    inAssetID,
+			Out:   outAssetID,
+			Value: 10,
+		}, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		exists, lastIn, lastOut, err := instances[0].tcli.LastTrade(context.TODO(), inAssetID, outAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(lastIn).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(lastOut).Should(gomega.Equal(uint64(1)))
+		result, _, _ = submitAction(trigger, keeperFactory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		or, err := actions.UnmarshalOrderResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(or.In).Should(gomega.Equal(uint64(50)))
+		gomega.Ω(or.Out).Should(gomega.Equal(uint64(5)))
+		checkBalance(sender2, inAssetID, 935)
+		checkBalance(sender2, outAssetID, 6)
+		checkBalance(utils.Address(rkeeper), inAssetID, 5)
+		triggers, err = instances[0].tcli.TriggerOrders(context.TODO())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(triggers).Should(gomega.HaveLen(0))
+		orders, err := instances[0].tcli.Orders(context.TODO(), actions.PairID(inAssetID, outAssetID))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(orders).Should(gomega.HaveLen(1))
+		gomega.Ω(orders[0].Remaining).Should(gomega.Equal(uint64(94)))
+
+		// Trigger on price feed
+		result, feedID, _ := submitAction(&actions.CreateFeed{
+			Decimals: 2,
+			Metadata: []byte("trigger"),
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(&actions.UpdateFeed{
+			Feed:  feedID,
+			Price: 1_000,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		createFeedTrigger := func(minOut uint64) ids.ID {
+			result, triggerID, _ := submitAction(&actions.CreateTriggerOrder{
+				In:         inAssetID,
+				Out:        outAssetID,
+				Value:      100,
+				MinOut:     minOut,
+				Feed:       feedID,
+				TriggerIn:  900,
+				TriggerOut: 1,
+			}, factory2)
+			gomega.Ω(result.Success).Should(gomega.BeTrue())
+			return triggerID
+		}
+		triggerID = createFeedTrigger(11)
+		checkBalance(sender2, inAssetID, 835)
+		trigger = &actions.TriggerOrder{
+			Trigger:    triggerID,
+			Owner:      rsender2,
+			In:         inAssetID,
+			Out:        outAssetID,
+			Feed:       feedID,
+			Order:      orderID,
+			OrderOwner: rsender,
+		}
+		result, _, _ = submitAction(trigger, keeperFactory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("trigger condition not met"))
+		result, _, _ = submitAction(&actions.UpdateFeed{
+			Feed:  feedID,
+			Price: 800,
+		}, factory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		result, _, _ = submitAction(trigger, keeperFactory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("insufficient output"))
+
+		// Cancel trigger order
+		cancel := &actions.CancelTriggerOrder{
+			Trigger: triggerID,
+			In:      inAssetID,
+		}
+		result, _, _ = submitAction(cancel, keeperFactory)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("unauthorized"))
+		result, _, _ = submitAction(cancel, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		checkBalance(sender2, inAssetID, 935)
+		result, _, _ = submitAction(cancel, factory2)
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).Should(gomega.ContainSubstring("trigger order is missing"))
+
+		// Trigger once the minimum output can be met
+		trigger.Trigger = createFeedTrigger(10)
+		result, _, _ = submitAction(trigger, keeperFactory)
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		checkBalance(sender2, inAssetID, 835)
+		checkBalance(sender2, outAssetID, 16)
+	})
+})
+
+func expectBlk(i instance) func() []*chain.Result {
+	ctx := context.TODO()
+
+	// manually signal ready
+	i.vm.Builder().TriggerBuild()
+	// manually ack ready sig as in engine
+	<-i.toEngine
+
+	blk, err := i.vm.BuildBlock(ctx)
+	gomega.Ω(err).To(gomega.BeNil())
+	gomega.Ω(blk).To(gomega.Not(gomega.BeNil()))
+
+	gomega.Ω(blk.Verify(ctx)).To(gomega.BeNil())
+	gomega.Ω(blk.Status()).To(gomega.Equal(choices.Processing))
+
+	err = i.vm.SetPreference(ctx, blk.ID())
+	gomega.Ω(err).To(gomega.BeNil())
+
+	return func() []*chain.Result {
+		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
+		gomega.Ω(blk.Status()).To(gomega.Equal(choices.Accepted))
+
+		lastAccepted, err := i.vm.LastAccepted(ctx)
+		gomega.Ω(err).To(gomega.BeNil())
+		gomega.Ω(lastAccepted).To(gomega.Equal(blk.ID()))
+		return blk.(*chain.StatelessBlock).Results()
+	}
+}
+
+// TODO: unify with expectBlk
+func expectBlkWithContext(i instance) func() []*chain.Result {
+	ctx := context.TODO()
+
+	// manually signal ready
+	i.vm.Builder().TriggerBuild()
+	// manually ack ready sig as in engine
+	<-i.toEngine
+
+	bctx := &block.Context{PChainHeight: 1}
+	blk, err := i.vm.BuildBlockWithContext(ctx, bctx)
+	gomega.Ω(err).To(gomega.BeNil())
+	gomega.Ω(blk).To(gomega.Not(gomega.BeNil()))
+	cblk := blk.(block.WithVerifyContext)
+
+	gomega.Ω(cblk.VerifyWithContext(ctx, bctx)).To(gomega.BeNil())
+	gomega.Ω(blk.Status()).To(gomega.Equal(choices.Processing))
+
+	err = i.vm.SetPreference(ctx, blk.ID())
+	gomega.Ω(err).To(gomega.BeNil())
+
+	return func() []*chain.Result {
+		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
+		gomega.Ω(blk.Status()).To(gomega.Equal(choices.Accepted))
+
+		lastAccepted, err := i.vm.LastAccepted(ctx)
+		gomega.Ω(err).To(gomega.BeNil())
+		gomega.Ω(lastAccepted).To(gomega.Equal(blk.ID()))
+		return blk.(*chain.StatelessBlock).Results()
+	}
+}
+
+// waitForBlockTime waits until the next block built (which is stamped with the
+// current time) will have a timestamp of at least [t].
+func waitForBlockTime(t int64) {
+	for time.Now().Unix() < t {
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// uniqueTx makes otherwise identical transactions generated within the same
+// second distinct (so they are not rejected as duplicates) by moving the
+// expiry of each one back by a different number of seconds.
+type uniqueTx struct{}
+
+var uniqueTxOffset int64
+
+func (uniqueTx) Base(b *chain.Base) {
+	uniqueTxOffset = uniqueTxOffset%30 + 1
+	b.Timestamp -= uniqueTxOffset
+}
+
+// submitAction issues [action] from [authFactory] in its own block and
+// returns the result, ID, and fee of the transaction that carried it.
+func submitAction(action chain.Action, authFactory chain.AuthFactory) (*chain.Result, ids.ID, uint64) {
+	parser, err := instances[0].tcli.Parser(context.Background())
+	gomega.Ω(err).Should(gomega.BeNil())
+	submit, tx, fee, err := instances[0].cli.GenerateTransaction(
+		context.Background(),
+		parser,
+		nil,
+		action,
+		authFactory,
+		uniqueTx{},
+	)
+	gomega.Ω(err).Should(gomega.BeNil())
+	gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+	accept := expectBlk(instances[0])
+	results := accept()
+	gomega.Ω(results).Should(gomega.HaveLen(1))
+	return results[0], tx.ID(), fee
+}
+
+// signWarpMessage signs [uwm] (sent from [sourceChainID]) with every
+// validator of the source subnet.
+func signWarpMessage(uwm *warp.UnsignedMessage) *warp.Message {
+	sig := &warp.BitSetSignature{Signers: set.NewBits(0).Bytes()}
+	copy(sig.Signature[:], bls.SignatureToBytes(bls.Sign(sourceSigner, uwm.Bytes())))
+	wm, err := warp.NewMessage(uwm, sig)
+	gomega.Ω(err).Should(gomega.BeNil())
+	return wm
+}
+
+// settleFees sends everything in the fee pool of [asset] to the treasury.
+func settleFees(asset ids.ID) {
+	parser, err := instances[0].tcli.Parser(context.Background())
+	gomega.Ω(err).Should(gomega.BeNil())
+	submit, _, _, err := instances[0].cli.GenerateTransaction(
+		context.Background(),
+		parser,
+		nil,
+		&actions.SettleFees{
+			Asset:        asset,
+			Treasury:     rtreasury,
+			FeeRecipient: rtreasury,
+		},
+		settler,
+		uniqueTx{},
+	)
+	gomega.Ω(err).Should(gomega.BeNil())
+	gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+	accept := expectBlk(instances[0])
+	results := accept()
+	gomega.Ω(results).Should(gomega.HaveLen(1))
+	gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 }
 
 var _ common.AppSender = &appSender{}
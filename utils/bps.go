@@ -0,0 +1,43 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"math"
+	"math/bits"
+)
+
+// MaxBasisPoints is 100%.
+const MaxBasisPoints = 10_000
+
+// BasisPoints returns [bps] basis points of [amount], rounded down.
+func BasisPoints(amount uint64, bps uint64) uint64 {
+	if bps >= MaxBasisPoints {
+		return amount
+	}
+	// [amount] * [bps] may overflow a uint64, so we perform the multiplication
+	// with 128 bits. The quotient is always less than [amount], so [Div64] will
+	// not panic.
+	hi, lo := bits.Mul64(amount, bps)
+	share, _ := bits.Div64(hi, lo, MaxBasisPoints)
+	return share
+}
+
+// MulDiv returns [a] * [b] / [c] computed with 128 bits. If [roundUp] is true,
+// any remainder rounds the result up. It returns false if the result does not
+// fit in a uint64 (or [c] is 0).
+func MulDiv(a uint64, b uint64, c uint64, roundUp bool) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= c {
+		return 0, false
+	}
+	quo, rem := bits.Div64(hi, lo, c)
+	if roundUp && rem > 0 {
+		if quo == math.MaxUint64 {
+			return 0, false
+		}
+		quo++
+	}
+	return quo, true
+}